@@ -19,6 +19,8 @@ package main
 import (
 	// The set of controllers this controller process runs.
 	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereaction"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vspheresink"
 
 	// This defines the shared main for injected controllers.
 	"knative.dev/pkg/injection/sharedmain"
@@ -27,5 +29,7 @@ import (
 func main() {
 	sharedmain.Main("controller",
 		vsphere.NewController,
+		vspheresink.NewController,
+		vsphereaction.NewController,
 	)
 }
@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"os"
 
 	// Uncomment if you want to run locally against remote GKE cluster.
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -32,7 +33,12 @@ import (
 
 func main() {
 	ctx := signals.NewContext()
-	kc := kubernetes.NewForConfigOrDie(sharedmain.ParseAndGetConfigOrDie())
-	ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
+	// We only need a cluster when we keep our checkpoint in it, so that the
+	// adapter can run locally without one.  Replaying a recording doesn't
+	// keep a checkpoint at all.
+	if os.Getenv("VSPHERE_REPLAY") == "" && vsphere.KVStoreNeedsKubernetes(os.Getenv("VSPHERE_KVSTORE")) {
+		kc := kubernetes.NewForConfigOrDie(sharedmain.ParseAndGetConfigOrDie())
+		ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
+	}
 	adapter.MainWithContext(ctx, "vspheresource", vsphere.NewEnvConfig, vsphere.NewAdapter)
 }
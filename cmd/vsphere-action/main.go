@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// vsphere-action is the executor of a VSphereAction, which performs an
+// operation in vCenter for the CloudEvents posted to it.  It is bound to
+// its vCenter by a VSphereBinding.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/vmware/govmomi/vapi/tags"
+	"go.uber.org/zap"
+	"knative.dev/pkg/signals"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/action"
+)
+
+func main() {
+	var env action.EnvConfig
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatalf("Error processing env var: %v", err)
+	}
+	af, op, err := env.Parse()
+	if err != nil {
+		log.Fatalf("Error parsing the action: %v", err)
+	}
+	filter, err := action.NewFilter(af)
+	if err != nil {
+		log.Fatalf("Error parsing the action: %v", err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Unable to create logger: %v", err)
+	}
+	defer logger.Sync()
+	ctx := signals.NewContext()
+
+	// Instantiate a client for interacting with the vSphere APIs.
+	client, err := vsphere.New(ctx)
+	if err != nil {
+		logger.Fatal("Unable to connect to vSphere", zap.Error(err))
+	}
+	defer client.Logout(context.Background())
+
+	executor := &action.Executor{
+		Logger:    logger.Sugar(),
+		Client:    client.Client,
+		Source:    env.Source,
+		Scope:     env.Scope,
+		Filter:    filter,
+		Operation: *op,
+		DryRun:    env.DryRun,
+	}
+	// Tags are managed through the vAPI, which needs a session of its own.
+	if op.AttachTag != nil || op.DetachTag != nil {
+		restclient, err := vsphere.NewREST(ctx)
+		if err != nil {
+			logger.Fatal("Unable to connect to the vSphere REST API", zap.Error(err))
+		}
+		defer restclient.Logout(context.Background())
+		executor.Tags = tags.NewManager(restclient)
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", env.Port),
+		Handler: executor,
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		// Finish the operations that we have already started.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("Receiving events", zap.Int("port", env.Port), zap.String("operation", action.Name(*op)))
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Fatal("Failed to receive events", zap.Error(err))
+	}
+	<-done
+}
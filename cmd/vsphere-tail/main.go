@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// vsphere-tail streams the events of a vCenter as the CloudEvents that a
+// VSphereSource would send for them, printing them as JSON lines (or posting
+// them to a URL), so that filters can be tried out without deploying
+// anything.
+//
+//	go run ./cmd/vsphere-tail --url https://vcenter.example.com/sdk \
+//	  --since 1h --type VmPoweredOnEvent --filter 'event.Vm.Name.startsWith("web-")'
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/soap"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+var (
+	address  = flag.String("url", os.Getenv("GOVC_URL"), "The URL of the vCenter's SDK, e.g. https://vcenter.example.com/sdk. Defaults to $GOVC_URL.")
+	insecure = flag.Bool("insecure", envBool("GOVC_INSECURE"), "Whether to skip verifying the vCenter's certificate. Defaults to $GOVC_INSECURE.")
+	username = flag.String("username", os.Getenv("GOVC_USERNAME"), "The username to log in to the vCenter with, unless the URL has one. Defaults to $GOVC_USERNAME.")
+	password = flag.String("password", os.Getenv("GOVC_PASSWORD"), "The password to log in to the vCenter with. Defaults to $GOVC_PASSWORD.")
+
+	since  = flag.String("since", "", "Start from the events since this time, as RFC3339 or a duration ago (e.g. 1h), rather than from now.")
+	scope  = flag.String("scope", "", "The inventory path of the entity whose events (and those of the entities beneath it) are streamed, e.g. /dc1/vm/prod.")
+	filter = flag.String("filter", "", "A CEL filter expression, as in a VSphereSource's filter.")
+	sink   = flag.String("sink", "", "Post the events to this URL, rather than print them.")
+
+	record = flag.String("record", "", "Append the raw events (before filtering) to this file, as JSON lines, to be replayed later.")
+	replay = flag.String("replay", "", "Play back the events recorded in this file, rather than connecting to a vCenter.")
+	speed  = flag.Float64("speed", 1, "How many times faster than they originally happened to play back the recorded events, or 0 for without pausing.")
+
+	eventTypes typesFlag
+)
+
+func init() {
+	flag.Var(&eventTypes, "type", "Only stream events of this vSphere event type, e.g. VmPoweredOnEvent. May be repeated or comma separated.")
+}
+
+// envBool returns the value of the boolean environment variable, which is
+// false when it is unset (or not a boolean), as govc treats it.
+func envBool(key string) bool {
+	b, _ := strconv.ParseBool(os.Getenv(key))
+	return b
+}
+
+// typesFlag collects the values of a repeatable, comma separated flag.
+type typesFlag []string
+
+func (tf *typesFlag) String() string {
+	return strings.Join(*tf, ",")
+}
+
+func (tf *typesFlag) Set(value string) error {
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			*tf = append(*tf, t)
+		}
+	}
+	return nil
+}
+
+// parseSince parses the --since flag, relative to now.
+func parseSince(raw string, now time.Time) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since must be an RFC3339 time or a duration, got %q", raw)
+	}
+	return now.Add(-d), nil
+}
+
+// printClient is a cloudevents.Client that prints the events that it sends
+// as JSON lines.
+type printClient struct {
+	cloudevents.Client
+	enc *json.Encoder
+}
+
+func (pc *printClient) Send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	return pc.enc.Encode(event)
+}
+
+func newClient() (cloudevents.Client, error) {
+	if *sink == "" {
+		return &printClient{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+	p, err := cloudevents.NewHTTP(cloudevents.WithTarget(*sink))
+	if err != nil {
+		return nil, err
+	}
+	return cloudevents.NewClient(p, cloudevents.WithTimeNow(), cloudevents.WithUUIDs())
+}
+
+func main() {
+	flag.Parse()
+
+	if *address == "" && *replay == "" {
+		log.Fatal("--url (or $GOVC_URL) is required")
+	}
+	start, err := parseSince(*since, time.Now())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Log to stderr, so that stdout only has the events.
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatalf("Unable to create logger: %v", err)
+	}
+	ctx := logging.WithLogger(signals.NewContext(), logger.Sugar())
+
+	opts := vsphere.TailOptions{
+		Source:      *address,
+		Filter:      *filter,
+		Types:       eventTypes,
+		Scope:       *scope,
+		Since:       start,
+		ReplaySpeed: *speed,
+	}
+	if *record != "" {
+		f, err := os.OpenFile(*record, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("Unable to open --record: %v", err)
+		}
+		defer f.Close()
+		opts.Record = f
+	}
+
+	var vClient *govmomi.Client
+	if *replay != "" {
+		f, err := os.Open(*replay)
+		if err != nil {
+			log.Fatalf("Unable to open --replay: %v", err)
+		}
+		defer f.Close()
+		opts.Replay = f
+	} else {
+		u, err := soap.ParseURL(*address)
+		if err != nil {
+			log.Fatalf("Unable to parse --url: %v", err)
+		}
+		if u.User == nil && *username != "" {
+			u.User = url.UserPassword(*username, *password)
+		}
+		vClient, err = govmomi.NewClient(ctx, u, *insecure)
+		if err != nil {
+			log.Fatalf("Unable to connect to %s: %v", *address, err)
+		}
+		defer vClient.Logout(context.Background())
+	}
+
+	ceClient, err := newClient()
+	if err != nil {
+		log.Fatalf("Unable to create CloudEvents client: %v", err)
+	}
+
+	err = vsphere.Tail(ctx, vClient, ceClient, opts)
+	if err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
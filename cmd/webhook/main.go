@@ -22,6 +22,9 @@ import (
 	"os"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
@@ -35,10 +38,13 @@ import (
 	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/psbinding"
 	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
+	defaultconfig "github.com/mattmoor/vmware-sources/pkg/apis/config"
 	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1beta1"
 	"github.com/mattmoor/vmware-sources/pkg/reconciler/vspherebinding"
 )
 
@@ -46,9 +52,17 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// List the types to validate.
 	v1alpha1.SchemeGroupVersion.WithKind("VSphereSource"):  &v1alpha1.VSphereSource{},
 	v1alpha1.SchemeGroupVersion.WithKind("VSphereBinding"): &v1alpha1.VSphereBinding{},
+	v1alpha1.SchemeGroupVersion.WithKind("VSphereSink"):    &v1alpha1.VSphereSink{},
+	v1alpha1.SchemeGroupVersion.WithKind("VSphereAction"):  &v1alpha1.VSphereAction{},
+	v1beta1.SchemeGroupVersion.WithKind("VSphereSource"):   &v1beta1.VSphereSource{},
+	v1beta1.SchemeGroupVersion.WithKind("VSphereBinding"):  &v1beta1.VSphereBinding{},
 }
 
 func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	// Decorate contexts with the current state of the config.
+	store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store.WatchConfigs(cmw)
+
 	return defaulting.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
@@ -61,11 +75,7 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-		func(ctx context.Context) context.Context {
-			// Here is where you would infuse the context with state
-			// (e.g. attach a store with configmap data)
-			return ctx
-		},
+		store.ToContext,
 
 		// Whether to disallow unknown fields.
 		true,
@@ -73,6 +83,12 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 }
 
 func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	// Decorate contexts with the current state of the config.
+	store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store.WatchConfigs(cmw)
+	nsLister := namespaceinformer.Get(ctx).Lister()
+	secretLister := secretinformer.Get(ctx).Lister()
+
 	return validation.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
@@ -85,10 +101,11 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
+		// The policy is checked against the labels of the resource's namespace,
+		// and we warn about Secrets that the resource won't be able to use.
 		func(ctx context.Context) context.Context {
-			// Here is where you would infuse the context with state
-			// (e.g. attach a store with configmap data)
-			return ctx
+			ctx = defaultconfig.WithNamespaceLister(store.ToContext(ctx), nsLister)
+			return v1alpha1.WithSecretLister(ctx, secretLister)
 		},
 
 		// Whether to disallow unknown fields.
@@ -96,6 +113,40 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	)
 }
 
+func NewConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return conversion.NewConversionController(ctx,
+
+		// The path on which to serve the webhook.
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted.
+		// v1alpha1 is the version that is stored, and that the controllers use.
+		map[schema.GroupKind]conversion.GroupKindConversion{
+			v1alpha1.Kind("VSphereSource"): {
+				DefinitionName: "vspheresources.sources.knative.dev",
+				HubVersion:     v1alpha1.SchemeGroupVersion.Version,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					v1alpha1.SchemeGroupVersion.Version: &v1alpha1.VSphereSource{},
+					v1beta1.SchemeGroupVersion.Version:  &v1beta1.VSphereSource{},
+				},
+			},
+			v1alpha1.Kind("VSphereBinding"): {
+				DefinitionName: "vspherebindings.sources.knative.dev",
+				HubVersion:     v1alpha1.SchemeGroupVersion.Version,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					v1alpha1.SchemeGroupVersion.Version: &v1alpha1.VSphereBinding{},
+					v1beta1.SchemeGroupVersion.Version:  &v1beta1.VSphereBinding{},
+				},
+			},
+		},
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		func(ctx context.Context) context.Context {
+			return ctx
+		},
+	)
+}
+
 func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	return configmaps.NewAdmissionController(ctx,
 
@@ -107,8 +158,10 @@ func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 
 		// The configmaps to validate.
 		configmap.Constructors{
-			logging.ConfigMapName(): logging.NewConfigFromConfigMap,
-			metrics.ConfigMapName(): metrics.NewObservabilityConfigFromConfigMap,
+			logging.ConfigMapName():          logging.NewConfigFromConfigMap,
+			metrics.ConfigMapName():          metrics.NewObservabilityConfigFromConfigMap,
+			defaultconfig.DefaultsConfigName: defaultconfig.NewDefaultsConfigFromConfigMap,
+			defaultconfig.PolicyConfigName:   defaultconfig.NewPolicyConfigFromConfigMap,
 		},
 	)
 }
@@ -125,12 +178,10 @@ func NewVSphereBindingWebhook(opts ...psbinding.ReconcilerOption) injection.Cont
 			// How to get all the Bindables for configuring the mutating webhook.
 			vspherebinding.ListAll,
 
-			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-			func(ctx context.Context, _ psbinding.Bindable) (context.Context, error) {
-				// Here is where you would infuse the context with state
-				// (e.g. attach a store with configmap data)
-				return ctx, nil
-			},
+			// A function that infuses the context passed to Do with the hash
+			// of the credentials.  The reconciler requeues the bindings when
+			// they change, so there is nothing for the webhook to do.
+			vspherebinding.WithContextFactory(ctx, func(k8stypes.NamespacedName) {}),
 			opts...,
 		)
 	}
@@ -153,6 +204,7 @@ func main() {
 		NewDefaultingAdmissionController,
 		NewValidationAdmissionController,
 		NewConfigValidationController,
+		NewConversionController,
 
 		// For each binding we have a controller and a binding webhook.
 		vspherebinding.NewController, NewVSphereBindingWebhook(vsbSelector),
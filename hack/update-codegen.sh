@@ -32,11 +32,27 @@ ${CODEGEN_PKG}/generate-groups.sh "deepcopy,client,informer,lister" \
   "sources:v1alpha1" \
   --go-header-file ${REPO_ROOT}/hack/boilerplate/boilerplate.go.txt
 
+# v1beta1 is only served (and converted to and from v1alpha1) by the webhook,
+# so it doesn't need a client.
+${CODEGEN_PKG}/generate-groups.sh "deepcopy" \
+  github.com/mattmoor/vmware-sources/pkg/client github.com/mattmoor/vmware-sources/pkg/apis \
+  "sources:v1beta1" \
+  --go-header-file ${REPO_ROOT}/hack/boilerplate/boilerplate.go.txt
+
+# Depends on generate-groups.sh to install bin/deepcopy-gen
+${GOPATH}/bin/deepcopy-gen --input-dirs \
+  github.com/mattmoor/vmware-sources/pkg/apis/config \
+  -O zz_generated.deepcopy \
+  --go-header-file ${REPO_ROOT}/hack/boilerplate/boilerplate.go.txt
+
 # Knative Injection
 ${KNATIVE_CODEGEN_PKG}/hack/generate-knative.sh "injection" \
   github.com/mattmoor/vmware-sources/pkg/client github.com/mattmoor/vmware-sources/pkg/apis \
   "sources:v1alpha1" \
   --go-header-file ${REPO_ROOT}/hack/boilerplate/boilerplate.go.txt
 
+# Generate the typed handlers for vSphere events.
+(cd ${REPO_ROOT}; go generate ./pkg/vsphere/events)
+
 # Make sure our dependencies are up-to-date
 ${REPO_ROOT}/hack/update-deps.sh
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+)
+
+const (
+	// DefaultsConfigName is the name of the ConfigMap holding the defaults
+	// for the fields that VSphereSources leave empty.
+	DefaultsConfigName = "config-vsphere-defaults"
+
+	// TLSModeVerify verifies the vCenter's certificate.
+	TLSModeVerify = "verify"
+
+	// TLSModeSkip skips verifying the vCenter's certificate.
+	TLSModeSkip = "skip"
+)
+
+// Defaults holds the defaults for the fields that VSphereSources leave
+// empty.
+type Defaults struct {
+	// Address is the URL of the vCenter used by sources that don't specify
+	// one (or a connectionRef).
+	Address *apis.URL
+
+	// SkipTLSVerify is used along with Address.
+	SkipTLSVerify bool
+
+	// ConnectionName is the name of the VSphereBinding (in the source's
+	// namespace) used as the connectionRef of sources that specify neither
+	// an address nor a secretRef.  It takes precedence over Address.
+	ConnectionName string
+
+	// SecretName is the name of the Secret (in the source's namespace)
+	// holding the credentials for sources that don't specify one.
+	SecretName string
+
+	// Filter is the CEL filter expression of sources that don't specify
+	// one.
+	Filter string
+
+	// AdapterResources are the resources of the adapter's container,
+	// unless the source's adapterTemplate specifies them.
+	AdapterResources corev1.ResourceRequirements
+}
+
+// NewDefaultsConfigFromMap creates a Defaults from the supplied map.
+func NewDefaultsConfigFromMap(data map[string]string) (*Defaults, error) {
+	nc := &Defaults{}
+
+	if raw, ok := data["default-address"]; ok && raw != "" {
+		u, err := apis.ParseURL(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse default-address: %w", err)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("default-address %q has no host", raw)
+		}
+		nc.Address = u
+	}
+
+	switch mode := data["default-tls-mode"]; mode {
+	case "", TLSModeVerify:
+	case TLSModeSkip:
+		nc.SkipTLSVerify = true
+	default:
+		return nil, fmt.Errorf("default-tls-mode must be %q or %q, got %q", TLSModeVerify, TLSModeSkip, mode)
+	}
+
+	for key, field := range map[string]*string{
+		"default-connection":  &nc.ConnectionName,
+		"default-secret-name": &nc.SecretName,
+	} {
+		if raw := data[key]; raw != "" {
+			if msgs := validation.IsDNS1123Subdomain(raw); len(msgs) > 0 {
+				return nil, fmt.Errorf("%s %q is not a valid name: %s", key, raw, strings.Join(msgs, ", "))
+			}
+			*field = raw
+		}
+	}
+
+	if raw := data["default-filter"]; raw != "" {
+		if _, err := expr.NewFilter(raw); err != nil {
+			return nil, fmt.Errorf("failed to compile default-filter: %w", err)
+		}
+		nc.Filter = raw
+	}
+
+	for _, r := range []struct {
+		key  string
+		list *corev1.ResourceList
+		name corev1.ResourceName
+	}{
+		{"adapter-cpu-request", &nc.AdapterResources.Requests, corev1.ResourceCPU},
+		{"adapter-memory-request", &nc.AdapterResources.Requests, corev1.ResourceMemory},
+		{"adapter-cpu-limit", &nc.AdapterResources.Limits, corev1.ResourceCPU},
+		{"adapter-memory-limit", &nc.AdapterResources.Limits, corev1.ResourceMemory},
+	} {
+		raw := data[r.key]
+		if raw == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", r.key, err)
+		}
+		if *r.list == nil {
+			*r.list = corev1.ResourceList{}
+		}
+		(*r.list)[r.name] = q
+	}
+
+	return nc, nil
+}
+
+// NewDefaultsConfigFromConfigMap creates a Defaults from the supplied
+// configMap.
+func NewDefaultsConfigFromConfigMap(config *corev1.ConfigMap) (*Defaults, error) {
+	return NewDefaultsConfigFromMap(config.Data)
+}
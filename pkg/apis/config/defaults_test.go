@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestDefaultsConfigurationFromFile(t *testing.T) {
+	cm, example := ConfigMapsFromTestFile(t, DefaultsConfigName)
+
+	if _, err := NewDefaultsConfigFromConfigMap(cm); err != nil {
+		t.Errorf("NewDefaultsConfigFromConfigMap(actual) = %v", err)
+	}
+
+	if _, err := NewDefaultsConfigFromConfigMap(example); err != nil {
+		t.Errorf("NewDefaultsConfigFromConfigMap(example) = %v", err)
+	}
+}
+
+func TestDefaultsConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantErr      bool
+		wantDefaults *Defaults
+		data         map[string]string
+	}{{
+		name:         "defaults configuration",
+		wantDefaults: &Defaults{},
+		data:         map[string]string{},
+	}, {
+		name: "specified values",
+		wantDefaults: &Defaults{
+			Address:        apis.HTTPS("vcenter.local"),
+			SkipTLSVerify:  true,
+			ConnectionName: "vcenter",
+			SecretName:     "vsphere-credentials",
+			Filter:         "true",
+			AdapterResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		data: map[string]string{
+			"default-address":      "https://vcenter.local",
+			"default-tls-mode":     "skip",
+			"default-connection":   "vcenter",
+			"default-secret-name":  "vsphere-credentials",
+			"default-filter":       "true",
+			"adapter-cpu-request":  "100m",
+			"adapter-memory-limit": "256Mi",
+		},
+	}, {
+		name:    "invalid address",
+		wantErr: true,
+		data: map[string]string{
+			"default-address": "vcenter.local",
+		},
+	}, {
+		name:    "invalid tls mode",
+		wantErr: true,
+		data: map[string]string{
+			"default-tls-mode": "sometimes",
+		},
+	}, {
+		name:    "invalid secret name",
+		wantErr: true,
+		data: map[string]string{
+			"default-secret-name": "Not_A_Name",
+		},
+	}, {
+		name:    "invalid filter",
+		wantErr: true,
+		data: map[string]string{
+			"default-filter": "eventType ==",
+		},
+	}, {
+		name:    "invalid quantity",
+		wantErr: true,
+		data: map[string]string{
+			"adapter-memory-request": "lots",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualDefaults, err := NewDefaultsConfigFromConfigMap(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: DefaultsConfigName,
+				},
+				Data: tt.data,
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDefaultsConfigFromConfigMap() error = %v, WantErr %v", err, tt.wantErr)
+			}
+			if !equality.Semantic.DeepEqual(actualDefaults, tt.wantDefaults) {
+				t.Errorf("Config mismatch: diff(-want,+got):\n%s", cmp.Diff(tt.wantDefaults, actualDefaults))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// PolicyConfigName is the name of the ConfigMap holding the policy on
+	// which vCenters the VSphereSources and VSphereBindings in each
+	// namespace may point at.
+	PolicyConfigName = "config-vsphere-policy"
+
+	// PolicyKey is the key of the ConfigMap holding the policy, as YAML.
+	PolicyKey = "policy"
+)
+
+// Policy restricts which vCenters the VSphereSources and VSphereBindings
+// in each namespace may point at.  When it has no rules, there are no
+// restrictions.  Otherwise, a resource must be allowed by one of the rules
+// whose namespaceSelector matches the labels of its namespace.
+type Policy struct {
+	Rules []PolicyRule `json:"rules,omitempty"`
+}
+
+// PolicyRule allows the resources in the namespaces that it selects to
+// point at some vCenters.
+type PolicyRule struct {
+	// NamespaceSelector selects the namespaces that the rule applies to.
+	// The empty selector selects all of them.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// AllowedHosts lists the hosts (e.g. "vcenter.example.com") of the
+	// vCenters that may be pointed at.  When it is empty, any host may be.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+
+	// RequireConnectionRef only allows VSphereSources that get their
+	// connection settings from a VSphereBinding through their
+	// connectionRef, rather than specifying an address themselves.
+	RequireConnectionRef bool `json:"requireConnectionRef,omitempty"`
+
+	// AllowedConnections lists the names of the VSphereBindings that a
+	// VSphereSource's connectionRef may name.  When it is empty, any
+	// binding may be named.
+	AllowedConnections []string `json:"allowedConnections,omitempty"`
+}
+
+// NewPolicyConfigFromMap creates a Policy from the supplied map.
+func NewPolicyConfigFromMap(data map[string]string) (*Policy, error) {
+	p := &Policy{}
+	if raw := data[PolicyKey]; raw != "" {
+		if err := yaml.Unmarshal([]byte(raw), p); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", PolicyKey, err)
+		}
+	}
+	for i := range p.Rules {
+		if _, err := metav1.LabelSelectorAsSelector(&p.Rules[i].NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector in rule %d: %w", i, err)
+		}
+	}
+	return p, nil
+}
+
+// NewPolicyConfigFromConfigMap creates a Policy from the supplied configMap.
+func NewPolicyConfigFromConfigMap(config *corev1.ConfigMap) (*Policy, error) {
+	return NewPolicyConfigFromMap(config.Data)
+}
+
+// AllowsAddress returns an error when none of the rules that apply to a
+// namespace with the given labels allow a VSphereSource (or, when source
+// is false, a VSphereBinding) to point at the vCenter at host.
+func (p *Policy) AllowsAddress(ns labels.Set, host string, source bool) error {
+	return p.allows(ns, fmt.Sprintf("vCenter %q", host), func(r *PolicyRule) bool {
+		if source && r.RequireConnectionRef {
+			return false
+		}
+		return len(r.AllowedHosts) == 0 || contains(r.AllowedHosts, host, strings.EqualFold)
+	})
+}
+
+// AllowsConnection returns an error when none of the rules that apply to a
+// namespace with the given labels allow a VSphereSource to get its
+// connection settings from the named VSphereBinding.
+func (p *Policy) AllowsConnection(ns labels.Set, name string) error {
+	return p.allows(ns, fmt.Sprintf("connection %q", name), func(r *PolicyRule) bool {
+		return len(r.AllowedConnections) == 0 || contains(r.AllowedConnections, name, func(a, b string) bool { return a == b })
+	})
+}
+
+func (p *Policy) allows(ns labels.Set, what string, allowed func(*PolicyRule) bool) error {
+	if len(p.Rules) == 0 {
+		return nil
+	}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		// We checked the selectors when we parsed the policy.
+		selector, _ := metav1.LabelSelectorAsSelector(&r.NamespaceSelector)
+		if selector.Matches(ns) && allowed(r) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not allowed in this namespace by the cluster's policy", what)
+}
+
+// contains returns whether the list has an item equal to s.
+func contains(list []string, s string, equal func(a, b string) bool) bool {
+	for _, item := range list {
+		if equal(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+type nsListerKey struct{}
+
+// WithNamespaceLister attaches the lister used to look up the labels of
+// namespaces when checking the Policy to the context.
+func WithNamespaceLister(ctx context.Context, lister corev1listers.NamespaceLister) context.Context {
+	return context.WithValue(ctx, nsListerKey{}, lister)
+}
+
+// NamespaceLabels returns the labels of the named namespace, or none when
+// the context doesn't have a lister for them.
+func NamespaceLabels(ctx context.Context, name string) (labels.Set, error) {
+	lister, ok := ctx.Value(nsListerKey{}).(corev1listers.NamespaceLister)
+	if !ok {
+		return labels.Set{}, nil
+	}
+	ns, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return labels.Set(ns.Labels), nil
+}
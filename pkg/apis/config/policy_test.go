@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestPolicyConfigurationFromFile(t *testing.T) {
+	cm, example := ConfigMapsFromTestFile(t, PolicyConfigName)
+
+	if _, err := NewPolicyConfigFromConfigMap(cm); err != nil {
+		t.Errorf("NewPolicyConfigFromConfigMap(actual) = %v", err)
+	}
+
+	if p, err := NewPolicyConfigFromConfigMap(example); err != nil {
+		t.Errorf("NewPolicyConfigFromConfigMap(example) = %v", err)
+	} else if len(p.Rules) == 0 {
+		t.Error("NewPolicyConfigFromConfigMap(example) has no rules")
+	}
+}
+
+func TestPolicyConfiguration(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantPolicy *Policy
+		data       map[string]string
+	}{{
+		name:       "no policy",
+		wantPolicy: &Policy{},
+		data:       map[string]string{},
+	}, {
+		name: "rules",
+		wantPolicy: &Policy{
+			Rules: []PolicyRule{{
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "infra"},
+				},
+				AllowedHosts: []string{"vcenter.example.com"},
+			}, {
+				RequireConnectionRef: true,
+				AllowedConnections:   []string{"shared"},
+			}},
+		},
+		data: map[string]string{
+			PolicyKey: `
+rules:
+- namespaceSelector:
+    matchLabels:
+      team: infra
+  allowedHosts: [vcenter.example.com]
+- namespaceSelector: {}
+  requireConnectionRef: true
+  allowedConnections: [shared]
+`,
+		},
+	}, {
+		name:    "not yaml",
+		wantErr: true,
+		data: map[string]string{
+			PolicyKey: "rules: [",
+		},
+	}, {
+		name:    "invalid selector",
+		wantErr: true,
+		data: map[string]string{
+			PolicyKey: `
+rules:
+- namespaceSelector:
+    matchExpressions:
+    - key: team
+      operator: Sometimes
+`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolicyConfigFromConfigMap(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: PolicyConfigName,
+				},
+				Data: tt.data,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPolicyConfigFromConfigMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantPolicy, got); diff != "" {
+				t.Errorf("NewPolicyConfigFromConfigMap() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy := &Policy{
+		Rules: []PolicyRule{{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "infra"},
+			},
+			AllowedHosts: []string{"vcenter.example.com"},
+		}, {
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "apps"},
+			},
+			AllowedHosts:         []string{"vcenter.example.com"},
+			RequireConnectionRef: true,
+			AllowedConnections:   []string{"shared"},
+		}},
+	}
+	infra := labels.Set{"team": "infra"}
+	apps := labels.Set{"team": "apps"}
+	other := labels.Set{}
+
+	tests := []struct {
+		name  string
+		check func(*Policy) error
+		want  bool
+	}{{
+		name:  "no rules",
+		check: func(*Policy) error { return (&Policy{}).AllowsAddress(other, "anything", true) },
+		want:  true,
+	}, {
+		name:  "allowed host",
+		check: func(p *Policy) error { return p.AllowsAddress(infra, "vcenter.example.com", true) },
+		want:  true,
+	}, {
+		name:  "allowed host, other case",
+		check: func(p *Policy) error { return p.AllowsAddress(infra, "VCenter.Example.com", true) },
+		want:  true,
+	}, {
+		name:  "other host",
+		check: func(p *Policy) error { return p.AllowsAddress(infra, "rogue.example.com", true) },
+	}, {
+		name:  "unselected namespace",
+		check: func(p *Policy) error { return p.AllowsAddress(other, "vcenter.example.com", false) },
+	}, {
+		name:  "source address with connectionRef required",
+		check: func(p *Policy) error { return p.AllowsAddress(apps, "vcenter.example.com", true) },
+	}, {
+		name:  "binding address with connectionRef required",
+		check: func(p *Policy) error { return p.AllowsAddress(apps, "vcenter.example.com", false) },
+		want:  true,
+	}, {
+		name:  "allowed connection",
+		check: func(p *Policy) error { return p.AllowsConnection(apps, "shared") },
+		want:  true,
+	}, {
+		name:  "other connection",
+		check: func(p *Policy) error { return p.AllowsConnection(apps, "private") },
+	}, {
+		name:  "any connection",
+		check: func(p *Policy) error { return p.AllowsConnection(infra, "private") },
+		want:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.check(policy); (err == nil) != tt.want {
+				t.Errorf("check() = %v, wanted allowed: %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceLabels(t *testing.T) {
+	ctx := context.Background()
+	if got, err := NamespaceLabels(ctx, "default"); err != nil || len(got) != 0 {
+		t.Errorf("NamespaceLabels() without lister = %v, %v", got, err)
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "default",
+			Labels: map[string]string{"team": "infra"},
+		},
+	})
+	ctx = WithNamespaceLister(ctx, corev1listers.NewNamespaceLister(indexer))
+
+	got, err := NamespaceLabels(ctx, "default")
+	if err != nil {
+		t.Fatalf("NamespaceLabels() = %v", err)
+	}
+	if want := (labels.Set{"team": "infra"}); !cmp.Equal(want, got) {
+		t.Errorf("NamespaceLabels() = %v, wanted %v", got, want)
+	}
+	if _, err := NamespaceLabels(ctx, "missing"); err == nil {
+		t.Error("NamespaceLabels(missing) = nil, wanted error")
+	}
+}
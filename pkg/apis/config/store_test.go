@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestStoreLoadWithContext(t *testing.T) {
+	store := NewStore(zap.NewNop().Sugar())
+
+	defaultsConfig := ConfigMapFromTestFile(t, DefaultsConfigName)
+	policyConfig := ConfigMapFromTestFile(t, PolicyConfigName)
+
+	store.OnConfigChanged(defaultsConfig)
+	store.OnConfigChanged(policyConfig)
+
+	config := FromContext(store.ToContext(context.Background()))
+
+	t.Run("defaults", func(t *testing.T) {
+		expected, _ := NewDefaultsConfigFromConfigMap(defaultsConfig)
+		if diff := cmp.Diff(expected, config.Defaults); diff != "" {
+			t.Errorf("Unexpected defaults config (-want, +got): %v", diff)
+		}
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		expected, _ := NewPolicyConfigFromConfigMap(policyConfig)
+		if diff := cmp.Diff(expected, config.Policy); diff != "" {
+			t.Errorf("Unexpected policy config (-want, +got): %v", diff)
+		}
+	})
+}
+
+func TestStoreImmutableConfig(t *testing.T) {
+	store := NewStore(zap.NewNop().Sugar())
+
+	store.OnConfigChanged(ConfigMapFromTestFile(t, DefaultsConfigName))
+	store.OnConfigChanged(ConfigMapFromTestFile(t, PolicyConfigName))
+
+	config := store.Load()
+
+	config.Defaults.SecretName = "mutated"
+	config.Policy.Rules = append(config.Policy.Rules, PolicyRule{})
+
+	newConfig := store.Load()
+
+	if newConfig.Defaults.SecretName == "mutated" {
+		t.Error("Defaults config is not immutable")
+	}
+	if len(newConfig.Policy.Rules) != 0 {
+		t.Error("Policy config is not immutable")
+	}
+}
+
+func TestFromContextOrDefaults(t *testing.T) {
+	if got := FromContextOrDefaults(context.Background()); got.Defaults == nil {
+		t.Error("FromContextOrDefaults() = nil Defaults")
+	} else if got.Policy == nil {
+		t.Error("FromContextOrDefaults() = nil Policy")
+	}
+}
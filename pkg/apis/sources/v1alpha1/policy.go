@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
+)
+
+// CheckPolicy checks that the cluster's policy allows the source to point
+// at its vCenter (or connection) from its namespace.  Updates are only
+// checked when they change where the source points, so that tightening the
+// policy doesn't block unrelated changes.
+func (fb *VSphereSource) CheckPolicy(ctx context.Context) *apis.FieldError {
+	if apis.IsInUpdate(ctx) {
+		base := &VSphereSource{}
+		if convertBaseline(ctx, base) &&
+			base.Spec.Address.Host == fb.Spec.Address.Host &&
+			equality.Semantic.DeepEqual(base.Spec.ConnectionRef, fb.Spec.ConnectionRef) {
+			return nil
+		}
+	}
+
+	if ref := fb.Spec.ConnectionRef; ref != nil {
+		return checkPolicy(ctx, fb.Namespace, "connectionRef.name", func(p *config.Policy, ns labels.Set) error {
+			return p.AllowsConnection(ns, ref.Name)
+		})
+	}
+	return checkPolicy(ctx, fb.Namespace, "address", func(p *config.Policy, ns labels.Set) error {
+		return p.AllowsAddress(ns, fb.Spec.Address.Host, true /* source */)
+	})
+}
+
+// CheckPolicy checks that the cluster's policy allows the binding to point
+// at its vCenter from its namespace.  Like for sources, updates are only
+// checked when they change the address.
+func (fb *VSphereBinding) CheckPolicy(ctx context.Context) *apis.FieldError {
+	if apis.IsInUpdate(ctx) {
+		base := &VSphereBinding{}
+		if convertBaseline(ctx, base) && base.Spec.Address.Host == fb.Spec.Address.Host {
+			return nil
+		}
+	}
+	return checkPolicy(ctx, fb.Namespace, "address", func(p *config.Policy, ns labels.Set) error {
+		return p.AllowsAddress(ns, fb.Spec.Address.Host, false /* source */)
+	})
+}
+
+// CheckPolicy checks that the cluster's policy allows the sink to point at
+// its vCenter from its namespace.  Since the sink writes to vCenter through
+// a VSphereBinding, it is held to the same rules as bindings.
+func (vs *VSphereSink) CheckPolicy(ctx context.Context) *apis.FieldError {
+	// Sinks only have the one version, so there is nothing to convert.
+	if base, ok := apis.GetBaseline(ctx).(*VSphereSink); ok && apis.IsInUpdate(ctx) &&
+		base.Spec.Address.Host == vs.Spec.Address.Host {
+		return nil
+	}
+	return checkPolicy(ctx, vs.Namespace, "address", func(p *config.Policy, ns labels.Set) error {
+		return p.AllowsAddress(ns, vs.Spec.Address.Host, false /* source */)
+	})
+}
+
+// CheckPolicy checks that the cluster's policy allows the action to point
+// at its vCenter from its namespace.  Like a sink, it is held to the same
+// rules as bindings.
+func (va *VSphereAction) CheckPolicy(ctx context.Context) *apis.FieldError {
+	if base, ok := apis.GetBaseline(ctx).(*VSphereAction); ok && apis.IsInUpdate(ctx) &&
+		base.Spec.Address.Host == va.Spec.Address.Host {
+		return nil
+	}
+	return checkPolicy(ctx, va.Namespace, "address", func(p *config.Policy, ns labels.Set) error {
+		return p.AllowsAddress(ns, va.Spec.Address.Host, false /* source */)
+	})
+}
+
+// convertBaseline converts the object being updated, in whatever version
+// it was sent to us, into hub.
+func convertBaseline(ctx context.Context, hub apis.Convertible) bool {
+	switch base := apis.GetBaseline(ctx).(type) {
+	case *VSphereSource:
+		*hub.(*VSphereSource) = *base
+	case *VSphereBinding:
+		*hub.(*VSphereBinding) = *base
+	case apis.Convertible:
+		return hub.ConvertFrom(ctx, base) == nil
+	default:
+		return false
+	}
+	return true
+}
+
+// checkPolicy runs the check against the cluster's policy, with the labels
+// of the namespace, and reports its failure on the given field.
+func checkPolicy(ctx context.Context, namespace, field string, check func(*config.Policy, labels.Set) error) *apis.FieldError {
+	policy := config.FromContextOrDefaults(ctx).Policy
+	if policy == nil || len(policy.Rules) == 0 {
+		return nil
+	}
+	ns, err := config.NamespaceLabels(ctx, namespace)
+	if err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("unable to check the cluster's policy: %v", err),
+			Paths:   []string{apis.CurrentField},
+		}
+	}
+	if err := check(policy, ns); err != nil {
+		return &apis.FieldError{
+			Message: err.Error(),
+			Paths:   []string{field},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
+)
+
+// policyContext attaches a policy that only allows the "infra" namespace to
+// point at validVAuthSpec's vCenter, and the "apps" namespace to use the
+// "shared" connection.
+func policyContext() context.Context {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, team := range map[string]string{"infra": "infra", "apps": "apps", "other": "other"} {
+		indexer.Add(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"team": team},
+			},
+		})
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Policy: &config.Policy{
+			Rules: []config.PolicyRule{{
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "infra"},
+				},
+				AllowedHosts: []string{validVAuthSpec.Address.Host},
+			}, {
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "apps"},
+				},
+				AllowedHosts:         []string{validVAuthSpec.Address.Host},
+				RequireConnectionRef: true,
+				AllowedConnections:   []string{"shared"},
+			}},
+		},
+	})
+	return config.WithNamespaceLister(ctx, corev1listers.NewNamespaceLister(indexer))
+}
+
+func TestVSphereSourcePolicy(t *testing.T) {
+	source := func(ns string, mod func(*VSphereSourceSpec)) *VSphereSource {
+		vs := &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "source",
+				Namespace: ns,
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+			},
+		}
+		if mod != nil {
+			mod(&vs.Spec)
+		}
+		return vs
+	}
+	connection := func(name string) func(*VSphereSourceSpec) {
+		return func(spec *VSphereSourceSpec) {
+			spec.VAuthSpec = VAuthSpec{}
+			spec.ConnectionRef = &corev1.LocalObjectReference{Name: name}
+		}
+	}
+	otherHost := func(spec *VSphereSourceSpec) {
+		spec.Address.Host = "rogue.example.com"
+	}
+
+	tests := []struct {
+		name string
+		c    *VSphereSource
+		base *VSphereSource
+		want *apis.FieldError
+	}{{
+		name: "allowed address",
+		c:    source("infra", nil),
+	}, {
+		name: "address on another host",
+		c:    source("infra", otherHost),
+		want: &apis.FieldError{
+			Message: `vCenter "rogue.example.com" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.address"},
+		},
+	}, {
+		name: "namespace without rules",
+		c:    source("other", nil),
+		want: &apis.FieldError{
+			Message: `vCenter "tekton.dev" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.address"},
+		},
+	}, {
+		name: "address where a connectionRef is required",
+		c:    source("apps", nil),
+		want: &apis.FieldError{
+			Message: `vCenter "tekton.dev" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.address"},
+		},
+	}, {
+		name: "allowed connection",
+		c:    source("apps", connection("shared")),
+	}, {
+		name: "other connection",
+		c:    source("apps", connection("private")),
+		want: &apis.FieldError{
+			Message: `connection "private" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.connectionRef.name"},
+		},
+	}, {
+		name: "unrelated update of a disallowed source",
+		c: source("other", func(spec *VSphereSourceSpec) {
+			spec.Filter = "true"
+		}),
+		base: source("other", nil),
+	}, {
+		name: "update to another host",
+		c:    source("infra", otherHost),
+		base: source("infra", nil),
+		want: &apis.FieldError{
+			Message: `vCenter "rogue.example.com" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.address"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := policyContext()
+			if test.base != nil {
+				ctx = apis.WithinUpdate(ctx, test.base)
+			}
+			got := test.c.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestVSphereBindingPolicy(t *testing.T) {
+	binding := func(ns, host string) *VSphereBinding {
+		vb := &VSphereBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "binding",
+				Namespace: ns,
+			},
+			Spec: VSphereBindingSpec{
+				BindingSpec: validBindingSpec,
+				VAuthSpec:   validVAuthSpec,
+			},
+		}
+		vb.Spec.Subject.Namespace = ns
+		vb.Spec.Address.Host = host
+		return vb
+	}
+
+	tests := []struct {
+		name string
+		c    *VSphereBinding
+		base *VSphereBinding
+		want *apis.FieldError
+	}{{
+		name: "allowed address",
+		c:    binding("infra", validVAuthSpec.Address.Host),
+	}, {
+		// Bindings may specify an address where sources need a connectionRef,
+		// since they're what the connectionRef names.
+		name: "allowed address where a connectionRef is required",
+		c:    binding("apps", validVAuthSpec.Address.Host),
+	}, {
+		name: "address on another host",
+		c:    binding("apps", "rogue.example.com"),
+		want: &apis.FieldError{
+			Message: `vCenter "rogue.example.com" is not allowed in this namespace by the cluster's policy`,
+			Paths:   []string{"spec.address"},
+		},
+	}, {
+		name: "unrelated update of a disallowed binding",
+		c:    binding("other", validVAuthSpec.Address.Host),
+		base: binding("other", validVAuthSpec.Address.Host),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := policyContext()
+			if test.base != nil {
+				ctx = apis.WithinUpdate(ctx, test.base)
+			}
+			got := test.c.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
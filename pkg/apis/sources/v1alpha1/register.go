@@ -49,6 +49,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VSphereSourceList{},
 		&VSphereBinding{},
 		&VSphereBindingList{},
+		&VSphereSink{},
+		&VSphereSinkList{},
+		&VSphereAction{},
+		&VSphereActionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
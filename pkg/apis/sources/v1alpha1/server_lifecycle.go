@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// serverConditions are the conditions of the resources that run a server
+// with vSphere credentials behind a Service (e.g. VSphereSink), which
+// reflect the server's VSphereBinding, Deployment and Service.
+type serverConditions struct {
+	set apis.ConditionSet
+
+	auth        apis.ConditionType
+	server      apis.ConditionType
+	addressable apis.ConditionType
+}
+
+func newServerConditions(auth, server, addressable apis.ConditionType) serverConditions {
+	return serverConditions{
+		set:         apis.NewLivingConditionSet(auth, server, addressable),
+		auth:        auth,
+		server:      server,
+		addressable: addressable,
+	}
+}
+
+func (sc serverConditions) propagateAuthStatus(s apis.ConditionsAccessor, status duckv1.Status) {
+	cond := status.GetCondition(apis.ConditionReady)
+	switch {
+	case cond == nil:
+		sc.set.Manage(s).MarkUnknown(sc.auth, "", "")
+	case cond.Status == corev1.ConditionUnknown:
+		sc.set.Manage(s).MarkUnknown(sc.auth, cond.Reason, "%s", cond.Message)
+	case cond.Status == corev1.ConditionFalse:
+		sc.set.Manage(s).MarkFalse(sc.auth, cond.Reason, "%s", cond.Message)
+	case cond.Status == corev1.ConditionTrue:
+		sc.set.Manage(s).MarkTrue(sc.auth)
+	}
+}
+
+func (sc serverConditions) propagateServerStatus(s apis.ConditionsAccessor, d appsv1.DeploymentStatus) {
+	// Check if the Deployment is available.
+	for _, cond := range d.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable {
+			switch {
+			case cond.Status == corev1.ConditionUnknown:
+				sc.set.Manage(s).MarkUnknown(sc.server, cond.Reason, "%s", cond.Message)
+			case cond.Status == corev1.ConditionFalse:
+				sc.set.Manage(s).MarkFalse(sc.server, cond.Reason, "%s", cond.Message)
+			case cond.Status == corev1.ConditionTrue:
+				sc.set.Manage(s).MarkTrue(sc.server)
+			}
+			return
+		}
+	}
+
+	sc.set.Manage(s).MarkUnknown(sc.server, "", "")
+}
+
+func (sc serverConditions) setAddress(s apis.ConditionsAccessor, as *duckv1.AddressStatus, url *apis.URL) {
+	if url == nil {
+		as.Address = nil
+		sc.set.Manage(s).MarkFalse(sc.addressable, "EmptyHostname", "hostname is the empty string")
+		return
+	}
+	as.Address = &duckv1.Addressable{URL: url}
+	sc.set.Manage(s).MarkTrue(sc.addressable)
+}
+
+// markResourceNotOwned reflects a resource that we won't touch in the
+// condition for the part of the server that it belongs to.
+func (sc serverConditions) markResourceNotOwned(s apis.ConditionsAccessor, kind, name string) {
+	cond := sc.server
+	switch kind {
+	case "Service":
+		cond = sc.addressable
+	case "VSphereBinding":
+		cond = sc.auth
+	}
+	sc.set.Manage(s).MarkFalse(cond, "NotOwned",
+		"There is an existing %s %q that we do not own.", kind, name)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	apistest "knative.dev/pkg/apis/testing"
+)
+
+// testConditions are the serverConditions of a kind that runs a server.
+var testConditions = newServerConditions("AuthReady", "ServerReady", "Addressable")
+
+// testServerStatus is the status of a kind that runs a server.
+type testServerStatus struct {
+	duckv1.Status
+	duckv1.AddressStatus
+}
+
+func TestTypicalServerFlow(t *testing.T) {
+	r := &testServerStatus{}
+	testConditions.set.Manage(r).InitializeConditions()
+	apistest.CheckConditionOngoing(r, apis.ConditionReady, t)
+
+	// Check the progression of the auth condition.
+	testConditions.propagateAuthStatus(r, duckv1.Status{})
+	apistest.CheckConditionOngoing(r, "AuthReady", t)
+	testConditions.propagateAuthStatus(r, duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionFalse,
+		}},
+	})
+	apistest.CheckConditionFailed(r, "AuthReady", t)
+	apistest.CheckConditionFailed(r, apis.ConditionReady, t)
+	testConditions.propagateAuthStatus(r, duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, "AuthReady", t)
+	apistest.CheckConditionOngoing(r, apis.ConditionReady, t)
+
+	// Check the progression of the server condition.
+	testConditions.propagateServerStatus(r, appsv1.DeploymentStatus{})
+	apistest.CheckConditionOngoing(r, "ServerReady", t)
+	testConditions.propagateServerStatus(r, appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionFalse,
+		}},
+	})
+	apistest.CheckConditionFailed(r, "ServerReady", t)
+	testConditions.propagateServerStatus(r, appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, "ServerReady", t)
+	apistest.CheckConditionOngoing(r, apis.ConditionReady, t)
+
+	// Check the progression of the Addressable condition.
+	testConditions.setAddress(r, &r.AddressStatus, nil)
+	apistest.CheckConditionFailed(r, "Addressable", t)
+	if r.Address != nil {
+		t.Errorf("Address = %v, wanted none", r.Address)
+	}
+	url := &apis.URL{Scheme: "http", Host: "foo.bar.svc.cluster.local"}
+	testConditions.setAddress(r, &r.AddressStatus, url)
+	apistest.CheckConditionSucceeded(r, "Addressable", t)
+	if r.Address == nil || r.Address.URL != url {
+		t.Errorf("Address = %v, wanted %v", r.Address, url)
+	}
+
+	// Now the server is ready.
+	apistest.CheckConditionSucceeded(r, apis.ConditionReady, t)
+	if !testConditions.set.Manage(r).IsHappy() {
+		t.Error("IsHappy() = false, wanted true")
+	}
+}
+
+func TestServerResourceNotOwned(t *testing.T) {
+	tests := []struct {
+		kind string
+		want apis.ConditionType
+	}{{
+		kind: "VSphereBinding",
+		want: "AuthReady",
+	}, {
+		kind: "Deployment",
+		want: "ServerReady",
+	}, {
+		kind: "Service",
+		want: "Addressable",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.kind, func(t *testing.T) {
+			r := &testServerStatus{}
+			testConditions.set.Manage(r).InitializeConditions()
+			testConditions.markResourceNotOwned(r, test.kind, "foo")
+			apistest.CheckConditionFailed(r, test.want, t)
+			apistest.CheckConditionFailed(r, apis.ConditionReady, t)
+			if got, want := r.GetCondition(test.want).Reason, "NotOwned"; got != want {
+				t.Errorf("Reason = %q, wanted %q", got, want)
+			}
+		})
+	}
+}
+
+func TestServerStatusMessages(t *testing.T) {
+	// Messages are copied as they are, rather than used as format strings.
+	const message = "100% of the replicas are unavailable"
+	r := &testServerStatus{}
+	testConditions.set.Manage(r).InitializeConditions()
+	testConditions.propagateAuthStatus(r, duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:    apis.ConditionReady,
+			Status:  corev1.ConditionFalse,
+			Message: message,
+		}},
+	})
+	testConditions.propagateServerStatus(r, appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:    appsv1.DeploymentAvailable,
+			Status:  corev1.ConditionUnknown,
+			Message: message,
+		}},
+	})
+	for _, cond := range []apis.ConditionType{"AuthReady", "ServerReady"} {
+		if got := r.GetCondition(cond).Message; got != message {
+			t.Errorf("%s message = %q, wanted %q", cond, got, message)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// SecretMissingReason is reported when the Secret named by secretRef
+	// doesn't exist.
+	SecretMissingReason = "SecretMissing"
+
+	// SecretKeyMissingReason is reported when the Secret named by secretRef
+	// doesn't hold a username and password.
+	SecretKeyMissingReason = "SecretKeyMissing"
+)
+
+// SecretError describes why the Secret named by secretRef can't be used to
+// authenticate with vSphere.
+type SecretError struct {
+	// Reason is SecretMissingReason or SecretKeyMissingReason.
+	Reason string
+
+	// Message tells the user how to fix the Secret.
+	Message string
+}
+
+// Error implements error
+func (se *SecretError) Error() string {
+	return se.Message
+}
+
+// CheckSecret checks that the Secret named by secretRef exists and holds the
+// username and password that the adapter and the subjects of bindings read,
+// as a kubernetes.io/basic-auth Secret does.  It returns a *SecretError when
+// it doesn't, and other errors when we can't tell.
+func (vas *VAuthSpec) CheckSecret(lister corev1listers.SecretNamespaceLister) error {
+	name := vas.SecretRef.Name
+	secret, err := lister.Get(name)
+	if apierrs.IsNotFound(err) {
+		return &SecretError{
+			Reason:  SecretMissingReason,
+			Message: fmt.Sprintf("Secret %q does not exist.", name),
+		}
+	} else if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, key := range []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey} {
+		if _, ok := secret.Data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return &SecretError{
+			Reason: SecretKeyMissingReason,
+			Message: fmt.Sprintf("Secret %q (of type %q) is missing the %s key(s); it should be a %s Secret with %q and %q.",
+				name, secret.Type, strings.Join(missing, " and "), corev1.SecretTypeBasicAuth,
+				corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey),
+		}
+	}
+	return nil
+}
+
+// WarnAboutSecret logs a warning when the Secret named by secretRef isn't
+// usable.  Unlike the rest of validation, it doesn't reject the resource,
+// since the Secret may well be created (or fixed) after it.
+func (vas *VAuthSpec) WarnAboutSecret(ctx context.Context, namespace string) {
+	lister := getSecretLister(ctx)
+	if lister == nil || vas.SecretRef.Name == "" {
+		return
+	}
+	var se *SecretError
+	if err := vas.CheckSecret(lister.Secrets(namespace)); errors.As(err, &se) {
+		logging.FromContext(ctx).Warnf("%s: %s", se.Reason, se.Message)
+	}
+}
+
+// secretListerKey is used as the key for associating a Secret lister with
+// a context.Context.
+type secretListerKey struct{}
+
+// WithSecretLister attaches the lister used to check the Secrets named by
+// secretRef during validation to the context.
+func WithSecretLister(ctx context.Context, lister corev1listers.SecretLister) context.Context {
+	return context.WithValue(ctx, secretListerKey{}, lister)
+}
+
+// getSecretLister accesses the lister attached to the context, if any.
+func getSecretLister(ctx context.Context) corev1listers.SecretLister {
+	lister, _ := ctx.Value(secretListerKey{}).(corev1listers.SecretLister)
+	return lister
+}
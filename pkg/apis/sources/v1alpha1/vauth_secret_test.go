@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestCheckSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     *corev1.Secret
+		wantReason string
+	}{{
+		name: "basic-auth",
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+				corev1.BasicAuthPasswordKey: []byte("pass"),
+			},
+		},
+	}, {
+		name: "opaque with the keys",
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+				corev1.BasicAuthPasswordKey: []byte("pass"),
+			},
+		},
+	}, {
+		name:       "missing",
+		wantReason: SecretMissingReason,
+	}, {
+		name: "missing password",
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+			},
+		},
+		wantReason: SecretKeyMissingReason,
+	}, {
+		name: "wrong type",
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		},
+		wantReason: SecretKeyMissingReason,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if test.secret != nil {
+				test.secret.ObjectMeta = metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      validVAuthSpec.SecretRef.Name,
+				}
+				indexer.Add(test.secret)
+			}
+			lister := corev1listers.NewSecretLister(indexer).Secrets("ns")
+
+			err := validVAuthSpec.CheckSecret(lister)
+			var se *SecretError
+			switch {
+			case test.wantReason == "" && err != nil:
+				t.Errorf("CheckSecret() = %v", err)
+			case test.wantReason == "":
+			case !errors.As(err, &se):
+				t.Errorf("CheckSecret() = %v, wanted a SecretError", err)
+			case se.Reason != test.wantReason:
+				t.Errorf("CheckSecret() reason = %q, wanted %q", se.Reason, test.wantReason)
+			}
+		})
+	}
+}
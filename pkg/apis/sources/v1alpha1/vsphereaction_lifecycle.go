@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var actionConditions = newServerConditions(
+	VSphereActionConditionAuthReady,
+	VSphereActionConditionExecutorReady,
+	VSphereActionConditionAddressable,
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (va *VSphereAction) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("VSphereAction")
+}
+
+func (vas *VSphereActionStatus) InitializeConditions() {
+	actionConditions.set.Manage(vas).InitializeConditions()
+}
+
+// IsReady returns whether the action is ready to act on events.
+func (vas *VSphereActionStatus) IsReady() bool {
+	return actionConditions.set.Manage(vas).IsHappy()
+}
+
+func (vas *VSphereActionStatus) PropagateAuthStatus(status duckv1.Status) {
+	actionConditions.propagateAuthStatus(vas, status)
+}
+
+func (vas *VSphereActionStatus) PropagateExecutorStatus(d appsv1.DeploymentStatus) {
+	actionConditions.propagateServerStatus(vas, d)
+}
+
+// SetAddress records the URL to which events for the action are sent, or
+// that the action has none when it is nil.
+func (vas *VSphereActionStatus) SetAddress(url *apis.URL) {
+	actionConditions.setAddress(vas, &vas.AddressStatus, url)
+}
+
+// MarkResourceNotOwned records that a resource of the given kind already
+// exists with the name that the action would give it, but isn't owned by
+// the action, so we won't touch it.  It is reflected in the condition for
+// the part of the action that the resource belongs to.
+func (vas *VSphereActionStatus) MarkResourceNotOwned(kind, name string) {
+	actionConditions.markResourceNotOwned(vas, kind, name)
+}
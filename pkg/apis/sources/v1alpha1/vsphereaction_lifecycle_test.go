@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	apistest "knative.dev/pkg/apis/testing"
+)
+
+func TestVSphereActionDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}, {
+		name: "addressable",
+		t:    &duckv1.Addressable{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&VSphereAction{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(VSphereAction, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func TestVSphereActionGetGroupVersionKind(t *testing.T) {
+	r := &VSphereAction{}
+	want := schema.GroupVersionKind{
+		Group:   "sources.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "VSphereAction",
+	}
+	if got := r.GetGroupVersionKind(); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+// The shared server conditions are tested in server_lifecycle_test.go, so
+// this only checks that the action's status maps onto its own conditions.
+func TestTypicalActionFlow(t *testing.T) {
+	r := &VSphereActionStatus{}
+	r.InitializeConditions()
+	apistest.CheckConditionOngoing(r, VSphereActionConditionReady, t)
+
+	r.PropagateAuthStatus(duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, VSphereActionConditionAuthReady, t)
+	r.PropagateExecutorStatus(appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	})
+	apistest.CheckConditionSucceeded(r, VSphereActionConditionExecutorReady, t)
+	r.SetAddress(&apis.URL{Scheme: "http", Host: "foo-action.bar.svc.cluster.local"})
+	apistest.CheckConditionSucceeded(r, VSphereActionConditionAddressable, t)
+
+	// Now the action is ready.
+	apistest.CheckConditionSucceeded(r, VSphereActionConditionReady, t)
+	if !r.IsReady() {
+		t.Error("IsReady() = false, wanted true")
+	}
+
+	// A Deployment that isn't ours fails the ExecutorReady condition.
+	r.MarkResourceNotOwned("Deployment", "foo-action-deployment")
+	apistest.CheckConditionFailed(r, VSphereActionConditionExecutorReady, t)
+	apistest.CheckConditionFailed(r, VSphereActionConditionReady, t)
+}
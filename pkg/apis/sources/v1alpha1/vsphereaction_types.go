@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereAction is an Addressable that performs an operation in vCenter on
+// the entity concerned by each of the CloudEvents sent to it that match its
+// filter, e.g. to tag the VMs named by VmCreatedEvents.
+type VSphereAction struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the VSphereAction (from the client).
+	// +optional
+	Spec VSphereActionSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the VSphereAction (from the controller).
+	// +optional
+	Status VSphereActionStatus `json:"status,omitempty"`
+}
+
+// Check that VSphereAction can be validated and defaulted.
+var _ apis.Validatable = (*VSphereAction)(nil)
+var _ apis.Defaultable = (*VSphereAction)(nil)
+var _ kmeta.OwnerRefable = (*VSphereAction)(nil)
+
+// VSphereActionSpec holds the desired state of the VSphereAction (from the client).
+type VSphereActionSpec struct {
+	VAuthSpec `json:",inline"`
+
+	// Scope is the inventory path of the part of the inventory that the
+	// action may operate on, e.g. a datacenter or a VM folder like
+	// "/dc1/vm/staging".  The operation is only performed on the entity at
+	// that path and the entities beneath it, and the events about others
+	// are rejected.
+	Scope string `json:"scope"`
+
+	// Filter selects the events on which the operation is performed.  The
+	// others are acknowledged and dropped.  Without a filter, the operation
+	// is performed on every event.
+	// +optional
+	Filter *ActionFilter `json:"filter,omitempty"`
+
+	// Operation is the operation performed on the entity concerned by each
+	// event.  That is the entity named by the event's "vsphereentity"
+	// extension, as for a VSphereSink, or else the most specific entity
+	// named by the vSphere event that it carries.
+	Operation ActionOperation `json:"operation"`
+
+	// DryRun has the action report what it would do, without doing it.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ActionFilter selects the events on which an action is taken.  An event
+// must satisfy all of its parts.
+type ActionFilter struct {
+	// Attributes are the values that the event's CloudEvent attributes and
+	// extensions must have, as with the filter of a Trigger.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// Expression is a CEL expression that is evaluated against the vSphere
+	// event carried by the event, with the same variables as
+	// VSphereSourceSpec.Filter.  Events not emitted by a VSphereSource
+	// don't match it.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+// ActionOperation is the operation that an action performs.  Exactly one
+// of its fields must be set.
+type ActionOperation struct {
+	// AttachTag attaches a tag to the entity.
+	// +optional
+	AttachTag *TagAction `json:"attachTag,omitempty"`
+
+	// DetachTag detaches a tag from the entity.
+	// +optional
+	DetachTag *TagAction `json:"detachTag,omitempty"`
+
+	// SetCustomAttribute sets the value of a custom attribute of the entity.
+	// +optional
+	SetCustomAttribute *CustomAttributeAction `json:"setCustomAttribute,omitempty"`
+
+	// PowerOn powers on the VM.
+	// +optional
+	PowerOn *PowerAction `json:"powerOn,omitempty"`
+
+	// PowerOff powers off the VM.
+	// +optional
+	PowerOff *PowerAction `json:"powerOff,omitempty"`
+
+	// Snapshot takes a snapshot of the VM.
+	// +optional
+	Snapshot *SnapshotAction `json:"snapshot,omitempty"`
+
+	// Migrate moves the VM to another host, resource pool or datastore.
+	// +optional
+	Migrate *MigrateAction `json:"migrate,omitempty"`
+
+	// Annotate sets the annotation (the notes) of the VM.
+	// +optional
+	Annotate *AnnotateAction `json:"annotate,omitempty"`
+}
+
+// TagAction names the tag that is attached or detached.
+type TagAction struct {
+	// Tag is the name or ID of the tag.
+	Tag string `json:"tag"`
+
+	// Category is the name or ID of the tag's category, which is needed
+	// when tags of the same name exist in several categories.
+	// +optional
+	Category string `json:"category,omitempty"`
+}
+
+// CustomAttributeAction holds the custom attribute that is set.
+type CustomAttributeAction struct {
+	// Name is the name of the custom attribute, which must already be
+	// defined in vCenter.
+	Name string `json:"name"`
+
+	// Value is the value the attribute is set to.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// PowerAction powers a VM on or off.
+type PowerAction struct{}
+
+// SnapshotAction holds the settings of the snapshots that are taken.
+type SnapshotAction struct {
+	// Name is the name of the snapshot.  It defaults to the ID of the
+	// event.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the snapshot.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Memory includes the VM's memory in the snapshot.
+	// +optional
+	Memory bool `json:"memory,omitempty"`
+
+	// Quiesce quiesces the VM's file system before it is snapshotted.
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
+}
+
+// MigrateAction names where VMs are moved to, by inventory path.  At least
+// one of its fields must be set.
+type MigrateAction struct {
+	// Host is the host the VM is moved to, e.g. "/dc1/host/cluster1/esx-2".
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// ResourcePool is the resource pool the VM is moved to, e.g.
+	// "/dc1/host/cluster1/Resources/prod".
+	// +optional
+	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// Datastore is the datastore the VM's disks are moved to, e.g.
+	// "/dc1/datastore/ds2".
+	// +optional
+	Datastore string `json:"datastore,omitempty"`
+}
+
+// AnnotateAction holds the annotation that VMs are given.
+type AnnotateAction struct {
+	// Annotation is the text that the VM's annotation is set to.  It
+	// defaults to the message of the event, as a VSphereSink would record
+	// it.
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+}
+
+const (
+	// VSphereActionLabelKey is the label placed on the resources that the
+	// controller creates for a VSphereAction, with the action's name as its
+	// value.
+	VSphereActionLabelKey = "vsphereactions.sources.knative.dev/name"
+
+	// ExecutorContainerName is the name of the executor's container.
+	ExecutorContainerName = "executor"
+)
+
+const (
+	// VSphereActionConditionReady is set to reflect the overall state of the resource.
+	VSphereActionConditionReady = apis.ConditionReady
+
+	// VSphereActionConditionAuthReady is set to reflect the state of the auth part of the VSphereAction.
+	VSphereActionConditionAuthReady = "AuthReady"
+
+	// VSphereActionConditionExecutorReady is set to reflect whether the
+	// executor's Deployment is available.
+	VSphereActionConditionExecutorReady = "ExecutorReady"
+
+	// VSphereActionConditionAddressable is set to reflect whether the
+	// action has an address that events can be sent to.
+	VSphereActionConditionAddressable = "Addressable"
+)
+
+// VSphereActionStatus communicates the observed state of the VSphereAction (from the controller).
+type VSphereActionStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// AddressStatus holds the address to which events are sent to be acted
+	// upon.
+	duckv1.AddressStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereActionList is a list of VSphereAction resources
+type VSphereActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VSphereAction `json:"items"`
+}
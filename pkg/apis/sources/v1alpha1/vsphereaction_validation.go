@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+)
+
+// Validate implements apis.Validatable
+func (va *VSphereAction) Validate(ctx context.Context) *apis.FieldError {
+	err := va.Spec.Validate(ctx).ViaField("spec")
+	if err != nil {
+		return err
+	}
+	va.Spec.VAuthSpec.WarnAboutSecret(ctx, va.Namespace)
+	return va.CheckPolicy(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (vas *VSphereActionSpec) Validate(ctx context.Context) *apis.FieldError {
+	err := vas.VAuthSpec.Validate(ctx)
+	if vas.Scope == "" {
+		err = err.Also(apis.ErrMissingField("scope"))
+	} else if ierr := validateInventoryPath(vas.Scope); ierr != nil {
+		err = err.Also(ierr.ViaField("scope"))
+	}
+	if vas.Filter != nil {
+		err = err.Also(vas.Filter.Validate(ctx).ViaField("filter"))
+	}
+	return err.Also(vas.Operation.Validate(ctx).ViaField("operation"))
+}
+
+// Validate implements apis.Validatable
+func (af *ActionFilter) Validate(ctx context.Context) (err *apis.FieldError) {
+	for name := range af.Attributes {
+		if name == "" {
+			err = err.Also(apis.ErrInvalidKeyName(name, "attributes", "attribute names may not be empty"))
+		}
+	}
+	if af.Expression != "" {
+		if _, ferr := expr.NewFilter(af.Expression); ferr != nil {
+			err = err.Also(apis.ErrInvalidValue(ferr.Error(), "expression"))
+		}
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (ao *ActionOperation) Validate(ctx context.Context) (err *apis.FieldError) {
+	var set []string
+	if ao.AttachTag != nil {
+		set = append(set, "attachTag")
+		err = err.Also(ao.AttachTag.Validate(ctx).ViaField("attachTag"))
+	}
+	if ao.DetachTag != nil {
+		set = append(set, "detachTag")
+		err = err.Also(ao.DetachTag.Validate(ctx).ViaField("detachTag"))
+	}
+	if ao.SetCustomAttribute != nil {
+		set = append(set, "setCustomAttribute")
+		if ao.SetCustomAttribute.Name == "" {
+			err = err.Also(apis.ErrMissingField("setCustomAttribute.name"))
+		}
+	}
+	if ao.PowerOn != nil {
+		set = append(set, "powerOn")
+	}
+	if ao.PowerOff != nil {
+		set = append(set, "powerOff")
+	}
+	if ao.Snapshot != nil {
+		set = append(set, "snapshot")
+	}
+	if ao.Migrate != nil {
+		set = append(set, "migrate")
+		err = err.Also(ao.Migrate.Validate(ctx).ViaField("migrate"))
+	}
+	if ao.Annotate != nil {
+		set = append(set, "annotate")
+	}
+
+	switch len(set) {
+	case 0:
+		err = err.Also(apis.ErrMissingOneOf("attachTag", "detachTag", "setCustomAttribute",
+			"powerOn", "powerOff", "snapshot", "migrate", "annotate"))
+	case 1:
+	default:
+		err = err.Also(apis.ErrMultipleOneOf(set...))
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (ta *TagAction) Validate(ctx context.Context) *apis.FieldError {
+	if ta.Tag == "" {
+		return apis.ErrMissingField("tag")
+	}
+	return nil
+}
+
+// Validate implements apis.Validatable
+func (ma *MigrateAction) Validate(ctx context.Context) (err *apis.FieldError) {
+	if ma.Host == "" && ma.ResourcePool == "" && ma.Datastore == "" {
+		return apis.ErrMissingOneOf("host", "resourcePool", "datastore")
+	}
+	for field, path := range map[string]string{
+		"host":         ma.Host,
+		"resourcePool": ma.ResourcePool,
+		"datastore":    ma.Datastore,
+	} {
+		if path != "" {
+			err = err.Also(validateInventoryPath(path).ViaField(field))
+		}
+	}
+	return err
+}
+
+// validateInventoryPath checks that the path is an absolute inventory path.
+func validateInventoryPath(path string) *apis.FieldError {
+	if !strings.HasPrefix(path, "/") {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid inventory path %q", path),
+			Paths:   []string{apis.CurrentField},
+			Details: "inventory paths are absolute, e.g. /dc1/host/cluster1",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestVSphereActionValidation(t *testing.T) {
+	attachTag := ActionOperation{AttachTag: &TagAction{Tag: "prod", Category: "env"}}
+
+	tests := []struct {
+		name string
+		spec VSphereActionSpec
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Filter: &ActionFilter{
+				Attributes: map[string]string{"type": "com.vmware.vsphere.VmCreatedEvent"},
+				Expression: `event.UserName == "administrator"`,
+			},
+			Operation: attachTag,
+			DryRun:    true,
+		},
+	}, {
+		name: "valid, without a filter",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{Snapshot: &SnapshotAction{Memory: true}},
+		},
+	}, {
+		name: "empty attribute name",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Filter: &ActionFilter{
+				Attributes: map[string]string{"": "foo"},
+			},
+			Operation: attachTag,
+		},
+		want: apis.ErrInvalidKeyName("", "spec.filter.attributes", "attribute names may not be empty"),
+	}, {
+		name: "invalid expression",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Filter: &ActionFilter{
+				Expression: "event.UserName ==",
+			},
+			Operation: attachTag,
+		},
+		want: func() *apis.FieldError {
+			err := (&ActionFilter{Expression: "event.UserName =="}).Validate(context.Background())
+			if err == nil {
+				t.Fatal("Validate() = nil, wanted an error")
+			}
+			return err.ViaField("filter").ViaField("spec")
+		}(),
+	}, {
+		name: "no operation",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+		},
+		want: apis.ErrMissingOneOf("spec.operation.attachTag", "spec.operation.detachTag",
+			"spec.operation.setCustomAttribute", "spec.operation.powerOn", "spec.operation.powerOff",
+			"spec.operation.snapshot", "spec.operation.migrate", "spec.operation.annotate"),
+	}, {
+		name: "two operations",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{
+				PowerOff: &PowerAction{},
+				Snapshot: &SnapshotAction{},
+			},
+		},
+		want: apis.ErrMultipleOneOf("spec.operation.powerOff", "spec.operation.snapshot"),
+	}, {
+		name: "missing tag",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{DetachTag: &TagAction{Category: "env"}},
+		},
+		want: apis.ErrMissingField("spec.operation.detachTag.tag"),
+	}, {
+		name: "missing custom attribute",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{SetCustomAttribute: &CustomAttributeAction{Value: "team-x"}},
+		},
+		want: apis.ErrMissingField("spec.operation.setCustomAttribute.name"),
+	}, {
+		name: "migrate nowhere",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{Migrate: &MigrateAction{}},
+		},
+		want: apis.ErrMissingOneOf("spec.operation.migrate.host",
+			"spec.operation.migrate.resourcePool", "spec.operation.migrate.datastore"),
+	}, {
+		name: "migrate to a relative path",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "/dc1/vm",
+			Operation: ActionOperation{Migrate: &MigrateAction{Host: "dc1/host/cluster1"}},
+		},
+		want: &apis.FieldError{
+			Message: `invalid inventory path "dc1/host/cluster1"`,
+			Paths:   []string{"spec.operation.migrate.host"},
+			Details: "inventory paths are absolute, e.g. /dc1/host/cluster1",
+		},
+	}, {
+		name: "missing scope",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Operation: attachTag,
+		},
+		want: apis.ErrMissingField("spec.scope"),
+	}, {
+		name: "relative scope",
+		spec: VSphereActionSpec{
+			VAuthSpec: validVAuthSpec,
+			Scope:     "dc1/vm",
+			Operation: attachTag,
+		},
+		want: &apis.FieldError{
+			Message: `invalid inventory path "dc1/vm"`,
+			Paths:   []string{"spec.scope"},
+			Details: "inventory paths are absolute, e.g. /dc1/host/cluster1",
+		},
+	}, {
+		name: "missing SecretRef",
+		spec: VSphereActionSpec{
+			VAuthSpec: VAuthSpec{
+				Address: validVAuthSpec.Address,
+			},
+			Scope:     "/dc1/vm",
+			Operation: attachTag,
+		},
+		want: apis.ErrMissingField("spec.secretRef.name"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			va := &VSphereAction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "valid",
+					Namespace: "knobots",
+				},
+				Spec: test.spec,
+			}
+			got := va.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
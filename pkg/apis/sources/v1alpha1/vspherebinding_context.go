@@ -0,0 +1,47 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+)
+
+// credentialsHashKey is used as the key for associating information
+// with a context.Context.
+type credentialsHashKey struct{}
+
+// WithCredentialsHash notes on the context for binding that the hash of
+// the contents of the Secret is the provided string.
+func WithCredentialsHash(ctx context.Context, hash string) context.Context {
+	return context.WithValue(ctx, credentialsHashKey{}, hash)
+}
+
+// GetCredentialsHash accesses the hash of the contents of the Secret that
+// has been associated with this context, if any.
+func GetCredentialsHash(ctx context.Context) string {
+	value := ctx.Value(credentialsHashKey{})
+	if value == nil {
+		return ""
+	}
+	return value.(string)
+}
+
+// RolloutOnCredentialsChange returns whether the VSphereBinding has opted in
+// to rolling out its subjects when its credentials change.
+func (vsb *VSphereBinding) RolloutOnCredentialsChange() bool {
+	return vsb.Annotations[RolloutOnCredentialsChangeAnnotationKey] == "true"
+}
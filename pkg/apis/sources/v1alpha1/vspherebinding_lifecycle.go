@@ -29,7 +29,9 @@ import (
 	"knative.dev/pkg/tracker"
 )
 
-var vsbCondSet = apis.NewLivingConditionSet()
+var vsbCondSet = apis.NewLivingConditionSet(
+	VSphereBindingConditionCredentialsReady,
+)
 
 // GetGroupVersionKind returns the GroupVersionKind.
 func (s *VSphereBinding) GetGroupVersionKind() schema.GroupVersionKind {
@@ -73,6 +75,16 @@ func (sbs *VSphereBindingStatus) MarkBindingAvailable() {
 	vsbCondSet.Manage(sbs).MarkTrue(VSphereBindingConditionReady)
 }
 
+// PropagateSecretStatus reflects the result of VAuthSpec.CheckSecret in the
+// VSphereBinding's CredentialsReady condition.
+func (sbs *VSphereBindingStatus) PropagateSecretStatus(err *SecretError) {
+	if err != nil {
+		vsbCondSet.Manage(sbs).MarkFalse(VSphereBindingConditionCredentialsReady, err.Reason, "%s", err.Message)
+		return
+	}
+	vsbCondSet.Manage(sbs).MarkTrue(VSphereBindingConditionCredentialsReady)
+}
+
 // Do implements psbinding.Bindable
 func (vsb *VSphereBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	// First undo so that we can just unconditionally append below.
@@ -89,6 +101,15 @@ func (vsb *VSphereBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 	ps.Spec.Template.Spec.Volumes = append(ps.Spec.Template.Spec.Volumes, volume)
 
+	// Stamp a hash of the credentials onto the pod template when we have
+	// one, so that the subject rolls out when they change.
+	if hash := GetCredentialsHash(ctx); hash != "" && vsb.RolloutOnCredentialsChange() {
+		if ps.Spec.Template.Annotations == nil {
+			ps.Spec.Template.Annotations = make(map[string]string, 1)
+		}
+		ps.Spec.Template.Annotations[CredentialsHashAnnotationKey] = hash
+	}
+
 	// Make sure that each [init]container in the PodSpec has a VolumeMount like this:
 	volumeMount := corev1.VolumeMount{
 		Name:      vsphere.VolumeName,
@@ -159,58 +180,60 @@ func (vsb *VSphereBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 }
 
+// Undo implements psbinding.Bindable
 func (vsb *VSphereBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 	spec := ps.Spec.Template.Spec
 
-	for i, v := range spec.Volumes {
-		if v.Name == vsphere.VolumeName {
-			ps.Spec.Template.Spec.Volumes = append(spec.Volumes[:i], spec.Volumes[i+1:]...)
-			break
-		}
+	// Only remove the hash if it is ours, since the VSphereSource stamps it
+	// onto its adapter itself.
+	if vsb.RolloutOnCredentialsChange() {
+		delete(ps.Spec.Template.Annotations, CredentialsHashAnnotationKey)
 	}
 
-	for i, c := range spec.InitContainers {
-		for j, vm := range c.VolumeMounts {
-			if vm.Name == vsphere.VolumeName {
-				spec.InitContainers[i].VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
-				break
+	// Remove every trace of the binding, not just the first, so that a
+	// PodSpec that somehow picked up duplicates is still cleaned up.
+	if len(spec.Volumes) != 0 {
+		volumes := make([]corev1.Volume, 0, len(spec.Volumes))
+		for _, v := range spec.Volumes {
+			if v.Name != vsphere.VolumeName {
+				volumes = append(volumes, v)
 			}
 		}
+		ps.Spec.Template.Spec.Volumes = volumes
+	}
 
-		if len(c.Env) == 0 {
-			continue
-		}
-		env := make([]corev1.EnvVar, 0, len(spec.InitContainers[i].Env))
-		for j, ev := range c.Env {
-			switch ev.Name {
-			case "GOVC_URL", "GOVC_INSECURE", "GOVC_USERNAME", "GOVC_PASSWORD":
-				continue
-			default:
-				env = append(env, spec.InitContainers[i].Env[j])
-			}
-		}
-		spec.InitContainers[i].Env = env
+	for i := range spec.InitContainers {
+		undoContainer(&spec.InitContainers[i])
 	}
-	for i, c := range spec.Containers {
-		for j, vm := range c.VolumeMounts {
-			if vm.Name == vsphere.VolumeName {
-				spec.Containers[i].VolumeMounts = append(c.VolumeMounts[:j], c.VolumeMounts[j+1:]...)
-				break
+	for i := range spec.Containers {
+		undoContainer(&spec.Containers[i])
+	}
+}
+
+// undoContainer removes the VolumeMount and environment variables that Do
+// adds to each container.
+func undoContainer(c *corev1.Container) {
+	if len(c.VolumeMounts) != 0 {
+		mounts := make([]corev1.VolumeMount, 0, len(c.VolumeMounts))
+		for _, vm := range c.VolumeMounts {
+			if vm.Name != vsphere.VolumeName {
+				mounts = append(mounts, vm)
 			}
 		}
+		c.VolumeMounts = mounts
+	}
 
-		if len(c.Env) == 0 {
+	if len(c.Env) == 0 {
+		return
+	}
+	env := make([]corev1.EnvVar, 0, len(c.Env))
+	for _, ev := range c.Env {
+		switch ev.Name {
+		case "GOVC_URL", "GOVC_INSECURE", "GOVC_USERNAME", "GOVC_PASSWORD":
 			continue
+		default:
+			env = append(env, ev)
 		}
-		env := make([]corev1.EnvVar, 0, len(spec.Containers[i].Env))
-		for j, ev := range c.Env {
-			switch ev.Name {
-			case "GOVC_URL", "GOVC_INSECURE", "GOVC_USERNAME", "GOVC_PASSWORD":
-				continue
-			default:
-				env = append(env, spec.Containers[i].Env[j])
-			}
-		}
-		spec.Containers[i].Env = env
 	}
+	c.Env = env
 }
@@ -220,6 +220,56 @@ func TestVSphereBindingUndo(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "duplicates to remove",
+		in: &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "blah",
+							Image: "busybox",
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      vsphere.VolumeName,
+								MountPath: vsphere.MountPath,
+							}, {
+								Name:      "config",
+								MountPath: "/var/config",
+							}, {
+								Name:      vsphere.VolumeName,
+								MountPath: vsphere.MountPath,
+							}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: vsphere.VolumeName,
+						}, {
+							Name: "config",
+						}, {
+							Name: vsphere.VolumeName,
+						}},
+					},
+				},
+			},
+		},
+		want: &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "blah",
+							Image: "busybox",
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "config",
+								MountPath: "/var/config",
+							}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: "config",
+						}},
+					},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -645,7 +695,73 @@ func TestTypicalBindingFlow(t *testing.T) {
 	r.MarkBindingUnavailable("Foo", "Bar")
 	apistest.CheckConditionFailed(r, VSphereBindingConditionReady, t)
 
+	r.PropagateSecretStatus(&SecretError{Reason: SecretMissingReason, Message: "Missing"})
+	apistest.CheckConditionFailed(r, VSphereBindingConditionCredentialsReady, t)
+
+	r.MarkBindingAvailable()
+	// We can't be ready without credentials.
+	apistest.CheckConditionFailed(r, VSphereBindingConditionReady, t)
+	if got := r.GetCondition(VSphereBindingConditionReady).Reason; got != SecretMissingReason {
+		t.Errorf("Ready reason = %q, wanted %q", got, SecretMissingReason)
+	}
+
+	r.PropagateSecretStatus(nil)
+	apistest.CheckConditionSucceeded(r, VSphereBindingConditionCredentialsReady, t)
+
 	r.MarkBindingAvailable()
 	// After all of that, we're finally ready!
 	apistest.CheckConditionSucceeded(r, VSphereBindingConditionReady, t)
 }
+
+func TestVSphereBindingCredentialsHash(t *testing.T) {
+	vsb := &VSphereBinding{
+		Spec: VSphereBindingSpec{
+			VAuthSpec: VAuthSpec{
+				Address: apis.URL{
+					Scheme: "https",
+					Host:   "vcenter.local",
+				},
+				SecretRef: corev1.LocalObjectReference{
+					Name: "creds",
+				},
+			},
+		},
+	}
+	ctx := WithCredentialsHash(context.Background(), "abc")
+
+	newPod := func() *duckv1.WithPod {
+		return &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "blah",
+							Image: "busybox",
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	// Without opting in, the hash isn't stamped.
+	got := newPod()
+	vsb.Do(ctx, got)
+	if _, ok := got.Spec.Template.Annotations[CredentialsHashAnnotationKey]; ok {
+		t.Errorf("Do() = %v, wanted no %s", got.Spec.Template.Annotations, CredentialsHashAnnotationKey)
+	}
+
+	vsb.Annotations = map[string]string{
+		RolloutOnCredentialsChangeAnnotationKey: "true",
+	}
+	got = newPod()
+	vsb.Do(ctx, got)
+	if got, want := got.Spec.Template.Annotations[CredentialsHashAnnotationKey], "abc"; got != want {
+		t.Errorf("Annotations[%s] = %s, wanted %s", CredentialsHashAnnotationKey, got, want)
+	}
+
+	vsb.Undo(ctx, got)
+	if _, ok := got.Spec.Template.Annotations[CredentialsHashAnnotationKey]; ok {
+		t.Errorf("Undo() = %v, wanted no %s", got.Spec.Template.Annotations, CredentialsHashAnnotationKey)
+	}
+}
@@ -47,6 +47,7 @@ var (
 	_ apis.Validatable   = (*VSphereBinding)(nil)
 	_ apis.Defaultable   = (*VSphereBinding)(nil)
 	_ apis.HasSpec       = (*VSphereBinding)(nil)
+	_ apis.Convertible   = (*VSphereBinding)(nil)
 )
 
 // VSphereBindingSpec holds the desired state of the VSphereBinding (from the client).
@@ -75,6 +76,22 @@ const (
 	// VSphereBindingConditionReady is configured to indicate whether the Binding
 	// has been configured for resources subject to its runtime contract.
 	VSphereBindingConditionReady = apis.ConditionReady
+
+	// VSphereBindingConditionCredentialsReady is configured to indicate
+	// whether the Secret named by secretRef holds usable credentials.
+	VSphereBindingConditionCredentialsReady = "CredentialsReady"
+)
+
+const (
+	// CredentialsHashAnnotationKey is the annotation on the pod template of
+	// a bound resource holding a hash of the contents of the Secret that it
+	// is bound to, so that it rolls out when they change.
+	CredentialsHashAnnotationKey = "vspherebindings.sources.knative.dev/credentials-hash"
+
+	// RolloutOnCredentialsChangeAnnotationKey is the annotation with which
+	// a VSphereBinding opts in to stamping CredentialsHashAnnotationKey onto
+	// the pod templates of its subjects, when set to "true".
+	RolloutOnCredentialsChangeAnnotationKey = "vspherebindings.sources.knative.dev/rollout-on-credentials-change"
 )
 
 // VSphereBindingStatus communicates the observed state of the VSphereBinding (from the controller).
@@ -28,7 +28,11 @@ func (fb *VSphereBinding) Validate(ctx context.Context) *apis.FieldError {
 	if fb.Spec.Subject.Namespace != "" && fb.Namespace != fb.Spec.Subject.Namespace {
 		err = err.Also(apis.ErrInvalidValue(fb.Spec.Subject.Namespace, "spec.subject.namespace"))
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	fb.Spec.VAuthSpec.WarnAboutSecret(ctx, fb.Namespace)
+	return fb.CheckPolicy(ctx).ViaField("spec")
 }
 
 // Validate implements apis.Validatable
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
+)
+
+// SetDefaults implements apis.Defaultable
+func (vs *VSphereSink) SetDefaults(ctx context.Context) {
+	vs.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (vss *VSphereSinkSpec) SetDefaults(ctx context.Context) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+
+	// Sinks have no connectionRef, so they only pick up the default
+	// address and secret, as sources without a default connection do.
+	if vss.Address.Host == "" && defaults.Address != nil {
+		// The TLS mode goes with the address.
+		vss.Address = *defaults.Address.DeepCopy()
+		vss.SkipTLSVerify = defaults.SkipTLSVerify
+	}
+	if vss.SecretRef.Name == "" {
+		vss.SecretRef.Name = defaults.SecretName
+	}
+
+	if vss.RecordAs == "" {
+		vss.RecordAs = RecordAsUserEvent
+	}
+}
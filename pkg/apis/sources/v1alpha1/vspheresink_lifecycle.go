@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var sinkConditions = newServerConditions(
+	VSphereSinkConditionAuthReady,
+	VSphereSinkConditionReceiverReady,
+	VSphereSinkConditionAddressable,
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (vs *VSphereSink) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("VSphereSink")
+}
+
+func (vss *VSphereSinkStatus) InitializeConditions() {
+	sinkConditions.set.Manage(vss).InitializeConditions()
+}
+
+// IsReady returns whether the sink is ready to record events.
+func (vss *VSphereSinkStatus) IsReady() bool {
+	return sinkConditions.set.Manage(vss).IsHappy()
+}
+
+func (vss *VSphereSinkStatus) PropagateAuthStatus(status duckv1.Status) {
+	sinkConditions.propagateAuthStatus(vss, status)
+}
+
+func (vss *VSphereSinkStatus) PropagateReceiverStatus(d appsv1.DeploymentStatus) {
+	sinkConditions.propagateServerStatus(vss, d)
+}
+
+// SetAddress records the URL to which events for the sink are sent, or
+// that the sink has none when it is nil.
+func (vss *VSphereSinkStatus) SetAddress(url *apis.URL) {
+	sinkConditions.setAddress(vss, &vss.AddressStatus, url)
+}
+
+// MarkResourceNotOwned records that a resource of the given kind already
+// exists with the name that the sink would give it, but isn't owned by
+// the sink, so we won't touch it.  It is reflected in the condition for
+// the part of the sink that the resource belongs to.
+func (vss *VSphereSinkStatus) MarkResourceNotOwned(kind, name string) {
+	sinkConditions.markResourceNotOwned(vss, kind, name)
+}
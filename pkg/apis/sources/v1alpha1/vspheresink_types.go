@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereSink is an Addressable that records the CloudEvents sent to it in
+// vCenter, as events on the entities that they concern, so that they show
+// up in the vCenter UI next to that entity's own events.
+type VSphereSink struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the VSphereSink (from the client).
+	// +optional
+	Spec VSphereSinkSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the VSphereSink (from the controller).
+	// +optional
+	Status VSphereSinkStatus `json:"status,omitempty"`
+}
+
+// Check that VSphereSink can be validated and defaulted.
+var _ apis.Validatable = (*VSphereSink)(nil)
+var _ apis.Defaultable = (*VSphereSink)(nil)
+var _ kmeta.OwnerRefable = (*VSphereSink)(nil)
+
+// VSphereSinkSpec holds the desired state of the VSphereSink (from the client).
+type VSphereSinkSpec struct {
+	VAuthSpec `json:",inline"`
+
+	// Entity is the inventory path (e.g. "/dc1/vm/prod/web-1") of the entity
+	// on which the events that don't name one are recorded.  Events name
+	// their entity with the "vsphereentity" extension, or with the path of
+	// the request that they are sent with.  It defaults to the root folder.
+	// +optional
+	Entity string `json:"entity,omitempty"`
+
+	// RecordAs is how events are recorded: "UserEvent" logs them as user
+	// events with EventManager.LogUserEvent, and "EventEx" posts them as
+	// EventEx events whose eventTypeId is the CloudEvent's type.  It
+	// defaults to "UserEvent".
+	// +optional
+	RecordAs RecordAs `json:"recordAs,omitempty"`
+}
+
+// RecordAs is how a VSphereSink records the events sent to it.
+type RecordAs string
+
+const (
+	// RecordAsUserEvent logs events as user events.
+	RecordAsUserEvent RecordAs = "UserEvent"
+
+	// RecordAsEventEx posts events as EventEx events.
+	RecordAsEventEx RecordAs = "EventEx"
+)
+
+const (
+	// VSphereSinkLabelKey is the label placed on the resources that the
+	// controller creates for a VSphereSink, with the sink's name as its
+	// value.
+	VSphereSinkLabelKey = "vspheresinks.sources.knative.dev/name"
+
+	// ReceiverContainerName is the name of the receiver's container.
+	ReceiverContainerName = "receiver"
+)
+
+const (
+	// VSphereSinkConditionReady is set to reflect the overall state of the resource.
+	VSphereSinkConditionReady = apis.ConditionReady
+
+	// VSphereSinkConditionAuthReady is set to reflect the state of the auth part of the VSphereSink.
+	VSphereSinkConditionAuthReady = "AuthReady"
+
+	// VSphereSinkConditionReceiverReady is set to reflect whether the
+	// receiver's Deployment is available.
+	VSphereSinkConditionReceiverReady = "ReceiverReady"
+
+	// VSphereSinkConditionAddressable is set to reflect whether the sink
+	// has an address that events can be sent to.
+	VSphereSinkConditionAddressable = "Addressable"
+)
+
+// VSphereSinkStatus communicates the observed state of the VSphereSink (from the controller).
+type VSphereSinkStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// AddressStatus holds the address to which events are sent to be
+	// recorded in vCenter.
+	duckv1.AddressStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereSinkList is a list of VSphereSink resources
+type VSphereSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VSphereSink `json:"items"`
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (vs *VSphereSink) Validate(ctx context.Context) *apis.FieldError {
+	err := vs.Spec.Validate(ctx).ViaField("spec")
+	if err != nil {
+		return err
+	}
+	vs.Spec.VAuthSpec.WarnAboutSecret(ctx, vs.Namespace)
+	return vs.CheckPolicy(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (vss *VSphereSinkSpec) Validate(ctx context.Context) *apis.FieldError {
+	err := vss.VAuthSpec.Validate(ctx)
+	if vss.Entity != "" && !strings.HasPrefix(vss.Entity, "/") {
+		err = err.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid inventory path %q", vss.Entity),
+			Paths:   []string{"entity"},
+			Details: "inventory paths are absolute, e.g. /dc1/vm/prod/web-1",
+		})
+	}
+	switch vss.RecordAs {
+	case RecordAsUserEvent, RecordAsEventEx:
+	case "":
+		err = err.Also(apis.ErrMissingField("recordAs"))
+	default:
+		err = err.Also(apis.ErrInvalidValue(vss.RecordAs, "recordAs"))
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestVSphereSinkValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec VSphereSinkSpec
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		spec: VSphereSinkSpec{
+			VAuthSpec: validVAuthSpec,
+			Entity:    "/dc1/vm/prod",
+			RecordAs:  RecordAsEventEx,
+		},
+	}, {
+		name: "valid, on the root folder",
+		spec: VSphereSinkSpec{
+			VAuthSpec: validVAuthSpec,
+			RecordAs:  RecordAsUserEvent,
+		},
+	}, {
+		name: "relative entity",
+		spec: VSphereSinkSpec{
+			VAuthSpec: validVAuthSpec,
+			Entity:    "dc1/vm/prod",
+			RecordAs:  RecordAsUserEvent,
+		},
+		want: &apis.FieldError{
+			Message: `invalid inventory path "dc1/vm/prod"`,
+			Paths:   []string{"spec.entity"},
+			Details: "inventory paths are absolute, e.g. /dc1/vm/prod/web-1",
+		},
+	}, {
+		name: "missing recordAs",
+		spec: VSphereSinkSpec{
+			VAuthSpec: validVAuthSpec,
+		},
+		want: apis.ErrMissingField("spec.recordAs"),
+	}, {
+		name: "invalid recordAs",
+		spec: VSphereSinkSpec{
+			VAuthSpec: validVAuthSpec,
+			RecordAs:  "Tweet",
+		},
+		want: apis.ErrInvalidValue("Tweet", "spec.recordAs"),
+	}, {
+		name: "missing SecretRef",
+		spec: VSphereSinkSpec{
+			VAuthSpec: VAuthSpec{
+				Address: validVAuthSpec.Address,
+			},
+			RecordAs: RecordAsUserEvent,
+		},
+		want: apis.ErrMissingField("spec.secretRef.name"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vs := &VSphereSink{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "valid",
+					Namespace: "knobots",
+				},
+				Spec: test.spec,
+			}
+			got := vs.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
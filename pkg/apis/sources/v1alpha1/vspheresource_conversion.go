@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.  v1alpha1 is the hub version, and
+// so the other versions implement the conversions to and from it.
+func (source *VSphereSource) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	if _, ok := to.(*VSphereSource); ok {
+		return fmt.Errorf("v1alpha1 is the hub version, got: %T", to)
+	}
+	return to.ConvertFrom(ctx, source)
+}
+
+// ConvertFrom implements apis.Convertible
+func (sink *VSphereSource) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	if _, ok := from.(*VSphereSource); ok {
+		return fmt.Errorf("v1alpha1 is the hub version, got: %T", from)
+	}
+	return from.ConvertTo(ctx, sink)
+}
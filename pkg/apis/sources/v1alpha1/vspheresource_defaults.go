@@ -19,11 +19,76 @@ package v1alpha1
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
 )
 
 // SetDefaults implements apis.Defaultable
 func (as *VSphereSource) SetDefaults(ctx context.Context) {
 	withNS := apis.WithinParent(ctx, as.ObjectMeta)
 	as.Spec.Sink.SetDefaults(withNS)
+	for i := range as.Spec.Routes {
+		as.Spec.Routes[i].Sink.SetDefaults(withNS)
+	}
+	if as.Spec.ReplySink != nil {
+		as.Spec.ReplySink.SetDefaults(withNS)
+	}
+	as.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (ass *VSphereSourceSpec) SetDefaults(ctx context.Context) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+
+	switch {
+	case ass.ConnectionRef != nil:
+		// The connection settings come from the referenced binding.
+	case defaults.ConnectionName != "" && ass.Address.Host == "" && ass.SecretRef.Name == "":
+		ass.ConnectionRef = &corev1.LocalObjectReference{Name: defaults.ConnectionName}
+	default:
+		if ass.Address.Host == "" && defaults.Address != nil {
+			// The TLS mode goes with the address.
+			ass.Address = *defaults.Address.DeepCopy()
+			ass.SkipTLSVerify = defaults.SkipTLSVerify
+		}
+		if ass.SecretRef.Name == "" {
+			ass.SecretRef.Name = defaults.SecretName
+		}
+	}
+
+	if ass.Filter == "" {
+		ass.Filter = defaults.Filter
+	}
+
+	if len(defaults.AdapterResources.Requests) > 0 || len(defaults.AdapterResources.Limits) > 0 {
+		if ass.AdapterTemplate == nil {
+			ass.AdapterTemplate = &corev1.PodTemplateSpec{}
+		}
+		containers := ass.AdapterTemplate.Spec.Containers
+		if len(containers) == 0 {
+			containers = []corev1.Container{{Name: AdapterContainerName}}
+		}
+		// Only fill in the resources that the template leaves out.
+		res := &containers[0].Resources
+		res.Requests = defaultResources(res.Requests, defaults.AdapterResources.Requests)
+		res.Limits = defaultResources(res.Limits, defaults.AdapterResources.Limits)
+		ass.AdapterTemplate.Spec.Containers = containers
+	}
+}
+
+// defaultResources returns rl with the resources in defaults that it
+// doesn't list added.
+func defaultResources(rl, defaults corev1.ResourceList) corev1.ResourceList {
+	for name, q := range defaults {
+		if _, ok := rl[name]; ok {
+			continue
+		}
+		if rl == nil {
+			rl = corev1.ResourceList{}
+		}
+		rl[name] = q.DeepCopy()
+	}
+	return rl
 }
@@ -21,8 +21,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
 )
 
 func TestVSphereSourceDefaulting(t *testing.T) {
@@ -89,6 +95,88 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 				VAuthSpec: validVAuthSpec,
 			},
 		},
+	}, {
+		name: "route ref gets namespace",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType == "UserLoginSessionEvent"`,
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "serving.knative.dev",
+							Kind:       "Service",
+							Name:       "no-namespace",
+						},
+					},
+				}},
+			},
+		},
+		want: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType == "UserLoginSessionEvent"`,
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "serving.knative.dev",
+							Kind:       "Service",
+							Namespace:  "with-namespace",
+							Name:       "no-namespace",
+						},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "reply sink ref gets namespace",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				ReplySink: &duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "serving.knative.dev",
+						Kind:       "Service",
+						Name:       "no-namespace",
+					},
+				},
+			},
+		},
+		want: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "with-namespace",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				ReplySink: &duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "serving.knative.dev",
+						Kind:       "Service",
+						Namespace:  "with-namespace",
+						Name:       "no-namespace",
+					},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -101,3 +189,160 @@ func TestVSphereSourceDefaulting(t *testing.T) {
 		})
 	}
 }
+
+func TestVSphereSourceDefaultingFromConfig(t *testing.T) {
+	defaults, err := config.NewDefaultsConfigFromMap(map[string]string{
+		"default-address":        "https://vcenter.local",
+		"default-tls-mode":       "skip",
+		"default-secret-name":    "vsphere-credentials",
+		"default-filter":         `eventType != "UserLoginSessionEvent"`,
+		"adapter-memory-request": "64Mi",
+		"adapter-memory-limit":   "128Mi",
+	})
+	if err != nil {
+		t.Fatalf("NewDefaultsConfigFromMap() = %v", err)
+	}
+	withConnection := defaults.DeepCopy()
+	withConnection.ConnectionName = "vcenter"
+
+	tests := []struct {
+		name     string
+		defaults *config.Defaults
+		c        *VSphereSourceSpec
+		want     *VSphereSourceSpec
+	}{{
+		name:     "everything defaulted",
+		defaults: defaults,
+		c:        &VSphereSourceSpec{},
+		want: &VSphereSourceSpec{
+			VAuthSpec: VAuthSpec{
+				Address:       *apis.HTTPS("vcenter.local"),
+				SkipTLSVerify: true,
+				SecretRef:     corev1.LocalObjectReference{Name: "vsphere-credentials"},
+			},
+			Filter: `eventType != "UserLoginSessionEvent"`,
+			AdapterTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: AdapterContainerName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, {
+		name:     "nothing defaulted",
+		defaults: defaults,
+		c: &VSphereSourceSpec{
+			VAuthSpec: validVAuthSpec,
+			Filter:    "true",
+			AdapterTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("32Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("32Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+		want: &VSphereSourceSpec{
+			VAuthSpec: validVAuthSpec,
+			Filter:    "true",
+			AdapterTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("32Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("32Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, {
+		name:     "default connection",
+		defaults: withConnection,
+		c: &VSphereSourceSpec{
+			Filter: "true",
+		},
+		want: &VSphereSourceSpec{
+			ConnectionRef: &corev1.LocalObjectReference{Name: "vcenter"},
+			Filter:        "true",
+			AdapterTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: AdapterContainerName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, {
+		name:     "default connection with a secret",
+		defaults: withConnection,
+		c: &VSphereSourceSpec{
+			VAuthSpec: VAuthSpec{
+				SecretRef: corev1.LocalObjectReference{Name: "mine"},
+			},
+			Filter: "true",
+		},
+		want: &VSphereSourceSpec{
+			VAuthSpec: VAuthSpec{
+				Address:       *apis.HTTPS("vcenter.local"),
+				SkipTLSVerify: true,
+				SecretRef:     corev1.LocalObjectReference{Name: "mine"},
+			},
+			Filter: "true",
+			AdapterTemplate: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: AdapterContainerName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{Defaults: test.defaults})
+			got := test.c.DeepCopy()
+			got.SetDefaults(ctx)
+			if !equality.Semantic.DeepEqual(test.want, got) {
+				t.Errorf("SetDefaults (-want, +got) = %v", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
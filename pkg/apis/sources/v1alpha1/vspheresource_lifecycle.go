@@ -28,6 +28,7 @@ var condSet = apis.NewLivingConditionSet(
 	VSphereSourceConditionSourceReady,
 	VSphereSourceConditionAuthReady,
 	VSphereSourceConditionAdapterReady,
+	VSphereSourceConditionRoutesReady,
 )
 
 // GetGroupVersionKind implements kmeta.OwnerRefable
@@ -72,6 +73,13 @@ func (ass *VSphereSourceStatus) PropagateAuthStatus(status duckv1.Status) {
 	}
 }
 
+// MarkAuthNotReady sets the condition that the source's connection to
+// vSphere is ready to False, e.g. because the binding that it references
+// doesn't exist.
+func (ass *VSphereSourceStatus) MarkAuthNotReady(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(ass).MarkFalse(VSphereSourceConditionAuthReady, reason, messageFormat, messageA...)
+}
+
 func (ass *VSphereSourceStatus) PropagateAdapterStatus(d appsv1.DeploymentStatus) {
 	// Check if the Deployment is available.
 	for _, cond := range d.Conditions {
@@ -90,3 +98,89 @@ func (ass *VSphereSourceStatus) PropagateAdapterStatus(d appsv1.DeploymentStatus
 
 	condSet.Manage(ass).MarkUnknown(VSphereSourceConditionAdapterReady, "", "")
 }
+
+// MarkResourceNotOwned records that a resource of the given kind already
+// exists with the name that the source would give it, but isn't owned by
+// the source, so we won't touch it.  It is reflected in the condition for
+// the part of the source that the resource belongs to.
+func (ass *VSphereSourceStatus) MarkResourceNotOwned(kind, name string) {
+	var cond apis.ConditionType = VSphereSourceConditionAdapterReady
+	switch kind {
+	case "SinkBinding":
+		cond = VSphereSourceConditionSourceReady
+	case "VSphereBinding":
+		cond = VSphereSourceConditionAuthReady
+	}
+	condSet.Manage(ass).MarkFalse(cond, "NotOwned",
+		"There is an existing %s %q that we do not own.", kind, name)
+}
+
+// MarkRoutesReady records the resolved sinks of the source's routes.
+func (ass *VSphereSourceStatus) MarkRoutesReady(routes []RouteStatus) {
+	ass.Routes = routes
+	condSet.Manage(ass).MarkTrue(VSphereSourceConditionRoutesReady)
+}
+
+// MarkRoutesNotReady records that the sink of one of the source's routes
+// could not be resolved.
+func (ass *VSphereSourceStatus) MarkRoutesNotReady(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(ass).MarkFalse(VSphereSourceConditionRoutesReady, reason, messageFormat, messageA...)
+}
+
+// MarkExpressionsHealthy records that the adapter has been able to evaluate
+// the source's filter and transform expressions.
+func (ass *VSphereSourceStatus) MarkExpressionsHealthy() {
+	condSet.Manage(ass).MarkTrue(VSphereSourceConditionExpressionsHealthy)
+}
+
+// MarkExpressionsFailing records that the adapter failed to evaluate the
+// source's filter or transform expressions against an event.
+func (ass *VSphereSourceStatus) MarkExpressionsFailing(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(ass).MarkFalse(VSphereSourceConditionExpressionsHealthy, reason, messageFormat, messageA...)
+}
+
+// ClearExpressionsStatus removes the ExpressionsHealthy condition, for
+// sources without expressions.
+func (ass *VSphereSourceStatus) ClearExpressionsStatus() {
+	condSet.Manage(ass).ClearCondition(VSphereSourceConditionExpressionsHealthy)
+}
+
+// MarkSuspended records that the source is suspended.
+func (ass *VSphereSourceStatus) MarkSuspended() {
+	condSet.Manage(ass).MarkTrue(VSphereSourceConditionSuspended)
+}
+
+// MarkResumed removes the Suspended condition, for sources that aren't
+// suspended.
+func (ass *VSphereSourceStatus) MarkResumed() {
+	condSet.Manage(ass).ClearCondition(VSphereSourceConditionSuspended)
+}
+
+// IsSuspended returns whether the source was last seen suspended.
+func (ass *VSphereSourceStatus) IsSuspended() bool {
+	return ass.GetCondition(VSphereSourceConditionSuspended).IsTrue()
+}
+
+// MarkStreaming records that the adapter has recently reported that it is
+// streaming events.
+func (ass *VSphereSourceStatus) MarkStreaming() {
+	condSet.Manage(ass).MarkTrue(VSphereSourceConditionStreaming)
+}
+
+// MarkNotStreaming records that the adapter hasn't reported that it is
+// streaming events recently.
+func (ass *VSphereSourceStatus) MarkNotStreaming(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(ass).MarkFalse(VSphereSourceConditionStreaming, reason, messageFormat, messageA...)
+}
+
+// MarkStreamingUnknown records that the adapter hasn't reported on its
+// progress yet.
+func (ass *VSphereSourceStatus) MarkStreamingUnknown(reason, messageFormat string, messageA ...interface{}) {
+	condSet.Manage(ass).MarkUnknown(VSphereSourceConditionStreaming, reason, messageFormat, messageA...)
+}
+
+// ClearStreamingStatus removes the Streaming condition, for sources whose
+// adapter isn't expected to be running.
+func (ass *VSphereSourceStatus) ClearStreamingStatus() {
+	condSet.Manage(ass).ClearCondition(VSphereSourceConditionStreaming)
+}
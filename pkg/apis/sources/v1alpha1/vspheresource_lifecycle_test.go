@@ -152,7 +152,82 @@ func TestTypicalSourceFlow(t *testing.T) {
 		}},
 	})
 	apistest.CheckConditionSucceeded(r, VSphereSourceConditionAdapterReady, t)
+	apistest.CheckConditionOngoing(r, VSphereSourceConditionReady, t)
+
+	// Check the progression of the RoutesReady condition.
+	r.MarkRoutesNotReady("SinkNotFound", "route %q: not found", "security")
+	apistest.CheckConditionFailed(r, VSphereSourceConditionRoutesReady, t)
+	apistest.CheckConditionFailed(r, VSphereSourceConditionReady, t)
+	r.MarkRoutesReady([]RouteStatus{{
+		Name:    "security",
+		SinkURI: apis.HTTP("security.default.svc.cluster.local"),
+	}})
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionRoutesReady, t)
+	if got, want := len(r.Routes), 1; got != want {
+		t.Errorf("len(Routes) = %d, wanted %d", got, want)
+	}
 
 	// After all of that, we're finally ready!
 	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+
+	// Failing expressions are surfaced, but don't affect readiness.
+	r.MarkExpressionsFailing("EvaluationFailed", "no such key: %s", "foo")
+	if got := r.GetCondition(VSphereSourceConditionExpressionsHealthy); got == nil || got.Status != corev1.ConditionFalse {
+		t.Errorf("ExpressionsHealthy = %v, wanted False", got)
+	}
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+	r.MarkExpressionsHealthy()
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionExpressionsHealthy, t)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+	r.ClearExpressionsStatus()
+	if got := r.GetCondition(VSphereSourceConditionExpressionsHealthy); got != nil {
+		t.Errorf("ExpressionsHealthy = %v, wanted nil", got)
+	}
+
+	// Suspension is surfaced, but doesn't affect readiness either.
+	r.MarkSuspended()
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionSuspended, t)
+	apistest.CheckConditionSucceeded(r, VSphereSourceConditionReady, t)
+	if !r.IsSuspended() {
+		t.Error("IsSuspended() = false, wanted true")
+	}
+	r.MarkResumed()
+	if got := r.GetCondition(VSphereSourceConditionSuspended); got != nil {
+		t.Errorf("Suspended = %v, wanted nil", got)
+	}
+	if r.IsSuspended() {
+		t.Error("IsSuspended() = true, wanted false")
+	}
+}
+
+func TestMarkResourceNotOwned(t *testing.T) {
+	tests := []struct {
+		kind string
+		cond apis.ConditionType
+	}{{
+		kind: "SinkBinding",
+		cond: VSphereSourceConditionSourceReady,
+	}, {
+		kind: "VSphereBinding",
+		cond: VSphereSourceConditionAuthReady,
+	}, {
+		kind: "Deployment",
+		cond: VSphereSourceConditionAdapterReady,
+	}, {
+		kind: "RoleBinding",
+		cond: VSphereSourceConditionAdapterReady,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.kind, func(t *testing.T) {
+			r := &VSphereSourceStatus{}
+			r.InitializeConditions()
+			r.MarkResourceNotOwned(test.kind, "foo")
+			apistest.CheckConditionFailed(r, test.cond, t)
+			apistest.CheckConditionFailed(r, VSphereSourceConditionReady, t)
+			if got, want := r.GetCondition(test.cond).Reason, "NotOwned"; got != want {
+				t.Errorf("Reason = %s, wanted %s", got, want)
+			}
+		})
+	}
 }
@@ -18,16 +18,258 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 )
 
 // Validate implements apis.Validatable
 func (fb *VSphereSource) Validate(ctx context.Context) *apis.FieldError {
-	return fb.Spec.Validate(ctx).ViaField("spec")
+	err := fb.Spec.Validate(ctx).ViaField("spec")
+	if err != nil {
+		return err
+	}
+	fb.Spec.VAuthSpec.WarnAboutSecret(ctx, fb.Namespace)
+	return fb.CheckPolicy(ctx).ViaField("spec")
 }
 
 // Validate implements apis.Validatable
 func (fbs *VSphereSourceSpec) Validate(ctx context.Context) *apis.FieldError {
-	return fbs.Sink.Validate(ctx).ViaField("sink").Also(fbs.VAuthSpec.Validate(ctx))
+	err := fbs.Sink.Validate(ctx).ViaField("sink")
+	if fbs.ConnectionRef != nil {
+		// The connection settings come from the referenced binding.
+		if fbs.ConnectionRef.Name == "" {
+			err = err.Also(apis.ErrMissingField("connectionRef.name"))
+		}
+		if fbs.Address.Host != "" {
+			err = err.Also(apis.ErrMultipleOneOf("address", "connectionRef"))
+		}
+		if fbs.SecretRef.Name != "" {
+			err = err.Also(apis.ErrMultipleOneOf("secretRef", "connectionRef"))
+		}
+	} else {
+		err = err.Also(fbs.VAuthSpec.Validate(ctx))
+	}
+	if fbs.Scope != "" && !strings.HasPrefix(fbs.Scope, "/") {
+		err = err.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid inventory path %q", fbs.Scope),
+			Paths:   []string{"scope"},
+			Details: "inventory paths are absolute, e.g. /dc1/vm/prod",
+		})
+	}
+	if fbs.Delivery != nil {
+		err = err.Also(ValidateDelivery(fbs.Delivery).ViaField("delivery"))
+	}
+	if fbs.Selector != nil {
+		err = err.Also(fbs.Selector.Validate(ctx).ViaField("selector"))
+	}
+	if fbs.Filter != "" {
+		if _, ferr := expr.NewFilter(fbs.Filter); ferr != nil {
+			err = err.Also(invalidExpression(ferr, "filter"))
+		}
+	}
+	if fbs.Transform != nil {
+		err = err.Also(fbs.Transform.Validate(ctx).ViaField("transform"))
+	}
+	names := make(map[string]struct{}, len(fbs.Routes))
+	for i, route := range fbs.Routes {
+		if _, ok := names[route.Name]; ok {
+			err = err.Also(&apis.FieldError{
+				Message: fmt.Sprintf("duplicate route name %q", route.Name),
+				Paths:   []string{"name"},
+			}).ViaFieldIndex("routes", i)
+		}
+		names[route.Name] = struct{}{}
+		err = err.Also(route.Validate(ctx).ViaFieldIndex("routes", i))
+	}
+	if fbs.ReplySink != nil {
+		err = err.Also(fbs.ReplySink.Validate(ctx).ViaField("replySink"))
+	}
+	if fbs.AdapterTemplate != nil {
+		err = err.Also(validateAdapterTemplate(fbs.AdapterTemplate).ViaField("adapterTemplate"))
+	}
+	return err
+}
+
+// ValidateDelivery checks that the adapter supports the delivery spec.
+func ValidateDelivery(ds *eventingduckv1beta1.DeliverySpec) (err *apis.FieldError) {
+	if ds.DeadLetterSink != nil {
+		err = err.Also(apis.ErrDisallowedFields("deadLetterSink"))
+	}
+	if ds.Retry != nil && *ds.Retry < 0 {
+		err = err.Also(apis.ErrInvalidValue(*ds.Retry, "retry"))
+	}
+	if ds.BackoffPolicy != nil {
+		switch *ds.BackoffPolicy {
+		case eventingduckv1beta1.BackoffPolicyLinear, eventingduckv1beta1.BackoffPolicyExponential:
+		default:
+			err = err.Also(apis.ErrInvalidValue(*ds.BackoffPolicy, "backoffPolicy"))
+		}
+	}
+	if ds.BackoffDelay != nil {
+		if _, perr := vsphere.ParseBackoffDelay(*ds.BackoffDelay); perr != nil {
+			err = err.Also(apis.ErrInvalidValue(*ds.BackoffDelay, "backoffDelay"))
+		}
+	}
+	return err
+}
+
+// validateAdapterTemplate checks that the adapterTemplate doesn't override
+// any of the fields of the adapter's pod template that the controller sets.
+func validateAdapterTemplate(pt *corev1.PodTemplateSpec) (err *apis.FieldError) {
+	if pt.Name != "" {
+		err = err.Also(apis.ErrDisallowedFields("metadata.name"))
+	}
+	if pt.Namespace != "" {
+		err = err.Also(apis.ErrDisallowedFields("metadata.namespace"))
+	}
+	if _, ok := pt.Labels[VSphereSourceLabelKey]; ok {
+		err = err.Also(apis.ErrInvalidKeyName(VSphereSourceLabelKey, "metadata.labels", "is reserved"))
+	}
+
+	spec := pt.Spec
+	if spec.ServiceAccountName != "" {
+		err = err.Also(apis.ErrDisallowedFields("spec.serviceAccountName"))
+	}
+	for i, v := range spec.Volumes {
+		if v.Name == vsphere.VolumeName {
+			err = err.Also(apis.ErrInvalidValue(v.Name, "name").ViaFieldIndex("spec.volumes", i))
+		}
+	}
+	switch len(spec.Containers) {
+	case 0:
+	case 1:
+		err = err.Also(validateAdapterContainer(&spec.Containers[0]).ViaFieldIndex("spec.containers", 0))
+	default:
+		err = err.Also(apis.ErrOutOfBoundsValue(len(spec.Containers), 0, 1, "spec.containers"))
+	}
+	return err
+}
+
+// validateAdapterContainer checks that the adapter's container in the
+// adapterTemplate doesn't override any of the fields that the controller
+// sets.
+func validateAdapterContainer(c *corev1.Container) (err *apis.FieldError) {
+	if c.Name != "" && c.Name != AdapterContainerName {
+		err = err.Also(apis.ErrInvalidValue(c.Name, "name"))
+	}
+	if c.Image != "" {
+		err = err.Also(apis.ErrDisallowedFields("image"))
+	}
+	if len(c.Command) > 0 {
+		err = err.Also(apis.ErrDisallowedFields("command"))
+	}
+	if len(c.Args) > 0 {
+		err = err.Also(apis.ErrDisallowedFields("args"))
+	}
+	for i, ev := range c.Env {
+		if reservedEnvVar(ev.Name) {
+			err = err.Also(apis.ErrInvalidValue(ev.Name, "name").ViaFieldIndex("env", i))
+		}
+	}
+	for i, vm := range c.VolumeMounts {
+		if vm.Name == vsphere.VolumeName || vm.MountPath == vsphere.MountPath {
+			err = err.Also(apis.ErrInvalidValue(vm.Name, "name").ViaFieldIndex("volumeMounts", i))
+		}
+	}
+	return err
+}
+
+// reservedEnvVar returns whether the named environment variable is one
+// that the controller, or one of the bindings of the adapter, sets.
+func reservedEnvVar(name string) bool {
+	switch name {
+	case "NAMESPACE", "NAME", "K_METRICS_CONFIG", "K_LOGGING_CONFIG", "K_SINK", "K_CE_OVERRIDES":
+		return true
+	}
+	return strings.HasPrefix(name, "VSPHERE_") || strings.HasPrefix(name, "GOVC_")
+}
+
+// Validate implements apis.Validatable
+func (er *EventRoute) Validate(ctx context.Context) (err *apis.FieldError) {
+	if er.Name == "" {
+		err = err.Also(apis.ErrMissingField("name"))
+	} else if msgs := validation.IsDNS1123Label(er.Name); len(msgs) > 0 {
+		err = err.Also(apis.ErrInvalidValue(er.Name, "name"))
+	}
+	if er.Filter == "" {
+		err = err.Also(apis.ErrMissingField("filter"))
+	} else if _, ferr := expr.NewFilter(er.Filter); ferr != nil {
+		err = err.Also(invalidExpression(ferr, "filter"))
+	}
+	return err.Also(er.Sink.Validate(ctx).ViaField("sink"))
+}
+
+// Validate implements apis.Validatable
+func (et *EventTransform) Validate(ctx context.Context) (err *apis.FieldError) {
+	if et.Data == "" && len(et.Extensions) == 0 {
+		return apis.ErrMissingOneOf("data", "extensions")
+	}
+	if et.Data != "" {
+		if _, terr := expr.NewTransform(et.Data, nil); terr != nil {
+			err = err.Also(invalidExpression(terr, "data"))
+		}
+	}
+	for name, src := range et.Extensions {
+		if !validExtensionName(name) {
+			err = err.Also(apis.ErrInvalidKeyName(name, "extensions",
+				"must consist of 1 to 20 lower-case letters and digits"))
+			continue
+		}
+		if _, terr := expr.NewTransform("", map[string]string{name: src}); terr != nil {
+			err = err.Also(invalidExpression(terr, apis.CurrentField).ViaFieldKey("extensions", name))
+		}
+	}
+	return err
+}
+
+// invalidExpression returns a FieldError describing an expression that
+// failed to compile.
+func invalidExpression(err error, field string) *apis.FieldError {
+	return &apis.FieldError{
+		Message: "invalid expression",
+		Paths:   []string{field},
+		Details: err.Error(),
+	}
+}
+
+// validExtensionName returns whether name is a valid CloudEvents
+// extension attribute name.
+func validExtensionName(name string) bool {
+	if len(name) == 0 || len(name) > 20 {
+		return false
+	}
+	for _, r := range name {
+		if !('a' <= r && r <= 'z') && !('0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate implements apis.Validatable
+func (es *EntitySelector) Validate(ctx context.Context) (err *apis.FieldError) {
+	if len(es.Tags) == 0 && len(es.CustomAttributes) == 0 {
+		return apis.ErrMissingOneOf("tags", "customAttributes")
+	}
+	for i, ts := range es.Tags {
+		if ts.Category == "" {
+			err = err.Also(apis.ErrMissingField("category").ViaFieldIndex("tags", i))
+		}
+		if ts.Name == "" {
+			err = err.Also(apis.ErrMissingField("name").ViaFieldIndex("tags", i))
+		}
+	}
+	for i, cas := range es.CustomAttributes {
+		if cas.Name == "" {
+			err = err.Also(apis.ErrMissingField("name").ViaFieldIndex("customAttributes", i))
+		}
+	}
+	return err
 }
@@ -20,14 +20,22 @@ import (
 	"context"
 	"testing"
 
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
+	exponential = eventingduckv1beta1.BackoffPolicyExponential
+	bogusPolicy = eventingduckv1beta1.BackoffPolicyType("bogus")
+
 	validSourceSpec = duckv1.SourceSpec{
 		Sink: duckv1.Destination{
 			URI: &apis.URL{
@@ -79,6 +87,432 @@ func TestVSphereSourceValidation(t *testing.T) {
 			},
 		},
 		want: apis.ErrGeneric("expected at least one, got none", "spec.sink.ref", "spec.sink.uri"),
+	}, {
+		name: "valid selector",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Selector: &EntitySelector{
+					Tags: []TagSelector{{
+						Category: "env",
+						Name:     "prod",
+					}},
+					CustomAttributes: []CustomAttributeSelector{{
+						Name:  "owner",
+						Value: "team-x",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "empty selector",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Selector:   &EntitySelector{},
+			},
+		},
+		want: apis.ErrMissingOneOf("spec.selector.tags", "spec.selector.customAttributes"),
+	}, {
+		name: "incomplete selector",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Selector: &EntitySelector{
+					Tags: []TagSelector{{
+						Name: "prod",
+					}, {
+						Category: "env",
+					}},
+					CustomAttributes: []CustomAttributeSelector{{
+						Value: "team-x",
+					}},
+				},
+			},
+		},
+		want: apis.ErrMissingField(
+			"spec.selector.tags[0].category",
+			"spec.selector.tags[1].name",
+			"spec.selector.customAttributes[0].name",
+		),
+	}, {
+		name: "valid filter and transform",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Filter:     `eventType == "VmPoweredOnEvent" && event.Vm.Name.startsWith("prod-")`,
+				Transform: &EventTransform{
+					Data: `{"vm": event.Vm.Name}`,
+					Extensions: map[string]string{
+						"vmname": `event.Vm.Name`,
+					},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid filter",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Filter:     `eventType ==`,
+			},
+		},
+		want: invalidExpression(filterError(`eventType ==`), "spec.filter"),
+	}, {
+		name: "non-bool filter",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Filter:     `eventType`,
+			},
+		},
+		want: invalidExpression(filterError(`eventType`), "spec.filter"),
+	}, {
+		name: "empty transform",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Transform:  &EventTransform{},
+			},
+		},
+		want: apis.ErrMissingOneOf("spec.transform.data", "spec.transform.extensions"),
+	}, {
+		name: "bad extension name",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Transform: &EventTransform{
+					Extensions: map[string]string{
+						"vm-name": `event.Vm.Name`,
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName("vm-name", "spec.transform.extensions",
+			"must consist of 1 to 20 lower-case letters and digits"),
+	}, {
+		name: "valid routes",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType in ["UserLoginSessionEvent", "PermissionAddedEvent"]`,
+					Sink:   validSourceSpec.Sink,
+				}, {
+					Name:   "ops",
+					Filter: `eventType.startsWith("Vm")`,
+					Sink:   validSourceSpec.Sink,
+				}},
+			},
+		},
+		want: nil,
+	}, {
+		name: "incomplete route",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes:     []EventRoute{{}},
+			},
+		},
+		want: apis.ErrMissingField("spec.routes[0].name", "spec.routes[0].filter").Also(
+			apis.ErrGeneric("expected at least one, got none", "spec.routes[0].sink.ref", "spec.routes[0].sink.uri")),
+	}, {
+		name: "invalid route filter",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType ==`,
+					Sink:   validSourceSpec.Sink,
+				}},
+			},
+		},
+		want: invalidExpression(filterError(`eventType ==`), "spec.routes[0].filter"),
+	}, {
+		name: "invalid route name",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "Security_Events",
+					Filter: `eventType == "UserLoginSessionEvent"`,
+					Sink:   validSourceSpec.Sink,
+				}},
+			},
+		},
+		want: apis.ErrInvalidValue("Security_Events", "spec.routes[0].name"),
+	}, {
+		name: "duplicate route names",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType == "UserLoginSessionEvent"`,
+					Sink:   validSourceSpec.Sink,
+				}, {
+					Name:   "security",
+					Filter: `eventType == "PermissionAddedEvent"`,
+					Sink:   validSourceSpec.Sink,
+				}},
+			},
+		},
+		want: &apis.FieldError{
+			Message: `duplicate route name "security"`,
+			Paths:   []string{"spec.routes[1].name"},
+		},
+	}, {
+		name: "valid reply sink",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				ReplySink:  &validSourceSpec.Sink,
+			},
+		},
+		want: nil,
+	}, {
+		name: "empty reply sink",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				ReplySink:  &duckv1.Destination{},
+			},
+		},
+		want: apis.ErrGeneric("expected at least one, got none", "spec.replySink.ref", "spec.replySink.uri"),
+	}, {
+		name: "valid adapter template",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				AdapterTemplate: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"sidecar.istio.io/inject": "false",
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeSelector: map[string]string{
+							"kubernetes.io/os": "linux",
+						},
+						PriorityClassName: "high",
+						ImagePullSecrets: []corev1.LocalObjectReference{{
+							Name: "regcred",
+						}},
+						Containers: []corev1.Container{{
+							Name: "adapter",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+							},
+							Env: []corev1.EnvVar{{
+								Name:  "HTTPS_PROXY",
+								Value: "http://proxy.local:3128",
+							}},
+						}},
+					},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "adapter template overrides owned fields",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				AdapterTemplate: &corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							VSphereSourceLabelKey: "other",
+						},
+					},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "admin",
+						Containers: []corev1.Container{{
+							Name:  "sidecar",
+							Image: "busybox",
+							Env: []corev1.EnvVar{{
+								Name:  "K_SINK",
+								Value: "http://elsewhere",
+							}},
+						}},
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName(VSphereSourceLabelKey, "spec.adapterTemplate.metadata.labels", "is reserved").Also(
+			apis.ErrDisallowedFields("spec.adapterTemplate.spec.serviceAccountName"),
+			apis.ErrInvalidValue("sidecar", "spec.adapterTemplate.spec.containers[0].name"),
+			apis.ErrDisallowedFields("spec.adapterTemplate.spec.containers[0].image"),
+			apis.ErrInvalidValue("K_SINK", "spec.adapterTemplate.spec.containers[0].env[0].name"),
+		),
+	}, {
+		name: "adapter template with extra containers",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				AdapterTemplate: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name: "adapter",
+						}, {
+							Name:  "sidecar",
+							Image: "busybox",
+						}},
+					},
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue(2, 0, 1, "spec.adapterTemplate.spec.containers"),
+	}, {
+		name: "valid connectionRef, scope and delivery",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:    validSourceSpec,
+				ConnectionRef: &corev1.LocalObjectReference{Name: "vcenter"},
+				Scope:         "/dc1/vm/prod",
+				Delivery: &eventingduckv1beta1.DeliverySpec{
+					Retry:         ptr.Int32(3),
+					BackoffPolicy: &exponential,
+					BackoffDelay:  ptr.String("PT0.5S"),
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "connectionRef with connection settings",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:    validSourceSpec,
+				VAuthSpec:     validVAuthSpec,
+				ConnectionRef: &corev1.LocalObjectReference{},
+			},
+		},
+		want: apis.ErrMissingField("spec.connectionRef.name").Also(
+			apis.ErrMultipleOneOf("spec.address", "spec.connectionRef"),
+			apis.ErrMultipleOneOf("spec.secretRef", "spec.connectionRef"),
+		),
+	}, {
+		name: "relative scope",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Scope:      "dc1/vm",
+			},
+		},
+		want: &apis.FieldError{
+			Message: `invalid inventory path "dc1/vm"`,
+			Paths:   []string{"spec.scope"},
+			Details: "inventory paths are absolute, e.g. /dc1/vm/prod",
+		},
+	}, {
+		name: "unsupported delivery",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: validSourceSpec,
+				VAuthSpec:  validVAuthSpec,
+				Delivery: &eventingduckv1beta1.DeliverySpec{
+					DeadLetterSink: &validSourceSpec.Sink,
+					Retry:          ptr.Int32(-1),
+					BackoffPolicy:  &bogusPolicy,
+					BackoffDelay:   ptr.String("1s"),
+				},
+			},
+		},
+		want: apis.ErrDisallowedFields("spec.delivery.deadLetterSink").Also(
+			apis.ErrInvalidValue(-1, "spec.delivery.retry"),
+			apis.ErrInvalidValue("bogus", "spec.delivery.backoffPolicy"),
+			apis.ErrInvalidValue("1s", "spec.delivery.backoffDelay"),
+		),
 	}}
 
 	for _, test := range tests {
@@ -91,3 +525,8 @@ func TestVSphereSourceValidation(t *testing.T) {
 		})
 	}
 }
+
+func filterError(src string) error {
+	_, err := expr.NewFilter(src)
+	return err
+}
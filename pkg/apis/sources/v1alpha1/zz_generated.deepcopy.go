@@ -21,9 +21,328 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+	apis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionFilter) DeepCopyInto(out *ActionFilter) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionFilter.
+func (in *ActionFilter) DeepCopy() *ActionFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionOperation) DeepCopyInto(out *ActionOperation) {
+	*out = *in
+	if in.AttachTag != nil {
+		in, out := &in.AttachTag, &out.AttachTag
+		*out = new(TagAction)
+		**out = **in
+	}
+	if in.DetachTag != nil {
+		in, out := &in.DetachTag, &out.DetachTag
+		*out = new(TagAction)
+		**out = **in
+	}
+	if in.SetCustomAttribute != nil {
+		in, out := &in.SetCustomAttribute, &out.SetCustomAttribute
+		*out = new(CustomAttributeAction)
+		**out = **in
+	}
+	if in.PowerOn != nil {
+		in, out := &in.PowerOn, &out.PowerOn
+		*out = new(PowerAction)
+		**out = **in
+	}
+	if in.PowerOff != nil {
+		in, out := &in.PowerOff, &out.PowerOff
+		*out = new(PowerAction)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(SnapshotAction)
+		**out = **in
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(MigrateAction)
+		**out = **in
+	}
+	if in.Annotate != nil {
+		in, out := &in.Annotate, &out.Annotate
+		*out = new(AnnotateAction)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionOperation.
+func (in *ActionOperation) DeepCopy() *ActionOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnnotateAction) DeepCopyInto(out *AnnotateAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotateAction.
+func (in *AnnotateAction) DeepCopy() *AnnotateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AnnotateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAttributeAction) DeepCopyInto(out *CustomAttributeAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeAction.
+func (in *CustomAttributeAction) DeepCopy() *CustomAttributeAction {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAttributeAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAttributeSelector) DeepCopyInto(out *CustomAttributeSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeSelector.
+func (in *CustomAttributeSelector) DeepCopy() *CustomAttributeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAttributeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntitySelector) DeepCopyInto(out *EntitySelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]TagSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomAttributes != nil {
+		in, out := &in.CustomAttributes, &out.CustomAttributes
+		*out = make([]CustomAttributeSelector, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntitySelector.
+func (in *EntitySelector) DeepCopy() *EntitySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(EntitySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRoute) DeepCopyInto(out *EventRoute) {
+	*out = *in
+	in.Sink.DeepCopyInto(&out.Sink)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRoute.
+func (in *EventRoute) DeepCopy() *EventRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventTransform) DeepCopyInto(out *EventTransform) {
+	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTransform.
+func (in *EventTransform) DeepCopy() *EventTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(EventTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrateAction) DeepCopyInto(out *MigrateAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateAction.
+func (in *MigrateAction) DeepCopy() *MigrateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerAction) DeepCopyInto(out *PowerAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerAction.
+func (in *PowerAction) DeepCopy() *PowerAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
+	*out = *in
+	if in.SinkURI != nil {
+		in, out := &in.SinkURI, &out.SinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteStatus.
+func (in *RouteStatus) DeepCopy() *RouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotAction) DeepCopyInto(out *SnapshotAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotAction.
+func (in *SnapshotAction) DeepCopy() *SnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamingStatus) DeepCopyInto(out *StreamingStatus) {
+	*out = *in
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamingStatus.
+func (in *StreamingStatus) DeepCopy() *StreamingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagAction) DeepCopyInto(out *TagAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagAction.
+func (in *TagAction) DeepCopy() *TagAction {
+	if in == nil {
+		return nil
+	}
+	out := new(TagAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagSelector) DeepCopyInto(out *TagSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagSelector.
+func (in *TagSelector) DeepCopy() *TagSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TagSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VAuthSpec) DeepCopyInto(out *VAuthSpec) {
 	*out = *in
@@ -42,6 +361,108 @@ func (in *VAuthSpec) DeepCopy() *VAuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereAction) DeepCopyInto(out *VSphereAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereAction.
+func (in *VSphereAction) DeepCopy() *VSphereAction {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionList) DeepCopyInto(out *VSphereActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionList.
+func (in *VSphereActionList) DeepCopy() *VSphereActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionSpec) DeepCopyInto(out *VSphereActionSpec) {
+	*out = *in
+	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(ActionFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Operation.DeepCopyInto(&out.Operation)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionSpec.
+func (in *VSphereActionSpec) DeepCopy() *VSphereActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereActionStatus) DeepCopyInto(out *VSphereActionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereActionStatus.
+func (in *VSphereActionStatus) DeepCopy() *VSphereActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereBinding) DeepCopyInto(out *VSphereBinding) {
 	*out = *in
@@ -138,6 +559,102 @@ func (in *VSphereBindingStatus) DeepCopy() *VSphereBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereSink) DeepCopyInto(out *VSphereSink) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereSink.
+func (in *VSphereSink) DeepCopy() *VSphereSink {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereSink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereSinkList) DeepCopyInto(out *VSphereSinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereSinkList.
+func (in *VSphereSinkList) DeepCopy() *VSphereSinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereSinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereSinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereSinkSpec) DeepCopyInto(out *VSphereSinkSpec) {
+	*out = *in
+	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereSinkSpec.
+func (in *VSphereSinkSpec) DeepCopy() *VSphereSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereSinkStatus) DeepCopyInto(out *VSphereSinkStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereSinkStatus.
+func (in *VSphereSinkStatus) DeepCopy() *VSphereSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereSource) DeepCopyInto(out *VSphereSource) {
 	*out = *in
@@ -204,6 +721,43 @@ func (in *VSphereSourceSpec) DeepCopyInto(out *VSphereSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	in.VAuthSpec.DeepCopyInto(&out.VAuthSpec)
+	if in.ConnectionRef != nil {
+		in, out := &in.ConnectionRef, &out.ConnectionRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(v1beta1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(EntitySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(EventTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]EventRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplySink != nil {
+		in, out := &in.ReplySink, &out.ReplySink
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdapterTemplate != nil {
+		in, out := &in.AdapterTemplate, &out.AdapterTemplate
+		*out = new(v1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -221,6 +775,23 @@ func (in *VSphereSourceSpec) DeepCopy() *VSphereSourceSpec {
 func (in *VSphereSourceStatus) DeepCopyInto(out *VSphereSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]RouteStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplySinkURI != nil {
+		in, out := &in.ReplySinkURI, &out.ReplySinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(StreamingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible
+func (source *VSphereBinding) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.VSphereBinding:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec.BindingSpec = source.Spec.BindingSpec
+		sink.Spec.VAuthSpec = v1alpha1.VAuthSpec(source.Spec.VAuthSpec)
+		sink.Status.Status = source.Status.Status
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertFrom implements apis.Convertible
+func (sink *VSphereBinding) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.VSphereBinding:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec.BindingSpec = source.Spec.BindingSpec
+		sink.Spec.VAuthSpec = VAuthSpec(source.Spec.VAuthSpec)
+		sink.Status.Status = source.Status.Status
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/tracker"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+func TestVSphereBindingConversionBadType(t *testing.T) {
+	good, bad := &VSphereBinding{}, &VSphereSource{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestVSphereBindingConversionRoundTrip(t *testing.T) {
+	in := &VSphereBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "binding",
+			Namespace: "default",
+		},
+		Spec: VSphereBindingSpec{
+			BindingSpec: duckv1alpha1.BindingSpec{
+				Subject: tracker.Reference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Namespace:  "default",
+					Name:       "app",
+				},
+			},
+			VAuthSpec: vauth,
+		},
+	}
+
+	ctx := context.Background()
+	hub := &v1alpha1.VSphereBinding{}
+	if err := hub.ConvertFrom(ctx, in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	got := &VSphereBinding{}
+	if err := hub.ConvertTo(ctx, got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if !cmp.Equal(in, got) {
+		t.Errorf("roundtrip (-want, +got) = %s", cmp.Diff(in, got))
+	}
+
+	// The hub doesn't convert to itself.
+	if err := hub.ConvertTo(ctx, &v1alpha1.VSphereBinding{}); err == nil {
+		t.Error("ConvertTo(v1alpha1) = nil, wanted error")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereBinding describes a Binding that makes authenticating against
+// a vSphere API simple.
+type VSphereBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VSphereBindingSpec   `json:"spec"`
+	Status VSphereBindingStatus `json:"status"`
+}
+
+// Check the interfaces that VSphereBinding should be implementing.
+var (
+	_ runtime.Object   = (*VSphereBinding)(nil)
+	_ apis.Validatable = (*VSphereBinding)(nil)
+	_ apis.Defaultable = (*VSphereBinding)(nil)
+	_ apis.Convertible = (*VSphereBinding)(nil)
+)
+
+// VSphereBindingSpec holds the desired state of the VSphereBinding (from the client).
+type VSphereBindingSpec struct {
+	duckv1alpha1.BindingSpec `json:",inline"`
+
+	VAuthSpec `json:",inline"`
+}
+
+// VAuthSpec is the information used to authenticate with a vSphere API
+type VAuthSpec struct {
+	// Address contains the URL of the vSphere API.
+	Address apis.URL `json:"address"`
+
+	// SkipTLSVerify specifies whether the client should skip TLS verification when
+	// talking to the vsphere address.
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+
+	// SecretRef is a reference to a Kubernetes secret of type kubernetes.io/basic-auth
+	// which contains keys for "username" and "password", which will be used to authenticate
+	// with the vSphere API at "address".
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// VSphereBindingStatus communicates the observed state of the VSphereBinding (from the controller).
+type VSphereBindingStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereBindingList contains a list of VSphereBinding
+type VSphereBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereBinding `json:"items"`
+}
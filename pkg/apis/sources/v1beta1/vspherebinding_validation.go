@@ -0,0 +1,34 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (fb *VSphereBinding) Validate(ctx context.Context) *apis.FieldError {
+	// VSphereBinding is the same as in v1alpha1.
+	hub := &v1alpha1.VSphereBinding{}
+	if err := fb.ConvertTo(ctx, hub); err != nil {
+		return &apis.FieldError{Message: err.Error()}
+	}
+	return hub.Validate(ctx)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible
+func (source *VSphereSource) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.VSphereSource:
+		sink.ObjectMeta = source.ObjectMeta
+		source.Spec.ConvertTo(ctx, &sink.Spec)
+		source.Status.ConvertTo(ctx, &sink.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertTo helps implement apis.Convertible
+func (source *VSphereSourceSpec) ConvertTo(ctx context.Context, sink *v1alpha1.VSphereSourceSpec) {
+	sink.SourceSpec = source.SourceSpec
+	sink.VAuthSpec = v1alpha1.VAuthSpec(source.VAuthSpec)
+	sink.ConnectionRef = source.ConnectionRef
+	sink.Scope = source.Scope
+	sink.Delivery = source.Delivery
+	sink.Selector, sink.Filter = nil, ""
+	if source.Filters != nil {
+		if es := source.Filters.Selector; es != nil {
+			sink.Selector = &v1alpha1.EntitySelector{}
+			for _, ts := range es.Tags {
+				sink.Selector.Tags = append(sink.Selector.Tags, v1alpha1.TagSelector(ts))
+			}
+			for _, cas := range es.CustomAttributes {
+				sink.Selector.CustomAttributes = append(sink.Selector.CustomAttributes, v1alpha1.CustomAttributeSelector(cas))
+			}
+		}
+		sink.Filter = source.Filters.CEL
+	}
+	sink.Transform = nil
+	if source.Transform != nil {
+		t := v1alpha1.EventTransform(*source.Transform)
+		sink.Transform = &t
+	}
+	sink.Routes = nil
+	for _, r := range source.Routes {
+		sink.Routes = append(sink.Routes, v1alpha1.EventRoute(r))
+	}
+	sink.ReplySink = source.ReplySink
+	sink.AdapterTemplate = source.AdapterTemplate
+	sink.Suspend = source.Suspend
+	sink.SkipEventsWhileSuspended = source.SkipEventsWhileSuspended
+}
+
+// ConvertTo helps implement apis.Convertible
+func (source *VSphereSourceStatus) ConvertTo(ctx context.Context, sink *v1alpha1.VSphereSourceStatus) {
+	sink.SourceStatus = source.SourceStatus
+	sink.Routes = nil
+	for _, rs := range source.Routes {
+		sink.Routes = append(sink.Routes, v1alpha1.RouteStatus(rs))
+	}
+	sink.ReplySinkURI = source.ReplySinkURI
+	sink.Streaming = nil
+	if source.Streaming != nil {
+		ss := v1alpha1.StreamingStatus(*source.Streaming)
+		sink.Streaming = &ss
+	}
+}
+
+// ConvertFrom implements apis.Convertible
+func (sink *VSphereSource) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.VSphereSource:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec.ConvertFrom(ctx, &source.Spec)
+		sink.Status.ConvertFrom(ctx, &source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// ConvertFrom helps implement apis.Convertible
+func (sink *VSphereSourceSpec) ConvertFrom(ctx context.Context, source *v1alpha1.VSphereSourceSpec) {
+	sink.SourceSpec = source.SourceSpec
+	sink.VAuthSpec = VAuthSpec(source.VAuthSpec)
+	sink.ConnectionRef = source.ConnectionRef
+	sink.Scope = source.Scope
+	sink.Delivery = source.Delivery
+	sink.Filters = nil
+	if source.Selector != nil || source.Filter != "" {
+		sink.Filters = &EventFilters{CEL: source.Filter}
+		if es := source.Selector; es != nil {
+			sink.Filters.Selector = &EntitySelector{}
+			for _, ts := range es.Tags {
+				sink.Filters.Selector.Tags = append(sink.Filters.Selector.Tags, TagSelector(ts))
+			}
+			for _, cas := range es.CustomAttributes {
+				sink.Filters.Selector.CustomAttributes = append(sink.Filters.Selector.CustomAttributes, CustomAttributeSelector(cas))
+			}
+		}
+	}
+	sink.Transform = nil
+	if source.Transform != nil {
+		t := EventTransform(*source.Transform)
+		sink.Transform = &t
+	}
+	sink.Routes = nil
+	for _, r := range source.Routes {
+		sink.Routes = append(sink.Routes, EventRoute(r))
+	}
+	sink.ReplySink = source.ReplySink
+	sink.AdapterTemplate = source.AdapterTemplate
+	sink.Suspend = source.Suspend
+	sink.SkipEventsWhileSuspended = source.SkipEventsWhileSuspended
+}
+
+// ConvertFrom helps implement apis.Convertible
+func (sink *VSphereSourceStatus) ConvertFrom(ctx context.Context, source *v1alpha1.VSphereSourceStatus) {
+	sink.SourceStatus = source.SourceStatus
+	sink.Routes = nil
+	for _, rs := range source.Routes {
+		sink.Routes = append(sink.Routes, RouteStatus(rs))
+	}
+	sink.ReplySinkURI = source.ReplySinkURI
+	sink.Streaming = nil
+	if source.Streaming != nil {
+		ss := StreamingStatus(*source.Streaming)
+		sink.Streaming = &ss
+	}
+}
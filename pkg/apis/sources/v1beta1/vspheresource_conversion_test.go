@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+var (
+	sink = duckv1.Destination{
+		URI: apis.HTTP("sink.default.svc.cluster.local"),
+	}
+
+	vauth = VAuthSpec{
+		Address:   apis.URL{Scheme: "https", Host: "vcenter.local"},
+		SecretRef: corev1.LocalObjectReference{Name: "vsphere-credentials"},
+	}
+)
+
+func TestVSphereSourceConversionBadType(t *testing.T) {
+	good, bad := &VSphereSource{}, &VSphereBinding{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestVSphereSourceConversionRoundTrip(t *testing.T) {
+	heartbeat := metav1.Now()
+	tests := []struct {
+		name string
+		in   *VSphereSource
+	}{{
+		name: "minimal",
+		in: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "minimal",
+				Namespace:  "default",
+				Generation: 3,
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+				VAuthSpec:  vauth,
+			},
+		},
+	}, {
+		name: "full",
+		in: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "full",
+				Namespace: "default",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec:    duckv1.SourceSpec{Sink: sink},
+				ConnectionRef: &corev1.LocalObjectReference{Name: "vcenter"},
+				Scope:         "/dc1/vm/prod",
+				Filters: &EventFilters{
+					Selector: &EntitySelector{
+						Tags: []TagSelector{{
+							Category: "env",
+							Name:     "prod",
+						}},
+						CustomAttributes: []CustomAttributeSelector{{
+							Name:  "owner",
+							Value: "team-x",
+						}},
+					},
+					CEL: `eventType == "VmPoweredOnEvent"`,
+				},
+				Transform: &EventTransform{
+					Data:       `{"vm": event.Vm.Name}`,
+					Extensions: map[string]string{"vmname": "event.Vm.Name"},
+				},
+				Routes: []EventRoute{{
+					Name:   "security",
+					Filter: `eventType == "UserLoginSessionEvent"`,
+					Sink:   sink,
+				}},
+				ReplySink: &sink,
+				Delivery: &eventingduckv1beta1.DeliverySpec{
+					Retry: ptr.Int32(3),
+				},
+				AdapterTemplate: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						PriorityClassName: "high-priority",
+					},
+				},
+				Suspend:                  true,
+				SkipEventsWhileSuspended: true,
+			},
+			Status: VSphereSourceStatus{
+				SourceStatus: duckv1.SourceStatus{
+					Status: duckv1.Status{
+						ObservedGeneration: 1,
+						Conditions: duckv1.Conditions{{
+							Type:   apis.ConditionReady,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					SinkURI: sink.URI,
+				},
+				Routes: []RouteStatus{{
+					Name:    "security",
+					SinkURI: sink.URI,
+				}},
+				ReplySinkURI: sink.URI,
+				Streaming: &StreamingStatus{
+					LastEventKey:      42,
+					EventsSent:        12,
+					VCenterVersion:    "7.0.0",
+					LastHeartbeatTime: heartbeat,
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			hub := &v1alpha1.VSphereSource{}
+			if err := test.in.ConvertTo(ctx, hub); err != nil {
+				t.Fatalf("ConvertTo() = %v", err)
+			}
+			got := &VSphereSource{}
+			if err := got.ConvertFrom(ctx, hub); err != nil {
+				t.Fatalf("ConvertFrom() = %v", err)
+			}
+			if !cmp.Equal(test.in, got) {
+				t.Errorf("roundtrip (-want, +got) = %s", cmp.Diff(test.in, got))
+			}
+
+			// The conversion webhook goes through the hub's methods.
+			got = &VSphereSource{}
+			if err := hub.ConvertTo(ctx, got); err != nil {
+				t.Fatalf("hub.ConvertTo() = %v", err)
+			}
+			if !cmp.Equal(test.in, got) {
+				t.Errorf("hub.ConvertTo (-want, +got) = %s", cmp.Diff(test.in, got))
+			}
+			hub2 := &v1alpha1.VSphereSource{}
+			if err := hub2.ConvertFrom(ctx, got); err != nil {
+				t.Fatalf("hub.ConvertFrom() = %v", err)
+			}
+			if !cmp.Equal(hub, hub2) {
+				t.Errorf("hub.ConvertFrom (-want, +got) = %s", cmp.Diff(hub, hub2))
+			}
+		})
+	}
+}
+
+func TestVSphereSourceConversionFilters(t *testing.T) {
+	hub := &v1alpha1.VSphereSource{
+		Spec: v1alpha1.VSphereSourceSpec{
+			Filter: `eventType == "VmPoweredOnEvent"`,
+		},
+	}
+	got := &VSphereSource{}
+	if err := got.ConvertFrom(context.Background(), hub); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	want := &EventFilters{CEL: hub.Spec.Filter}
+	if !cmp.Equal(got.Spec.Filters, want) {
+		t.Errorf("Filters (-want, +got) = %s", cmp.Diff(want, got.Spec.Filters))
+	}
+}
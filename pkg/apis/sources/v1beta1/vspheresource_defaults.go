@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+// SetDefaults implements apis.Defaultable
+func (as *VSphereSource) SetDefaults(ctx context.Context) {
+	withNS := apis.WithinParent(ctx, as.ObjectMeta)
+	as.Spec.Sink.SetDefaults(withNS)
+	for i := range as.Spec.Routes {
+		as.Spec.Routes[i].Sink.SetDefaults(withNS)
+	}
+	if as.Spec.ReplySink != nil {
+		as.Spec.ReplySink.SetDefaults(withNS)
+	}
+
+	// The cluster's defaults are applied the same way as in v1alpha1.
+	hub := &v1alpha1.VSphereSourceSpec{}
+	as.Spec.ConvertTo(ctx, hub)
+	hub.SetDefaults(ctx)
+	as.Spec.ConvertFrom(ctx, hub)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/config"
+)
+
+func TestVSphereSourceDefaulting(t *testing.T) {
+	defaults, err := config.NewDefaultsConfigFromMap(map[string]string{
+		"default-connection": "vcenter",
+		"default-filter":     `eventType == "VmPoweredOnEvent"`,
+	})
+	if err != nil {
+		t.Fatalf("NewDefaultsConfigFromMap() = %v", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Defaults: defaults})
+
+	got := &VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "default",
+		},
+		Spec: VSphereSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "serving.knative.dev/v1",
+						Kind:       "Service",
+						Name:       "no-namespace",
+					},
+				},
+			},
+		},
+	}
+	got.SetDefaults(ctx)
+
+	want := &VSphereSource{
+		ObjectMeta: got.ObjectMeta,
+		Spec: VSphereSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "serving.knative.dev/v1",
+						Kind:       "Service",
+						Namespace:  "default",
+						Name:       "no-namespace",
+					},
+				},
+			},
+			ConnectionRef: &corev1.LocalObjectReference{Name: "vcenter"},
+			Filters: &EventFilters{
+				CEL: `eventType == "VmPoweredOnEvent"`,
+			},
+		},
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("SetDefaults (-want, +got) = %v", cmp.Diff(want, got))
+	}
+}
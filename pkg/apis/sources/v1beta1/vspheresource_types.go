@@ -0,0 +1,256 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereSource sends the events of a vCenter to a sink as CloudEvents.
+type VSphereSource struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the VSphereSource (from the client).
+	// +optional
+	Spec VSphereSourceSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the VSphereSource (from the controller).
+	// +optional
+	Status VSphereSourceStatus `json:"status,omitempty"`
+}
+
+// Check that VSphereSource can be validated, defaulted and converted.
+var (
+	_ apis.Validatable = (*VSphereSource)(nil)
+	_ apis.Defaultable = (*VSphereSource)(nil)
+	_ apis.Convertible = (*VSphereSource)(nil)
+)
+
+// VSphereSourceSpec holds the desired state of the VSphereSource (from the client).
+type VSphereSourceSpec struct {
+	duckv1.SourceSpec `json:",inline"`
+
+	// VAuthSpec holds the address of the vCenter and the credentials for
+	// it, unless ConnectionRef is set.
+	VAuthSpec `json:",inline"`
+
+	// ConnectionRef names a VSphereBinding in the source's namespace whose
+	// address, skipTLSVerify and secretRef the source uses in place of its
+	// own, so that the sources for a vCenter can share its settings.
+	// +optional
+	ConnectionRef *corev1.LocalObjectReference `json:"connectionRef,omitempty"`
+
+	// Scope is the inventory path (e.g. "/dc1/vm/prod") of the entity whose
+	// events, and those of the entities beneath it, are sent.  It defaults
+	// to the root folder, so that all of the vCenter's events are sent.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// Filters restricts the events that are sent.
+	// +optional
+	Filters *EventFilters `json:"filters,omitempty"`
+
+	// Transform optionally reshapes the events that are sent.
+	// +optional
+	Transform *EventTransform `json:"transform,omitempty"`
+
+	// Routes optionally sends the events matching each route's filter to
+	// that route's sink.  An event is sent to every route that it matches,
+	// and events that match none of the routes are sent to Sink.
+	// +optional
+	Routes []EventRoute `json:"routes,omitempty"`
+
+	// ReplySink is where the events with which the sink (or the sink of a
+	// route) replies to the events sent to it are forwarded, with the
+	// "vsphereeventid" extension set to the ID of the event replied to, so
+	// that functions that enrich events can be chained without a broker.
+	// Replies are dropped when it isn't set.
+	// +optional
+	ReplySink *duckv1.Destination `json:"replySink,omitempty"`
+
+	// Delivery configures how sending events that their sink rejects is
+	// retried.  Dead letter sinks aren't supported (yet).
+	// +optional
+	Delivery *eventingduckv1beta1.DeliverySpec `json:"delivery,omitempty"`
+
+	// AdapterTemplate is merged into the pod template of the adapter's
+	// Deployment.  It may not override the fields that the controller sets
+	// itself, such as the image and environment of the "adapter" container,
+	// which is the only container that it may list.
+	// +optional
+	AdapterTemplate *corev1.PodTemplateSpec `json:"adapterTemplate,omitempty"`
+
+	// Suspend stops the flow of events while it is set, by scaling the
+	// adapter down to zero.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// SkipEventsWhileSuspended drops the events that happened while the
+	// source was suspended, instead of sending them when it is resumed.
+	// +optional
+	SkipEventsWhileSuspended bool `json:"skipEventsWhileSuspended,omitempty"`
+}
+
+// EventFilters restricts the events that are sent to those that pass all
+// of its filters.
+type EventFilters struct {
+	// Selector only lets through the events whose affected entity (e.g. the
+	// VM) matches the given tags and custom attributes.
+	// +optional
+	Selector *EntitySelector `json:"selector,omitempty"`
+
+	// CEL is an expression that is evaluated against each event, and only
+	// lets through the events for which it evaluates to true.  The
+	// expression may reference "event" (the vSphere event) and "eventType"
+	// (the name of its type, e.g. "VmReconfiguredEvent").
+	// +optional
+	CEL string `json:"cel,omitempty"`
+}
+
+// EventRoute sends the events that match its filter to its sink.
+type EventRoute struct {
+	// Name identifies the route within the source, and must be a DNS label.
+	Name string `json:"name"`
+
+	// Filter is the CEL expression selecting the events sent to Sink.  It
+	// has access to the same variables as EventFilters.CEL, and only sees
+	// the events that the source's filters let through.
+	Filter string `json:"filter"`
+
+	// Sink is where the matching events are sent.
+	Sink duckv1.Destination `json:"sink"`
+}
+
+// EventTransform holds the CEL expressions used to reshape events before
+// they are sent.  The expressions have access to the same variables as
+// EventFilters.CEL.
+type EventTransform struct {
+	// Data is an expression whose result replaces the event's payload,
+	// which is then sent as JSON.
+	// +optional
+	Data string `json:"data,omitempty"`
+
+	// Extensions maps CloudEvent extension attribute names to expressions
+	// producing the string values they should be set to.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// EntitySelector selects vSphere entities by the tags attached to them and
+// the values of their custom attributes.  An entity matches when it carries
+// all of the listed tags and all of the listed custom attribute values.
+type EntitySelector struct {
+	// Tags lists the tags that must be attached to the entity.
+	// +optional
+	Tags []TagSelector `json:"tags,omitempty"`
+
+	// CustomAttributes lists the custom attribute values that the entity
+	// must have.
+	// +optional
+	CustomAttributes []CustomAttributeSelector `json:"customAttributes,omitempty"`
+}
+
+// TagSelector identifies a vSphere tag by its category and name.
+type TagSelector struct {
+	// Category is the name of the tag category.
+	Category string `json:"category"`
+
+	// Name is the name of the tag within the category.
+	Name string `json:"name"`
+}
+
+// CustomAttributeSelector matches a custom attribute by its name and value.
+type CustomAttributeSelector struct {
+	// Name is the name of the custom attribute.
+	Name string `json:"name"`
+
+	// Value is the value the custom attribute must have.
+	Value string `json:"value"`
+}
+
+// VSphereSourceStatus communicates the observed state of the VSphereSource (from the controller).
+type VSphereSourceStatus struct {
+	duckv1.SourceStatus `json:",inline"`
+
+	// Routes holds the resolved sinks of the source's routes.
+	// +optional
+	Routes []RouteStatus `json:"routes,omitempty"`
+
+	// ReplySinkURI is the resolved URI of the source's replySink.
+	// +optional
+	ReplySinkURI *apis.URL `json:"replySinkUri,omitempty"`
+
+	// Streaming holds the progress of the adapter, as last reported by it.
+	// +optional
+	Streaming *StreamingStatus `json:"streaming,omitempty"`
+}
+
+// StreamingStatus holds the progress of the adapter.
+type StreamingStatus struct {
+	// LastEventKey is the key of the last event that the adapter handled
+	// (whether or not it was sent).
+	// +optional
+	LastEventKey int32 `json:"lastEventKey,omitempty"`
+
+	// LastEventTime is when the last event that the adapter handled was
+	// created.
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// EventsSent counts the events sent since the adapter started.
+	EventsSent int64 `json:"eventsSent"`
+
+	// VCenterVersion is the version of the vCenter that the adapter is
+	// connected to.
+	// +optional
+	VCenterVersion string `json:"vcenterVersion,omitempty"`
+
+	// VCenterInstanceUUID identifies the vCenter that the adapter is
+	// connected to.
+	// +optional
+	VCenterInstanceUUID string `json:"vcenterInstanceUUID,omitempty"`
+
+	// LastHeartbeatTime is when the adapter last reported its progress.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime"`
+}
+
+// RouteStatus holds the resolved sink of one of the source's routes.
+type RouteStatus struct {
+	// Name is the name of the route.
+	Name string `json:"name"`
+
+	// SinkURI is the current active sink URI of the route.
+	SinkURI *apis.URL `json:"sinkUri,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VSphereSourceList is a list of VSphereSource resources
+type VSphereSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VSphereSource `json:"items"`
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (fb *VSphereSource) Validate(ctx context.Context) *apis.FieldError {
+	err := fb.Spec.Validate(ctx).ViaField("spec")
+	if err != nil {
+		return err
+	}
+	// The policy is checked against the hub, where the connection fields
+	// live in the same place.
+	hub := &v1alpha1.VSphereSource{}
+	if err := fb.ConvertTo(ctx, hub); err != nil {
+		return &apis.FieldError{Message: err.Error()}
+	}
+	hub.Spec.VAuthSpec.WarnAboutSecret(ctx, hub.Namespace)
+	return hub.CheckPolicy(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (fbs *VSphereSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	// The fields that are the same as in v1alpha1 are validated the same
+	// way, but the filters moved, so we check those here to get their
+	// paths right.
+	hub := &v1alpha1.VSphereSourceSpec{}
+	fbs.ConvertTo(ctx, hub)
+	hub.Selector, hub.Filter = nil, ""
+	err := hub.Validate(ctx)
+	if fbs.Filters != nil {
+		err = err.Also(fbs.Filters.Validate(ctx).ViaField("filters"))
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (ef *EventFilters) Validate(ctx context.Context) (err *apis.FieldError) {
+	if ef.Selector == nil && ef.CEL == "" {
+		return apis.ErrMissingOneOf("selector", "cel")
+	}
+	if ef.Selector != nil {
+		err = err.Also(ef.Selector.Validate(ctx).ViaField("selector"))
+	}
+	if ef.CEL != "" {
+		if _, ferr := expr.NewFilter(ef.CEL); ferr != nil {
+			err = err.Also(&apis.FieldError{
+				Message: "invalid expression",
+				Paths:   []string{"cel"},
+				Details: ferr.Error(),
+			})
+		}
+	}
+	return err
+}
+
+// Validate implements apis.Validatable
+func (es *EntitySelector) Validate(ctx context.Context) *apis.FieldError {
+	hub := &v1alpha1.EntitySelector{}
+	for _, ts := range es.Tags {
+		hub.Tags = append(hub.Tags, v1alpha1.TagSelector(ts))
+	}
+	for _, cas := range es.CustomAttributes {
+		hub.CustomAttributes = append(hub.CustomAttributes, v1alpha1.CustomAttributeSelector(cas))
+	}
+	return hub.Validate(ctx)
+}
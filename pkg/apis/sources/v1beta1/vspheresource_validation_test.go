@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestVSphereSourceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *VSphereSource
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+				VAuthSpec:  vauth,
+				Filters: &EventFilters{
+					Selector: &EntitySelector{
+						Tags: []TagSelector{{
+							Category: "env",
+							Name:     "prod",
+						}},
+					},
+					CEL: `eventType == "VmPoweredOnEvent"`,
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "missing VAuthSpec",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+			},
+		},
+		want: apis.ErrMissingField("spec.address.host", "spec.secretRef.name"),
+	}, {
+		name: "empty filters",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+				VAuthSpec:  vauth,
+				Filters:    &EventFilters{},
+			},
+		},
+		want: apis.ErrMissingOneOf("spec.filters.selector", "spec.filters.cel"),
+	}, {
+		name: "invalid filters",
+		c: &VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: VSphereSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+				VAuthSpec:  vauth,
+				Filters: &EventFilters{
+					Selector: &EntitySelector{
+						Tags: []TagSelector{{}},
+					},
+					CEL: "eventType ==",
+				},
+			},
+		},
+		want: apis.ErrMissingField(
+			"spec.filters.selector.tags[0].category",
+			"spec.filters.selector.tags[0].name",
+		).Also(&apis.FieldError{
+			Message: "invalid expression",
+			Paths:   []string{"spec.filters.cel"},
+			Details: filterError("eventType ==").Error(),
+		}),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = %v",
+					cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
+
+func filterError(src string) error {
+	_, err := expr.NewFilter(src)
+	return err
+}
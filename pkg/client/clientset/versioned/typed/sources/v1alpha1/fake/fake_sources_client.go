@@ -28,10 +28,18 @@ type FakeSourcesV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSourcesV1alpha1) VSphereActions(namespace string) v1alpha1.VSphereActionInterface {
+	return &FakeVSphereActions{c, namespace}
+}
+
 func (c *FakeSourcesV1alpha1) VSphereBindings(namespace string) v1alpha1.VSphereBindingInterface {
 	return &FakeVSphereBindings{c, namespace}
 }
 
+func (c *FakeSourcesV1alpha1) VSphereSinks(namespace string) v1alpha1.VSphereSinkInterface {
+	return &FakeVSphereSinks{c, namespace}
+}
+
 func (c *FakeSourcesV1alpha1) VSphereSources(namespace string) v1alpha1.VSphereSourceInterface {
 	return &FakeVSphereSources{c, namespace}
 }
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVSphereActions implements VSphereActionInterface
+type FakeVSphereActions struct {
+	Fake *FakeSourcesV1alpha1
+	ns   string
+}
+
+var vsphereactionsResource = schema.GroupVersionResource{Group: "sources.knative.dev", Version: "v1alpha1", Resource: "vsphereactions"}
+
+var vsphereactionsKind = schema.GroupVersionKind{Group: "sources.knative.dev", Version: "v1alpha1", Kind: "VSphereAction"}
+
+// Get takes name of the vSphereAction, and returns the corresponding vSphereAction object, and an error if there is any.
+func (c *FakeVSphereActions) Get(name string, options v1.GetOptions) (result *v1alpha1.VSphereAction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vsphereactionsResource, c.ns, name), &v1alpha1.VSphereAction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereAction), err
+}
+
+// List takes label and field selectors, and returns the list of VSphereActions that match those selectors.
+func (c *FakeVSphereActions) List(opts v1.ListOptions) (result *v1alpha1.VSphereActionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vsphereactionsResource, vsphereactionsKind, c.ns, opts), &v1alpha1.VSphereActionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VSphereActionList{ListMeta: obj.(*v1alpha1.VSphereActionList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VSphereActionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vSphereActions.
+func (c *FakeVSphereActions) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vsphereactionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vSphereAction and creates it.  Returns the server's representation of the vSphereAction, and an error, if there is any.
+func (c *FakeVSphereActions) Create(vSphereAction *v1alpha1.VSphereAction) (result *v1alpha1.VSphereAction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vsphereactionsResource, c.ns, vSphereAction), &v1alpha1.VSphereAction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereAction), err
+}
+
+// Update takes the representation of a vSphereAction and updates it. Returns the server's representation of the vSphereAction, and an error, if there is any.
+func (c *FakeVSphereActions) Update(vSphereAction *v1alpha1.VSphereAction) (result *v1alpha1.VSphereAction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vsphereactionsResource, c.ns, vSphereAction), &v1alpha1.VSphereAction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereAction), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVSphereActions) UpdateStatus(vSphereAction *v1alpha1.VSphereAction) (*v1alpha1.VSphereAction, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vsphereactionsResource, "status", c.ns, vSphereAction), &v1alpha1.VSphereAction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereAction), err
+}
+
+// Delete takes name of the vSphereAction and deletes it. Returns an error if one occurs.
+func (c *FakeVSphereActions) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(vsphereactionsResource, c.ns, name), &v1alpha1.VSphereAction{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVSphereActions) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vsphereactionsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VSphereActionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vSphereAction.
+func (c *FakeVSphereActions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereAction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vsphereactionsResource, c.ns, name, pt, data, subresources...), &v1alpha1.VSphereAction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereAction), err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVSphereSinks implements VSphereSinkInterface
+type FakeVSphereSinks struct {
+	Fake *FakeSourcesV1alpha1
+	ns   string
+}
+
+var vspheresinksResource = schema.GroupVersionResource{Group: "sources.knative.dev", Version: "v1alpha1", Resource: "vspheresinks"}
+
+var vspheresinksKind = schema.GroupVersionKind{Group: "sources.knative.dev", Version: "v1alpha1", Kind: "VSphereSink"}
+
+// Get takes name of the vSphereSink, and returns the corresponding vSphereSink object, and an error if there is any.
+func (c *FakeVSphereSinks) Get(name string, options v1.GetOptions) (result *v1alpha1.VSphereSink, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vspheresinksResource, c.ns, name), &v1alpha1.VSphereSink{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereSink), err
+}
+
+// List takes label and field selectors, and returns the list of VSphereSinks that match those selectors.
+func (c *FakeVSphereSinks) List(opts v1.ListOptions) (result *v1alpha1.VSphereSinkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vspheresinksResource, vspheresinksKind, c.ns, opts), &v1alpha1.VSphereSinkList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VSphereSinkList{ListMeta: obj.(*v1alpha1.VSphereSinkList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VSphereSinkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vSphereSinks.
+func (c *FakeVSphereSinks) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vspheresinksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vSphereSink and creates it.  Returns the server's representation of the vSphereSink, and an error, if there is any.
+func (c *FakeVSphereSinks) Create(vSphereSink *v1alpha1.VSphereSink) (result *v1alpha1.VSphereSink, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vspheresinksResource, c.ns, vSphereSink), &v1alpha1.VSphereSink{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereSink), err
+}
+
+// Update takes the representation of a vSphereSink and updates it. Returns the server's representation of the vSphereSink, and an error, if there is any.
+func (c *FakeVSphereSinks) Update(vSphereSink *v1alpha1.VSphereSink) (result *v1alpha1.VSphereSink, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vspheresinksResource, c.ns, vSphereSink), &v1alpha1.VSphereSink{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereSink), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVSphereSinks) UpdateStatus(vSphereSink *v1alpha1.VSphereSink) (*v1alpha1.VSphereSink, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vspheresinksResource, "status", c.ns, vSphereSink), &v1alpha1.VSphereSink{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereSink), err
+}
+
+// Delete takes name of the vSphereSink and deletes it. Returns an error if one occurs.
+func (c *FakeVSphereSinks) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(vspheresinksResource, c.ns, name), &v1alpha1.VSphereSink{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVSphereSinks) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vspheresinksResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VSphereSinkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vSphereSink.
+func (c *FakeVSphereSinks) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereSink, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vspheresinksResource, c.ns, name, pt, data, subresources...), &v1alpha1.VSphereSink{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VSphereSink), err
+}
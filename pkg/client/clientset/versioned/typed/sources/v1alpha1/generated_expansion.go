@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha1
 
+type VSphereActionExpansion interface{}
+
 type VSphereBindingExpansion interface{}
 
+type VSphereSinkExpansion interface{}
+
 type VSphereSourceExpansion interface{}
@@ -26,7 +26,9 @@ import (
 
 type SourcesV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	VSphereActionsGetter
 	VSphereBindingsGetter
+	VSphereSinksGetter
 	VSphereSourcesGetter
 }
 
@@ -35,10 +37,18 @@ type SourcesV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *SourcesV1alpha1Client) VSphereActions(namespace string) VSphereActionInterface {
+	return newVSphereActions(c, namespace)
+}
+
 func (c *SourcesV1alpha1Client) VSphereBindings(namespace string) VSphereBindingInterface {
 	return newVSphereBindings(c, namespace)
 }
 
+func (c *SourcesV1alpha1Client) VSphereSinks(namespace string) VSphereSinkInterface {
+	return newVSphereSinks(c, namespace)
+}
+
 func (c *SourcesV1alpha1Client) VSphereSources(namespace string) VSphereSourceInterface {
 	return newVSphereSources(c, namespace)
 }
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	scheme "github.com/mattmoor/vmware-sources/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VSphereActionsGetter has a method to return a VSphereActionInterface.
+// A group's client should implement this interface.
+type VSphereActionsGetter interface {
+	VSphereActions(namespace string) VSphereActionInterface
+}
+
+// VSphereActionInterface has methods to work with VSphereAction resources.
+type VSphereActionInterface interface {
+	Create(*v1alpha1.VSphereAction) (*v1alpha1.VSphereAction, error)
+	Update(*v1alpha1.VSphereAction) (*v1alpha1.VSphereAction, error)
+	UpdateStatus(*v1alpha1.VSphereAction) (*v1alpha1.VSphereAction, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.VSphereAction, error)
+	List(opts v1.ListOptions) (*v1alpha1.VSphereActionList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereAction, err error)
+	VSphereActionExpansion
+}
+
+// vSphereActions implements VSphereActionInterface
+type vSphereActions struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVSphereActions returns a VSphereActions
+func newVSphereActions(c *SourcesV1alpha1Client, namespace string) *vSphereActions {
+	return &vSphereActions{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the vSphereAction, and returns the corresponding vSphereAction object, and an error if there is any.
+func (c *vSphereActions) Get(name string, options v1.GetOptions) (result *v1alpha1.VSphereAction, err error) {
+	result = &v1alpha1.VSphereAction{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VSphereActions that match those selectors.
+func (c *vSphereActions) List(opts v1.ListOptions) (result *v1alpha1.VSphereActionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VSphereActionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested vSphereActions.
+func (c *vSphereActions) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a vSphereAction and creates it.  Returns the server's representation of the vSphereAction, and an error, if there is any.
+func (c *vSphereActions) Create(vSphereAction *v1alpha1.VSphereAction) (result *v1alpha1.VSphereAction, err error) {
+	result = &v1alpha1.VSphereAction{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		Body(vSphereAction).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a vSphereAction and updates it. Returns the server's representation of the vSphereAction, and an error, if there is any.
+func (c *vSphereActions) Update(vSphereAction *v1alpha1.VSphereAction) (result *v1alpha1.VSphereAction, err error) {
+	result = &v1alpha1.VSphereAction{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		Name(vSphereAction.Name).
+		Body(vSphereAction).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *vSphereActions) UpdateStatus(vSphereAction *v1alpha1.VSphereAction) (result *v1alpha1.VSphereAction, err error) {
+	result = &v1alpha1.VSphereAction{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		Name(vSphereAction.Name).
+		SubResource("status").
+		Body(vSphereAction).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the vSphereAction and deletes it. Returns an error if one occurs.
+func (c *vSphereActions) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *vSphereActions) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched vSphereAction.
+func (c *vSphereActions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereAction, err error) {
+	result = &v1alpha1.VSphereAction{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("vsphereactions").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
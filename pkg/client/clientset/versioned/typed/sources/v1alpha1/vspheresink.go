@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"time"
+
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	scheme "github.com/mattmoor/vmware-sources/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VSphereSinksGetter has a method to return a VSphereSinkInterface.
+// A group's client should implement this interface.
+type VSphereSinksGetter interface {
+	VSphereSinks(namespace string) VSphereSinkInterface
+}
+
+// VSphereSinkInterface has methods to work with VSphereSink resources.
+type VSphereSinkInterface interface {
+	Create(*v1alpha1.VSphereSink) (*v1alpha1.VSphereSink, error)
+	Update(*v1alpha1.VSphereSink) (*v1alpha1.VSphereSink, error)
+	UpdateStatus(*v1alpha1.VSphereSink) (*v1alpha1.VSphereSink, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.VSphereSink, error)
+	List(opts v1.ListOptions) (*v1alpha1.VSphereSinkList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereSink, err error)
+	VSphereSinkExpansion
+}
+
+// vSphereSinks implements VSphereSinkInterface
+type vSphereSinks struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVSphereSinks returns a VSphereSinks
+func newVSphereSinks(c *SourcesV1alpha1Client, namespace string) *vSphereSinks {
+	return &vSphereSinks{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the vSphereSink, and returns the corresponding vSphereSink object, and an error if there is any.
+func (c *vSphereSinks) Get(name string, options v1.GetOptions) (result *v1alpha1.VSphereSink, err error) {
+	result = &v1alpha1.VSphereSink{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VSphereSinks that match those selectors.
+func (c *vSphereSinks) List(opts v1.ListOptions) (result *v1alpha1.VSphereSinkList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VSphereSinkList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested vSphereSinks.
+func (c *vSphereSinks) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a vSphereSink and creates it.  Returns the server's representation of the vSphereSink, and an error, if there is any.
+func (c *vSphereSinks) Create(vSphereSink *v1alpha1.VSphereSink) (result *v1alpha1.VSphereSink, err error) {
+	result = &v1alpha1.VSphereSink{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		Body(vSphereSink).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a vSphereSink and updates it. Returns the server's representation of the vSphereSink, and an error, if there is any.
+func (c *vSphereSinks) Update(vSphereSink *v1alpha1.VSphereSink) (result *v1alpha1.VSphereSink, err error) {
+	result = &v1alpha1.VSphereSink{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		Name(vSphereSink.Name).
+		Body(vSphereSink).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *vSphereSinks) UpdateStatus(vSphereSink *v1alpha1.VSphereSink) (result *v1alpha1.VSphereSink, err error) {
+	result = &v1alpha1.VSphereSink{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		Name(vSphereSink.Name).
+		SubResource("status").
+		Body(vSphereSink).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the vSphereSink and deletes it. Returns an error if one occurs.
+func (c *vSphereSinks) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *vSphereSinks) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched vSphereSink.
+func (c *vSphereSinks) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.VSphereSink, err error) {
+	result = &v1alpha1.VSphereSink{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("vspheresinks").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
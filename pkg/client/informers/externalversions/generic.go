@@ -53,8 +53,12 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=sources.knative.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("vsphereactions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1alpha1().VSphereActions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vspherebindings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1alpha1().VSphereBindings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("vspheresinks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1alpha1().VSphereSinks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("vspheresources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1alpha1().VSphereSources().Informer()}, nil
 
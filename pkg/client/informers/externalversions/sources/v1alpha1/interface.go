@@ -24,8 +24,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// VSphereActions returns a VSphereActionInformer.
+	VSphereActions() VSphereActionInformer
 	// VSphereBindings returns a VSphereBindingInformer.
 	VSphereBindings() VSphereBindingInformer
+	// VSphereSinks returns a VSphereSinkInformer.
+	VSphereSinks() VSphereSinkInformer
 	// VSphereSources returns a VSphereSourceInformer.
 	VSphereSources() VSphereSourceInformer
 }
@@ -41,11 +45,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// VSphereActions returns a VSphereActionInformer.
+func (v *version) VSphereActions() VSphereActionInformer {
+	return &vSphereActionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VSphereBindings returns a VSphereBindingInformer.
 func (v *version) VSphereBindings() VSphereBindingInformer {
 	return &vSphereBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VSphereSinks returns a VSphereSinkInformer.
+func (v *version) VSphereSinks() VSphereSinkInformer {
+	return &vSphereSinkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VSphereSources returns a VSphereSourceInformer.
 func (v *version) VSphereSources() VSphereSourceInformer {
 	return &vSphereSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
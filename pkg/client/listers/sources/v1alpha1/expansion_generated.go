@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// VSphereActionListerExpansion allows custom methods to be added to
+// VSphereActionLister.
+type VSphereActionListerExpansion interface{}
+
+// VSphereActionNamespaceListerExpansion allows custom methods to be added to
+// VSphereActionNamespaceLister.
+type VSphereActionNamespaceListerExpansion interface{}
+
 // VSphereBindingListerExpansion allows custom methods to be added to
 // VSphereBindingLister.
 type VSphereBindingListerExpansion interface{}
@@ -26,6 +34,14 @@ type VSphereBindingListerExpansion interface{}
 // VSphereBindingNamespaceLister.
 type VSphereBindingNamespaceListerExpansion interface{}
 
+// VSphereSinkListerExpansion allows custom methods to be added to
+// VSphereSinkLister.
+type VSphereSinkListerExpansion interface{}
+
+// VSphereSinkNamespaceListerExpansion allows custom methods to be added to
+// VSphereSinkNamespaceLister.
+type VSphereSinkNamespaceListerExpansion interface{}
+
 // VSphereSourceListerExpansion allows custom methods to be added to
 // VSphereSourceLister.
 type VSphereSourceListerExpansion interface{}
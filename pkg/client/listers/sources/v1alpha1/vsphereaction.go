@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VSphereActionLister helps list VSphereActions.
+type VSphereActionLister interface {
+	// List lists all VSphereActions in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.VSphereAction, err error)
+	// VSphereActions returns an object that can list and get VSphereActions.
+	VSphereActions(namespace string) VSphereActionNamespaceLister
+	VSphereActionListerExpansion
+}
+
+// vSphereActionLister implements the VSphereActionLister interface.
+type vSphereActionLister struct {
+	indexer cache.Indexer
+}
+
+// NewVSphereActionLister returns a new VSphereActionLister.
+func NewVSphereActionLister(indexer cache.Indexer) VSphereActionLister {
+	return &vSphereActionLister{indexer: indexer}
+}
+
+// List lists all VSphereActions in the indexer.
+func (s *vSphereActionLister) List(selector labels.Selector) (ret []*v1alpha1.VSphereAction, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VSphereAction))
+	})
+	return ret, err
+}
+
+// VSphereActions returns an object that can list and get VSphereActions.
+func (s *vSphereActionLister) VSphereActions(namespace string) VSphereActionNamespaceLister {
+	return vSphereActionNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VSphereActionNamespaceLister helps list and get VSphereActions.
+type VSphereActionNamespaceLister interface {
+	// List lists all VSphereActions in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.VSphereAction, err error)
+	// Get retrieves the VSphereAction from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.VSphereAction, error)
+	VSphereActionNamespaceListerExpansion
+}
+
+// vSphereActionNamespaceLister implements the VSphereActionNamespaceLister
+// interface.
+type vSphereActionNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VSphereActions in the indexer for a given namespace.
+func (s vSphereActionNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VSphereAction, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VSphereAction))
+	})
+	return ret, err
+}
+
+// Get retrieves the VSphereAction from the indexer for a given namespace and name.
+func (s vSphereActionNamespaceLister) Get(name string) (*v1alpha1.VSphereAction, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("vsphereaction"), name)
+	}
+	return obj.(*v1alpha1.VSphereAction), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VSphereSinkLister helps list VSphereSinks.
+type VSphereSinkLister interface {
+	// List lists all VSphereSinks in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.VSphereSink, err error)
+	// VSphereSinks returns an object that can list and get VSphereSinks.
+	VSphereSinks(namespace string) VSphereSinkNamespaceLister
+	VSphereSinkListerExpansion
+}
+
+// vSphereSinkLister implements the VSphereSinkLister interface.
+type vSphereSinkLister struct {
+	indexer cache.Indexer
+}
+
+// NewVSphereSinkLister returns a new VSphereSinkLister.
+func NewVSphereSinkLister(indexer cache.Indexer) VSphereSinkLister {
+	return &vSphereSinkLister{indexer: indexer}
+}
+
+// List lists all VSphereSinks in the indexer.
+func (s *vSphereSinkLister) List(selector labels.Selector) (ret []*v1alpha1.VSphereSink, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VSphereSink))
+	})
+	return ret, err
+}
+
+// VSphereSinks returns an object that can list and get VSphereSinks.
+func (s *vSphereSinkLister) VSphereSinks(namespace string) VSphereSinkNamespaceLister {
+	return vSphereSinkNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VSphereSinkNamespaceLister helps list and get VSphereSinks.
+type VSphereSinkNamespaceLister interface {
+	// List lists all VSphereSinks in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.VSphereSink, err error)
+	// Get retrieves the VSphereSink from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.VSphereSink, error)
+	VSphereSinkNamespaceListerExpansion
+}
+
+// vSphereSinkNamespaceLister implements the VSphereSinkNamespaceLister
+// interface.
+type vSphereSinkNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VSphereSinks in the indexer for a given namespace.
+func (s vSphereSinkNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VSphereSink, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VSphereSink))
+	})
+	return ret, err
+}
+
+// Get retrieves the VSphereSink from the indexer for a given namespace and name.
+func (s vSphereSinkNamespaceLister) Get(name string) (*v1alpha1.VSphereSink, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("vspheresink"), name)
+	}
+	return obj.(*v1alpha1.VSphereSink), nil
+}
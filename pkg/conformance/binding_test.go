@@ -0,0 +1,505 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	fuzz "github.com/google/gofuzz"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/webhook/psbinding"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1beta1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+// The VSphereBinding is reconciled by psbinding, which calls Do (or Undo)
+// on the PodSpec of each subject whenever anything changes, and patches the
+// subject with the difference.
+var _ psbinding.Bindable = (*v1alpha1.VSphereBinding)(nil)
+
+// boundEnv are the environment variables that the VSphereBinding owns.
+var boundEnv = []string{"GOVC_URL", "GOVC_INSECURE", "GOVC_USERNAME", "GOVC_PASSWORD"}
+
+// Pools of names for the generated PodSpecs.  They are small so that names
+// collide, and include names that are similar to, but aren't, ours.
+var (
+	envNames    = []string{"FOO", "BAR", "K_SINK", "GOVC_DATACENTER", "GOVC_URLS", "govc_url"}
+	volumeNames = []string{"config", "data", "vsphere", "vsphere-binding-2"}
+	annotations = []string{"foo", "sidecar.istio.io/inject", v1alpha1.CredentialsHashAnnotationKey}
+)
+
+// quickConfig is how hard we look for counterexamples.
+var quickConfig = &quick.Config{MaxCount: 500}
+
+func TestVSphereBindingDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}, {
+		name: "binding",
+		t:    &duckv1alpha1.Binding{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, vsb := range []interface{}{&v1alpha1.VSphereBinding{}, &v1beta1.VSphereBinding{}} {
+				if err := duck.VerifyType(vsb, test.t); err != nil {
+					t.Errorf("VerifyType(%T, %T) = %v", vsb, test.t, err)
+				}
+			}
+		})
+	}
+}
+
+// pod is an arbitrary PodSpecable that doesn't use any of the names that
+// the VSphereBinding owns.
+type pod struct {
+	*duckv1.WithPod
+}
+
+// Generate implements quick.Generator
+func (pod) Generate(r *rand.Rand, size int) reflect.Value {
+	p := &duckv1.WithPod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "subject",
+			Namespace: "default",
+		},
+	}
+	tmpl := &p.Spec.Template
+	if r.Intn(2) == 0 {
+		tmpl.Annotations = make(map[string]string)
+		for i := r.Intn(3); i >= 0; i-- {
+			tmpl.Annotations[pick(r, annotations)] = fmt.Sprint(r.Intn(3) == 0)
+		}
+	}
+	for i := r.Intn(4); i > 0; i-- {
+		name := pick(r, volumeNames)
+		tmpl.Spec.Volumes = append(tmpl.Spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		})
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		tmpl.Spec.InitContainers = append(tmpl.Spec.InitContainers, container(r, fmt.Sprint("init-", i)))
+	}
+	for i := r.Intn(3); i >= 0; i-- {
+		tmpl.Spec.Containers = append(tmpl.Spec.Containers, container(r, fmt.Sprint("user-", i)))
+	}
+	return reflect.ValueOf(pod{p})
+}
+
+// container returns an arbitrary container.
+func container(r *rand.Rand, name string) corev1.Container {
+	c := corev1.Container{
+		Name:  name,
+		Image: "busybox",
+	}
+	for i := r.Intn(4); i > 0; i-- {
+		name := pick(r, volumeNames)
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: "/var/" + name,
+			ReadOnly:  r.Intn(2) == 0,
+		})
+	}
+	for i := r.Intn(5); i > 0; i-- {
+		ev := corev1.EnvVar{Name: pick(r, envNames)}
+		if r.Intn(4) == 0 {
+			ev.ValueFrom = &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			}
+		} else {
+			ev.Value = fmt.Sprint(r.Intn(10))
+		}
+		c.Env = append(c.Env, ev)
+	}
+	return c
+}
+
+func pick(r *rand.Rand, from []string) string {
+	return from[r.Intn(len(from))]
+}
+
+// binding is an arbitrary VSphereBinding, with the hash of its credentials
+// (if known) as the reconciler would put it on the context.
+type binding struct {
+	*v1alpha1.VSphereBinding
+	hash string
+}
+
+// Generate implements quick.Generator
+func (binding) Generate(r *rand.Rand, size int) reflect.Value {
+	vsb := &v1alpha1.VSphereBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "binding",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.VSphereBindingSpec{
+			VAuthSpec: v1alpha1.VAuthSpec{
+				Address: apis.URL{
+					Scheme: "https",
+					Host:   fmt.Sprintf("vcenter-%d.example.com", r.Intn(3)),
+				},
+				SkipTLSVerify: r.Intn(2) == 0,
+				SecretRef: corev1.LocalObjectReference{
+					Name: fmt.Sprint("credentials-", r.Intn(3)),
+				},
+			},
+		},
+	}
+	if r.Intn(2) == 0 {
+		vsb.Annotations = map[string]string{
+			v1alpha1.RolloutOnCredentialsChangeAnnotationKey: "true",
+		}
+	}
+	var hash string
+	if r.Intn(3) != 0 {
+		hash = fmt.Sprint("hash-", r.Intn(3))
+	}
+	return reflect.ValueOf(binding{vsb, hash})
+}
+
+// context returns the context on which the binding is applied.
+func (b binding) context() context.Context {
+	ctx := context.Background()
+	if b.hash != "" {
+		ctx = v1alpha1.WithCredentialsHash(ctx, b.hash)
+	}
+	return ctx
+}
+
+// do returns the result of applying the bindings to the pod in turn.
+func do(p pod, bs ...binding) *duckv1.WithPod {
+	ps := p.DeepCopy()
+	for _, b := range bs {
+		b.Do(b.context(), ps)
+	}
+	return ps
+}
+
+// undo returns the result of removing the binding from the pod, as many
+// times as asked.
+func undo(ps *duckv1.WithPod, b binding, times int) *duckv1.WithPod {
+	ps = ps.DeepCopy()
+	for i := 0; i < times; i++ {
+		b.Undo(b.context(), ps)
+	}
+	return ps
+}
+
+// samePatch checks that psbinding would not patch a subject with the want
+// PodSpecable into one with the got PodSpecable, i.e. that they only differ
+// in ways that don't survive serialization, like nil vs. empty slices.
+func samePatch(t *testing.T, what string, want, got *duckv1.WithPod) bool {
+	t.Helper()
+	patch, err := duck.CreatePatch(want, got)
+	if err != nil {
+		t.Fatalf("CreatePatch() = %v", err)
+	}
+	if len(patch) == 0 {
+		return true
+	}
+	b, _ := json.Marshal(patch)
+	t.Errorf("%s: patch = %s", what, b)
+	return false
+}
+
+func TestVSphereBindingDoIsIdempotent(t *testing.T) {
+	f := func(p pod, b binding) bool {
+		once := do(p, b)
+		return samePatch(t, "Do(Do(x)) vs. Do(x)", once, do(pod{once}, b))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVSphereBindingUndoReversesDo(t *testing.T) {
+	f := func(p pod, b binding) bool {
+		// The hash on the pod template is dropped by Undo whoever put it
+		// there, since the VSphereSource stamps the same annotation itself.
+		if b.RolloutOnCredentialsChange() {
+			delete(p.Spec.Template.Annotations, v1alpha1.CredentialsHashAnnotationKey)
+		}
+		return samePatch(t, "Undo(Do(x)) vs. x", p.WithPod, undo(do(p, b), b, 1))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVSphereBindingUndoIsIdempotent(t *testing.T) {
+	f := func(p pod, b binding, bound bool) bool {
+		ps := p.WithPod
+		if bound {
+			ps = do(p, b)
+		}
+		once := undo(ps, b, 1)
+		return samePatch(t, "Undo(Undo(x)) vs. Undo(x)", once, undo(ps, b, 2))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVSphereBindingRepatch(t *testing.T) {
+	// When the binding changes, psbinding applies the new binding over the
+	// old one, which should be as if the old one had never been applied.
+	f := func(p pod, before, after binding) bool {
+		// The hash isn't ours to remove unless we roll out on changes, so
+		// flipping that off leaves the last hash behind (harmlessly).
+		if before.RolloutOnCredentialsChange() {
+			after.Annotations = before.Annotations
+		}
+		return samePatch(t, "Do_b(Do_a(x)) vs. Do_b(x)", do(p, after), do(p, before, after))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVSphereBindingDoShape(t *testing.T) {
+	f := func(p pod, b binding) bool {
+		got := do(p, b)
+		spec := got.Spec.Template.Spec
+		ok := true
+
+		var volumes []corev1.Volume
+		for _, v := range spec.Volumes {
+			if v.Name == vsphere.VolumeName {
+				volumes = append(volumes, v)
+			}
+		}
+		if len(volumes) != 1 || volumes[0].Secret == nil || volumes[0].Secret.SecretName != b.Spec.SecretRef.Name {
+			t.Errorf("volumes = %v, wanted exactly one for secret %q", volumes, b.Spec.SecretRef.Name)
+			ok = false
+		}
+
+		// The hash is ours when we roll out on changes, and otherwise
+		// whatever was there before.
+		wantHash, wantHashOK := p.Spec.Template.Annotations[v1alpha1.CredentialsHashAnnotationKey]
+		if b.RolloutOnCredentialsChange() {
+			wantHash, wantHashOK = b.hash, b.hash != ""
+		}
+		hash, hashOK := got.Spec.Template.Annotations[v1alpha1.CredentialsHashAnnotationKey]
+		if hash != wantHash || hashOK != wantHashOK {
+			t.Errorf("hash = %q (%v), wanted %q (%v)", hash, hashOK, wantHash, wantHashOK)
+			ok = false
+		}
+
+		want := containers(p.Spec.Template.Spec)
+		for i, c := range containers(spec) {
+			orig := want[i]
+
+			var mounts []corev1.VolumeMount
+			for _, vm := range c.VolumeMounts {
+				if vm.Name == vsphere.VolumeName {
+					mounts = append(mounts, vm)
+				}
+			}
+			wantMount := corev1.VolumeMount{
+				Name:      vsphere.VolumeName,
+				ReadOnly:  true,
+				MountPath: vsphere.MountPath,
+			}
+			if len(mounts) != 1 || mounts[0] != wantMount {
+				t.Errorf("%s: mounts = %v, wanted exactly %v", c.Name, mounts, wantMount)
+				ok = false
+			}
+
+			// The user's environment comes first, untouched, followed by
+			// ours, so that we win.
+			n := len(orig.Env)
+			if len(c.Env) != n+len(boundEnv) {
+				t.Errorf("%s: env = %v, wanted %d more than %v", c.Name, c.Env, len(boundEnv), orig.Env)
+				ok = false
+				continue
+			}
+			if !cmp.Equal(orig.Env, c.Env[:n], cmpopts.EquateEmpty()) {
+				t.Errorf("%s: user env (-want, +got) = %s", c.Name, cmp.Diff(orig.Env, c.Env[:n], cmpopts.EquateEmpty()))
+				ok = false
+			}
+			var names []string
+			for _, ev := range c.Env[n:] {
+				names = append(names, ev.Name)
+			}
+			if !cmp.Equal(boundEnv, names) {
+				t.Errorf("%s: bound env (-want, +got) = %s", c.Name, cmp.Diff(boundEnv, names))
+				ok = false
+			}
+			if got, want := c.Env[n].Value, b.Spec.Address.String(); got != want {
+				t.Errorf("%s: GOVC_URL = %q, wanted %q", c.Name, got, want)
+				ok = false
+			}
+		}
+		return ok
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVSphereBindingUndoRemovesEveryTrace(t *testing.T) {
+	// A PodSpec may come to carry duplicates of what we add, e.g. from a
+	// template copied out of a bound resource, and Undo should remove them
+	// wherever they are.
+	f := func(p pod, b binding, seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		ps := p.DeepCopy()
+		spec := &ps.Spec.Template.Spec
+		for i := r.Intn(3) + 1; i > 0; i-- {
+			spec.Volumes = insert(r, spec.Volumes, corev1.Volume{Name: vsphere.VolumeName}).([]corev1.Volume)
+		}
+		for _, cs := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+			for i := range cs {
+				for j := r.Intn(3); j > 0; j-- {
+					cs[i].VolumeMounts = insert(r, cs[i].VolumeMounts, corev1.VolumeMount{Name: vsphere.VolumeName}).([]corev1.VolumeMount)
+				}
+				for j := r.Intn(5); j > 0; j-- {
+					cs[i].Env = insert(r, cs[i].Env, corev1.EnvVar{Name: pick(r, boundEnv)}).([]corev1.EnvVar)
+				}
+			}
+		}
+		if b.RolloutOnCredentialsChange() {
+			delete(p.Spec.Template.Annotations, v1alpha1.CredentialsHashAnnotationKey)
+		}
+		return samePatch(t, "Undo(x + traces) vs. x", p.WithPod, undo(ps, b, 1))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+// containers returns the init containers and containers of the PodSpec.
+func containers(spec corev1.PodSpec) []corev1.Container {
+	return append(append([]corev1.Container(nil), spec.InitContainers...), spec.Containers...)
+}
+
+// insert returns the slice with the element inserted at a random position.
+func insert(r *rand.Rand, slice interface{}, elt interface{}) interface{} {
+	s := reflect.ValueOf(slice)
+	i := r.Intn(s.Len() + 1)
+	out := reflect.MakeSlice(s.Type(), 0, s.Len()+1)
+	out = reflect.AppendSlice(out, s.Slice(0, i))
+	out = reflect.Append(out, reflect.ValueOf(elt))
+	out = reflect.AppendSlice(out, s.Slice(i, s.Len()))
+	return out.Interface()
+}
+
+func TestVSphereBindingUndoFuzz(t *testing.T) {
+	// Fuzz arbitrary PodSpecables, drawing names from pools that include
+	// ours, and check that Undo removes exactly what is ours, and nothing
+	// else.
+	var (
+		ourVolumes = append([]string{vsphere.VolumeName}, volumeNames...)
+		ourEnv     = append(append([]string(nil), boundEnv...), envNames...)
+	)
+	f := fuzz.New().NilChance(.2).NumElements(0, 4).MaxDepth(10).Funcs(
+		func(v *corev1.Volume, c fuzz.Continue) {
+			c.FuzzNoCustom(v)
+			v.Name = pick(c.Rand, ourVolumes)
+		},
+		func(vm *corev1.VolumeMount, c fuzz.Continue) {
+			c.FuzzNoCustom(vm)
+			vm.Name = pick(c.Rand, ourVolumes)
+		},
+		func(ev *corev1.EnvVar, c fuzz.Continue) {
+			c.FuzzNoCustom(ev)
+			ev.Name = pick(c.Rand, ourEnv)
+		},
+	)
+
+	for i := 0; i < 500; i++ {
+		var ps duckv1.WithPod
+		f.Fuzz(&ps.Spec.Template.Spec)
+		b := binding{VSphereBinding: &v1alpha1.VSphereBinding{}}
+
+		got := undo(&ps, b, 1)
+		if !equality.Semantic.DeepEqual(got, undo(got, b, 1)) {
+			t.Fatalf("Undo is not idempotent on %#v", ps.Spec.Template.Spec)
+		}
+
+		spec := got.Spec.Template.Spec
+		if want := withoutVolume(ps.Spec.Template.Spec.Volumes); !equality.Semantic.DeepEqual(want, spec.Volumes) {
+			t.Errorf("Undo() volumes = %v, wanted %v", spec.Volumes, want)
+		}
+		want := containers(ps.Spec.Template.Spec)
+		for j, c := range containers(spec) {
+			if want := withoutMount(want[j].VolumeMounts); !equality.Semantic.DeepEqual(want, c.VolumeMounts) {
+				t.Errorf("Undo() mounts = %v, wanted %v", c.VolumeMounts, want)
+			}
+			if want := withoutEnv(want[j].Env); !equality.Semantic.DeepEqual(want, c.Env) {
+				t.Errorf("Undo() env = %v, wanted %v", c.Env, want)
+			}
+		}
+	}
+}
+
+// withoutVolume returns the volumes other than ours.
+func withoutVolume(in []corev1.Volume) (out []corev1.Volume) {
+	for _, v := range in {
+		if v.Name != vsphere.VolumeName {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// withoutMount returns the volume mounts other than ours.
+func withoutMount(in []corev1.VolumeMount) (out []corev1.VolumeMount) {
+	for _, vm := range in {
+		if vm.Name != vsphere.VolumeName {
+			out = append(out, vm)
+		}
+	}
+	return out
+}
+
+// withoutEnv returns the environment variables other than ours.
+func withoutEnv(in []corev1.EnvVar) (out []corev1.EnvVar) {
+	for _, ev := range in {
+		switch ev.Name {
+		case "GOVC_URL", "GOVC_INSECURE", "GOVC_USERNAME", "GOVC_PASSWORD":
+		default:
+			out = append(out, ev)
+		}
+	}
+	return out
+}
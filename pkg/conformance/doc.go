@@ -0,0 +1,27 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance holds tests that our resources behave as the Knative
+// specs say that any source or binding does: that the VSphereSource has the
+// shape of a duckv1.Source, reports Ready as the conjunction of its
+// dependent conditions, and that the CloudEvent overrides on it end up on
+// the events that its adapter sends; and that the VSphereBinding has the
+// shape of a psbinding, whose Do and Undo may be applied any number of times
+// to the same PodSpec as the webhook reconciles it.
+//
+// The package has no code of its own; its tests only use our public API,
+// as any other consumer of the specs would.
+package conformance
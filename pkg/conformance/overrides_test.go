@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cebinding "github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing/pkg/adapter/v2"
+	sourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/source"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/vspheretest"
+)
+
+// receiver is a sink that keeps the CloudEvents sent to it.
+type receiver struct {
+	*httptest.Server
+
+	m      sync.Mutex
+	events []cloudevents.Event
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ev, err := cebinding.ToEvent(req.Context(), cehttp.NewMessageFromHttpRequest(req))
+		if err != nil {
+			t.Errorf("ToEvent() = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.m.Lock()
+		defer r.m.Unlock()
+		r.events = append(r.events, *ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return r
+}
+
+// received returns the events received so far.
+func (r *receiver) received() []cloudevents.Event {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]cloudevents.Event(nil), r.events...)
+}
+
+// waitFor waits for an event of the given type to be received, and returns
+// the events received by then.
+func (r *receiver) waitFor(t *testing.T, typ string) []cloudevents.Event {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		got := r.received()
+		for _, ev := range got {
+			if ev.Type() == typ {
+				return got
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", typ)
+	return nil
+}
+
+// nopReporter is a source.StatsReporter that reports nothing.
+type nopReporter struct{}
+
+func (nopReporter) ReportEventCount(*source.ReportArgs, int) error {
+	return nil
+}
+
+func TestCloudEventOverrides(t *testing.T) {
+	// The ceOverrides of a VSphereSource reach its adapter through its
+	// SinkBinding, as they would those of any source built on one, and the
+	// adapter must apply them to every event that it sends.
+	ctx := logging.WithLogger(context.Background(), zap.NewNop().Sugar())
+	sim := vspheretest.New(t)
+	defer sim.Close()
+	sink := newReceiver(t)
+	defer sink.Close()
+
+	vms := &v1alpha1.VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.VSphereSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				CloudEventOverrides: &duckv1.CloudEventOverrides{
+					Extensions: map[string]string{"team": "infra"},
+				},
+			},
+		},
+	}
+	sinkURI, err := apis.ParseURL(sink.URL)
+	if err != nil {
+		t.Fatalf("ParseURL() = %v", err)
+	}
+
+	// Bind the adapter's Deployment as the SinkBinding would.
+	d := resources.MakeDeployment(ctx, vms, "adapter")
+	ps := &duckv1.WithPod{
+		ObjectMeta: d.ObjectMeta,
+		Spec: duckv1.WithPodSpec{
+			Template: duckv1.PodSpecable(d.Spec.Template),
+		},
+	}
+	sb := resources.MakeSinkBinding(ctx, vms)
+	sb.Do(sourcesv1alpha1.WithSinkURI(ctx, sinkURI), ps)
+
+	stop := startAdapterFromContainer(t, ps.Spec.Template.Spec.Containers[0], vms)
+	defer stop()
+
+	// Post events until the adapter sends one, and then make one happen.
+	manager := event.NewManager(sim.Client.Client)
+	vm := sim.VM(ctx, "DC0_H0_VM0")
+	for deadline := time.Now().Add(10 * time.Second); len(sink.received()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the adapter")
+		}
+		err := manager.PostEvent(ctx, &types.GeneralUserEvent{
+			GeneralEvent: types.GeneralEvent{
+				Event: types.Event{
+					Vm: &types.VmEventArgument{
+						EntityEventArgument: types.EntityEventArgument{Name: "ping"},
+						Vm:                  vm.Reference(),
+					},
+				},
+				Message: "ping",
+			},
+		})
+		if err != nil {
+			t.Fatalf("PostEvent() = %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	sim.PowerOn(ctx, sim.CreateVM(ctx, "overrides"))
+
+	const wantType = "com.vmware.vsphere.VmPoweredOnEvent"
+	got := sink.waitFor(t, wantType)
+
+	for _, ev := range got {
+		if got, want := ev.Extensions()["team"], "infra"; got != want {
+			t.Errorf("%s: team = %v, wanted %q", ev.Type(), got, want)
+		}
+		if got, want := ev.Source(), sim.URL; got != want {
+			t.Errorf("%s: Source() = %s, wanted %s", ev.Type(), got, want)
+		}
+	}
+}
+
+// startAdapterFromContainer starts the adapter with the environment of the
+// bound container, as it would run in it, and returns a func that stops it.
+func startAdapterFromContainer(t *testing.T, c corev1.Container, vms *v1alpha1.VSphereSource) (stop func()) {
+	t.Helper()
+
+	env := map[string]string{
+		// These come from the downward API.
+		"NAMESPACE": vms.Namespace,
+		"NAME":      vms.Name,
+		// We don't have a cluster to keep the checkpoint in.
+		"VSPHERE_KVSTORE": "memory://",
+	}
+	for _, ev := range c.Env {
+		if ev.ValueFrom == nil {
+			env[ev.Name] = ev.Value
+		}
+	}
+	for _, key := range []string{"K_SINK", "K_CE_OVERRIDES"} {
+		if _, ok := env[key]; !ok {
+			t.Fatalf("SinkBinding didn't set %s, got %v", key, c.Env)
+		}
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	processed := vsphere.NewEnvConfig()
+	err := envconfig.Process("", processed)
+	for key := range env {
+		os.Unsetenv(key)
+	}
+	if err != nil {
+		t.Fatalf("Process() = %v", err)
+	}
+
+	overrides, err := processed.GetCloudEventOverrides()
+	if err != nil {
+		t.Fatalf("GetCloudEventOverrides() = %v", err)
+	}
+	ce, err := adapter.NewCloudEventsClient(processed.GetSink(), overrides, nopReporter{})
+	if err != nil {
+		t.Fatalf("NewCloudEventsClient() = %v", err)
+	}
+
+	ctx := logging.WithLogger(context.Background(), zap.NewNop().Sugar())
+	a := vsphere.NewAdapter(ctx, processed, ce)
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		err := a.Start(stopCh)
+		select {
+		case <-stopCh:
+			// Whatever it was doing was interrupted.
+		default:
+			t.Errorf("Start() = %v", err)
+		}
+	}()
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1beta1"
+)
+
+func TestVSphereSourceDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}, {
+		name: "source",
+		t:    &duckv1.Source{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, vms := range []interface{}{&v1alpha1.VSphereSource{}, &v1beta1.VSphereSource{}} {
+				if err := duck.VerifyType(vms, test.t); err != nil {
+					t.Errorf("VerifyType(%T, %T) = %v", vms, test.t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestVSphereSourceAsSource(t *testing.T) {
+	// Tools that only know about sources read ours through duckv1.Source,
+	// so check that what we set is where they'll look, in each version.
+	sink := duckv1.Destination{
+		URI: apis.HTTP("sink.example.com"),
+	}
+	overrides := &duckv1.CloudEventOverrides{
+		Extensions: map[string]string{"team": "infra"},
+	}
+	sinkURI := apis.HTTP("sink.example.com")
+	attrs := []duckv1.CloudEventAttributes{{
+		Type:   "com.vmware.vsphere.VmPoweredOnEvent",
+		Source: "https://vcenter.example.com/sdk",
+	}}
+
+	vms := &v1alpha1.VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.VSphereSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink:                sink,
+				CloudEventOverrides: overrides,
+			},
+		},
+	}
+	vms.Status.InitializeConditions()
+	vms.Status.PropagateSourceStatus(duckv1.SourceStatus{
+		SinkURI:              sinkURI,
+		CloudEventAttributes: attrs,
+	})
+	beta := &v1beta1.VSphereSource{}
+	if err := vms.ConvertTo(context.Background(), beta); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+
+	for _, obj := range []interface{}{vms, beta} {
+		t.Run(fmt.Sprintf("%T", obj), func(t *testing.T) {
+			b, err := json.Marshal(obj)
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+			var got duckv1.Source
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("Unmarshal() = %v", err)
+			}
+
+			if !cmp.Equal(sink, got.Spec.Sink) {
+				t.Errorf("spec.sink (-want, +got) = %s", cmp.Diff(sink, got.Spec.Sink))
+			}
+			if !cmp.Equal(overrides, got.Spec.CloudEventOverrides) {
+				t.Errorf("spec.ceOverrides (-want, +got) = %s", cmp.Diff(overrides, got.Spec.CloudEventOverrides))
+			}
+			if !cmp.Equal(sinkURI, got.Status.SinkURI) {
+				t.Errorf("status.sinkUri (-want, +got) = %s", cmp.Diff(sinkURI, got.Status.SinkURI))
+			}
+			if !cmp.Equal(attrs, got.Status.CloudEventAttributes) {
+				t.Errorf("status.ceAttributes (-want, +got) = %s", cmp.Diff(attrs, got.Status.CloudEventAttributes))
+			}
+			if got.Status.GetCondition(apis.ConditionReady) == nil {
+				t.Error("status has no Ready condition")
+			}
+		})
+	}
+}
+
+// mark sets a condition of the source to the given status, through the
+// methods that the reconciler uses.
+type mark func(*v1alpha1.VSphereSourceStatus, corev1.ConditionStatus)
+
+// readyDependents are the conditions that Ready is the conjunction of.
+var readyDependents = map[apis.ConditionType]mark{
+	v1alpha1.VSphereSourceConditionSourceReady: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		s.PropagateSourceStatus(duckv1.SourceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionReady,
+					Status: cs,
+				}},
+			},
+		})
+	},
+	v1alpha1.VSphereSourceConditionAuthReady: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		s.PropagateAuthStatus(duckv1.Status{
+			Conditions: duckv1.Conditions{{
+				Type:   apis.ConditionReady,
+				Status: cs,
+			}},
+		})
+	},
+	v1alpha1.VSphereSourceConditionAdapterReady: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		s.PropagateAdapterStatus(appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentAvailable,
+				Status: cs,
+			}},
+		})
+	},
+	v1alpha1.VSphereSourceConditionRoutesReady: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		switch cs {
+		case corev1.ConditionTrue:
+			s.MarkRoutesReady(nil)
+		case corev1.ConditionFalse:
+			s.MarkRoutesNotReady("Unresolved", "the sink of a route could not be resolved")
+		default:
+			// Routes are only ever Unknown before they are first resolved.
+		}
+	},
+}
+
+// informational are the conditions that are reported, but that don't
+// affect Ready, where empty means that the condition is cleared.
+var informational = map[apis.ConditionType]mark{
+	v1alpha1.VSphereSourceConditionSuspended: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		if cs == corev1.ConditionTrue {
+			s.MarkSuspended()
+		} else {
+			s.MarkResumed()
+		}
+	},
+	v1alpha1.VSphereSourceConditionStreaming: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		switch cs {
+		case corev1.ConditionTrue:
+			s.MarkStreaming()
+		case corev1.ConditionFalse:
+			s.MarkNotStreaming("Stalled", "no progress")
+		case corev1.ConditionUnknown:
+			s.MarkStreamingUnknown("Starting", "no report yet")
+		default:
+			s.ClearStreamingStatus()
+		}
+	},
+	v1alpha1.VSphereSourceConditionExpressionsHealthy: func(s *v1alpha1.VSphereSourceStatus, cs corev1.ConditionStatus) {
+		switch cs {
+		case corev1.ConditionTrue:
+			s.MarkExpressionsHealthy()
+		case corev1.ConditionFalse:
+			s.MarkExpressionsFailing("EvaluationFailed", "no such key")
+		default:
+			s.ClearExpressionsStatus()
+		}
+	},
+}
+
+var (
+	statuses = []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown}
+
+	// informationalStatuses also include the condition being cleared.
+	informationalStatuses = append(statuses[:len(statuses):len(statuses)], "")
+)
+
+func TestVSphereSourceReadySemantics(t *testing.T) {
+	// Ready is True when all of its dependents are, False when any of them
+	// is, and otherwise Unknown, whatever else is going on and whatever the
+	// order in which the reconciler learns of it.
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 5000; i++ {
+		type setting struct {
+			cond apis.ConditionType
+			cs   corev1.ConditionStatus
+			mark mark
+		}
+		var settings []setting
+		want := corev1.ConditionTrue
+		for cond, m := range readyDependents {
+			cs := statuses[r.Intn(len(statuses))]
+			settings = append(settings, setting{cond, cs, m})
+			switch {
+			case cs == corev1.ConditionFalse:
+				want = corev1.ConditionFalse
+			case cs == corev1.ConditionUnknown && want == corev1.ConditionTrue:
+				want = corev1.ConditionUnknown
+			}
+		}
+		for cond, m := range informational {
+			cs := informationalStatuses[r.Intn(len(informationalStatuses))]
+			settings = append(settings, setting{cond, cs, m})
+		}
+		r.Shuffle(len(settings), func(i, j int) {
+			settings[i], settings[j] = settings[j], settings[i]
+		})
+
+		s := &v1alpha1.VSphereSourceStatus{}
+		s.InitializeConditions()
+		for _, set := range settings {
+			set.mark(s, set.cs)
+		}
+
+		if got := s.GetCondition(v1alpha1.VSphereSourceConditionReady).Status; got != want {
+			var desc []string
+			for _, set := range settings {
+				desc = append(desc, fmt.Sprintf("%s=%q", set.cond, set.cs))
+			}
+			t.Fatalf("Ready = %s, wanted %s after %v", got, want, desc)
+		}
+		if got, want := s.IsReady(), want == corev1.ConditionTrue; got != want {
+			t.Fatalf("IsReady() = %v, wanted %v", got, want)
+		}
+	}
+}
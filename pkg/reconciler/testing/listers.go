@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	eventingsourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
+	fakeeventingclientset "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	eventingv1beta1listers "knative.dev/eventing/pkg/client/listers/eventing/v1beta1"
+	eventingsourcesv1alpha1listers "knative.dev/eventing/pkg/client/listers/sources/v1alpha1"
+	"knative.dev/pkg/reconciler/testing"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	fakeclientset "github.com/mattmoor/vmware-sources/pkg/client/clientset/versioned/fake"
+	v1alpha1listers "github.com/mattmoor/vmware-sources/pkg/client/listers/sources/v1alpha1"
+)
+
+var clientSetSchemes = []func(*runtime.Scheme) error{
+	fakekubeclientset.AddToScheme,
+	fakeclientset.AddToScheme,
+	fakeeventingclientset.AddToScheme,
+	addEventTypesToScheme,
+}
+
+// addEventTypesToScheme registers the v1beta1 EventTypes, which the
+// eventing clientset's scheme leaves out, so that we can serve them from a
+// lister.  The fake eventing clientset can't be seeded with them, so they
+// are only seen through the lister.
+func addEventTypesToScheme(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(eventingv1beta1.SchemeGroupVersion,
+		&eventingv1beta1.EventType{},
+		&eventingv1beta1.EventTypeList{},
+	)
+	return nil
+}
+
+// Listers serves the objects of a table test row from listers, as the
+// informers would.
+type Listers struct {
+	sorter testing.ObjectSorter
+}
+
+// NewListers returns Listers for the objects, which must all be of types
+// known to one of our clientsets.
+func NewListers(objs []runtime.Object) Listers {
+	scheme := runtime.NewScheme()
+	for _, addTo := range clientSetSchemes {
+		addTo(scheme)
+	}
+
+	ls := Listers{
+		sorter: testing.NewObjectSorter(scheme),
+	}
+	ls.sorter.AddObjects(objs...)
+	return ls
+}
+
+// indexerFor returns the indexer holding the objects of the given type.
+func (l *Listers) indexerFor(obj runtime.Object) cache.Indexer {
+	return l.sorter.IndexerForObjectType(obj)
+}
+
+// GetKubeObjects returns the objects for the fake Kubernetes clientset.
+func (l *Listers) GetKubeObjects() []runtime.Object {
+	return l.sorter.ObjectsForSchemeFunc(fakekubeclientset.AddToScheme)
+}
+
+// GetSourcesObjects returns the objects for our fake clientset.
+func (l *Listers) GetSourcesObjects() []runtime.Object {
+	return l.sorter.ObjectsForSchemeFunc(fakeclientset.AddToScheme)
+}
+
+// GetEventingObjects returns the objects for the fake eventing clientset.
+func (l *Listers) GetEventingObjects() []runtime.Object {
+	return l.sorter.ObjectsForSchemeFunc(fakeeventingclientset.AddToScheme)
+}
+
+// GetVSphereSourceLister returns a lister for the VSphereSources.
+func (l *Listers) GetVSphereSourceLister() v1alpha1listers.VSphereSourceLister {
+	return v1alpha1listers.NewVSphereSourceLister(l.indexerFor(&v1alpha1.VSphereSource{}))
+}
+
+// GetVSphereBindingLister returns a lister for the VSphereBindings.
+func (l *Listers) GetVSphereBindingLister() v1alpha1listers.VSphereBindingLister {
+	return v1alpha1listers.NewVSphereBindingLister(l.indexerFor(&v1alpha1.VSphereBinding{}))
+}
+
+// GetVSphereSinkLister returns a lister for the VSphereSinks.
+func (l *Listers) GetVSphereSinkLister() v1alpha1listers.VSphereSinkLister {
+	return v1alpha1listers.NewVSphereSinkLister(l.indexerFor(&v1alpha1.VSphereSink{}))
+}
+
+// GetVSphereActionLister returns a lister for the VSphereActions.
+func (l *Listers) GetVSphereActionLister() v1alpha1listers.VSphereActionLister {
+	return v1alpha1listers.NewVSphereActionLister(l.indexerFor(&v1alpha1.VSphereAction{}))
+}
+
+// GetSinkBindingLister returns a lister for the SinkBindings.
+func (l *Listers) GetSinkBindingLister() eventingsourcesv1alpha1listers.SinkBindingLister {
+	return eventingsourcesv1alpha1listers.NewSinkBindingLister(l.indexerFor(&eventingsourcesv1alpha1.SinkBinding{}))
+}
+
+// GetEventTypeLister returns a lister for the EventTypes.
+func (l *Listers) GetEventTypeLister() eventingv1beta1listers.EventTypeLister {
+	return eventingv1beta1listers.NewEventTypeLister(l.indexerFor(&eventingv1beta1.EventType{}))
+}
+
+// GetDeploymentLister returns a lister for the Deployments.
+func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
+	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
+}
+
+// GetConfigMapLister returns a lister for the ConfigMaps.
+func (l *Listers) GetConfigMapLister() corev1listers.ConfigMapLister {
+	return corev1listers.NewConfigMapLister(l.indexerFor(&corev1.ConfigMap{}))
+}
+
+// GetSecretLister returns a lister for the Secrets.
+func (l *Listers) GetSecretLister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(l.indexerFor(&corev1.Secret{}))
+}
+
+// GetServiceLister returns a lister for the Services.
+func (l *Listers) GetServiceLister() corev1listers.ServiceLister {
+	return corev1listers.NewServiceLister(l.indexerFor(&corev1.Service{}))
+}
+
+// GetServiceAccountLister returns a lister for the ServiceAccounts.
+func (l *Listers) GetServiceAccountLister() corev1listers.ServiceAccountLister {
+	return corev1listers.NewServiceAccountLister(l.indexerFor(&corev1.ServiceAccount{}))
+}
+
+// GetRoleLister returns a lister for the Roles.
+func (l *Listers) GetRoleLister() rbacv1listers.RoleLister {
+	return rbacv1listers.NewRoleLister(l.indexerFor(&rbacv1.Role{}))
+}
+
+// GetRoleBindingLister returns a lister for the RoleBindings.
+func (l *Listers) GetRoleBindingLister() rbacv1listers.RoleBindingLister {
+	return rbacv1listers.NewRoleBindingLister(l.indexerFor(&rbacv1.RoleBinding{}))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	fakeclient "github.com/mattmoor/vmware-sources/pkg/client/injection/client/fake"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver/resources"
+)
+
+var (
+	// ReadyConditions are the conditions of a ready child of a server.
+	ReadyConditions = duckv1.Conditions{{
+		Type:   apis.ConditionReady,
+		Status: corev1.ConditionTrue,
+	}}
+
+	// AvailableStatus is the status of a server's available Deployment.
+	AvailableStatus = appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	}
+)
+
+// ServerStatus is the status of a resource that runs a server with vSphere
+// credentials behind a Service, e.g. a VSphereSink, seen through the object
+// that it belongs to.
+type ServerStatus interface {
+	InitializeConditions()
+	PropagateAuthStatus(duckv1.Status)
+	PropagateServerStatus(appsv1.DeploymentStatus)
+	SetAddress(*apis.URL)
+	MarkResourceNotOwned(kind, name string)
+
+	// ObserveGeneration records that the status reflects the object's
+	// current generation.
+	ObserveGeneration()
+}
+
+// ServerStatusOption enables further configuration of a ServerStatus.
+type ServerStatusOption func(ServerStatus)
+
+// WithInitServerConditions initializes the status's conditions.
+func WithInitServerConditions(s ServerStatus) {
+	s.InitializeConditions()
+}
+
+// WithServerAuthStatus reflects the status of the server's VSphereBinding
+// in the status.
+func WithServerAuthStatus(status duckv1.Status) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.PropagateAuthStatus(status)
+	}
+}
+
+// WithServerDeploymentStatus reflects the status of the server's
+// Deployment in the status.
+func WithServerDeploymentStatus(status appsv1.DeploymentStatus) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.PropagateServerStatus(status)
+	}
+}
+
+// WithServerAddress sets the address of the server.
+func WithServerAddress(url *apis.URL) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.SetAddress(url)
+	}
+}
+
+// WithServerResourceNotOwned marks the status as failing because of an
+// existing resource of the given kind that its owner doesn't own.
+func WithServerResourceNotOwned(kind, name string) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.MarkResourceNotOwned(kind, name)
+	}
+}
+
+// WithServerCreated gives the status that we report once we've created
+// all of the server's children, which aren't ready yet.
+func WithServerCreated(url *apis.URL) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.InitializeConditions()
+		s.PropagateAuthStatus(duckv1.Status{})
+		s.PropagateServerStatus(appsv1.DeploymentStatus{})
+		s.SetAddress(url)
+		s.ObserveGeneration()
+	}
+}
+
+// WithServerReady gives the status that we report once all of the
+// server's children are ready.
+func WithServerReady(url *apis.URL) ServerStatusOption {
+	return func(s ServerStatus) {
+		s.InitializeConditions()
+		s.PropagateAuthStatus(duckv1.Status{Conditions: ReadyConditions})
+		s.PropagateServerStatus(AvailableStatus)
+		s.SetAddress(url)
+		s.ObserveGeneration()
+	}
+}
+
+// MakeServerReconciler makes the vsphereserver.Reconciler that the reconciler
+// under test embeds, from the fake clients and the listers of the test.
+func MakeServerReconciler(ctx context.Context, listers *Listers) vsphereserver.Reconciler {
+	return vsphereserver.Reconciler{
+		KubeClient:           fakekubeclient.Get(ctx),
+		Client:               fakeclient.Get(ctx),
+		DeploymentLister:     listers.GetDeploymentLister(),
+		ServiceLister:        listers.GetServiceLister(),
+		VSphereBindingLister: listers.GetVSphereBindingLister(),
+	}
+}
+
+// ServerVSphereBinding returns the server's VSphereBinding.
+func ServerVSphereBinding(s *resources.Server) *v1alpha1.VSphereBinding {
+	return resources.MakeVSphereBinding(context.Background(), s)
+}
+
+// ReadyVSphereBinding returns the server's VSphereBinding, ready.
+func ReadyVSphereBinding(s *resources.Server) *v1alpha1.VSphereBinding {
+	vsb := ServerVSphereBinding(s)
+	vsb.Status.Conditions = ReadyConditions
+	return vsb
+}
+
+// ServerDeployment returns the server's Deployment.
+func ServerDeployment(s *resources.Server) *appsv1.Deployment {
+	return resources.MakeDeployment(context.Background(), s)
+}
+
+// AvailableDeployment returns the server's Deployment, available.
+func AvailableDeployment(s *resources.Server) *appsv1.Deployment {
+	d := ServerDeployment(s)
+	d.Status = AvailableStatus
+	return d
+}
+
+// ServerService returns the server's Service.
+func ServerService(s *resources.Server) *corev1.Service {
+	return resources.MakeService(context.Background(), s)
+}
+
+// ServerChildren returns the server's children, all ready.  The given
+// objects replace the children of the same kind.
+func ServerChildren(s *resources.Server, replace ...runtime.Object) []runtime.Object {
+	objs := []runtime.Object{
+		ReadyVSphereBinding(s),
+		AvailableDeployment(s),
+		ServerService(s),
+	}
+	for _, r := range replace {
+		for i, obj := range objs {
+			if reflect.TypeOf(obj) == reflect.TypeOf(r) {
+				objs[i] = r
+			}
+		}
+	}
+	return objs
+}
+
+// NotOwned strips the owner references from one of a server's children.
+func NotOwned(obj runtime.Object) runtime.Object {
+	obj.(metav1.Object).SetOwnerReferences(nil)
+	return obj
+}
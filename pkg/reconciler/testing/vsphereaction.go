@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+// VSphereActionOption enables further configuration of a VSphereAction.
+type VSphereActionOption func(*v1alpha1.VSphereAction)
+
+// NewVSphereAction creates a VSphereAction with VSphereActionOptions.
+func NewVSphereAction(name, namespace string, o ...VSphereActionOption) *v1alpha1.VSphereAction {
+	va := &v1alpha1.VSphereAction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range o {
+		opt(va)
+	}
+	return va
+}
+
+// WithVSphereActionSpec sets the action's spec.
+func WithVSphereActionSpec(spec v1alpha1.VSphereActionSpec) VSphereActionOption {
+	return func(va *v1alpha1.VSphereAction) {
+		va.Spec = spec
+	}
+}
+
+// WithVSphereActionGeneration sets the action's generation.
+func WithVSphereActionGeneration(gen int64) VSphereActionOption {
+	return func(va *v1alpha1.VSphereAction) {
+		va.Generation = gen
+	}
+}
+
+// WithVSphereActionObservedGeneration sets the generation of the action that
+// its status reflects.
+func WithVSphereActionObservedGeneration(gen int64) VSphereActionOption {
+	return func(va *v1alpha1.VSphereAction) {
+		va.Status.ObservedGeneration = gen
+	}
+}
+
+// WithVSphereActionStatus applies the ServerStatusOptions to the action's
+// status.
+func WithVSphereActionStatus(o ...ServerStatusOption) VSphereActionOption {
+	return func(va *v1alpha1.VSphereAction) {
+		for _, opt := range o {
+			opt(vsphereActionServerStatus{va})
+		}
+	}
+}
+
+// vsphereActionServerStatus is the ServerStatus of a VSphereAction, whose server
+// is its executor.
+type vsphereActionServerStatus struct {
+	*v1alpha1.VSphereAction
+}
+
+func (s vsphereActionServerStatus) InitializeConditions() {
+	s.Status.InitializeConditions()
+}
+
+func (s vsphereActionServerStatus) PropagateAuthStatus(status duckv1.Status) {
+	s.Status.PropagateAuthStatus(status)
+}
+
+func (s vsphereActionServerStatus) PropagateServerStatus(status appsv1.DeploymentStatus) {
+	s.Status.PropagateExecutorStatus(status)
+}
+
+func (s vsphereActionServerStatus) SetAddress(url *apis.URL) {
+	s.Status.SetAddress(url)
+}
+
+func (s vsphereActionServerStatus) MarkResourceNotOwned(kind, name string) {
+	s.Status.MarkResourceNotOwned(kind, name)
+}
+
+func (s vsphereActionServerStatus) ObserveGeneration() {
+	s.Status.ObservedGeneration = s.Generation
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+// VSphereSinkOption enables further configuration of a VSphereSink.
+type VSphereSinkOption func(*v1alpha1.VSphereSink)
+
+// NewVSphereSink creates a VSphereSink with VSphereSinkOptions.
+func NewVSphereSink(name, namespace string, o ...VSphereSinkOption) *v1alpha1.VSphereSink {
+	vs := &v1alpha1.VSphereSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range o {
+		opt(vs)
+	}
+	return vs
+}
+
+// WithVSphereSinkSpec sets the sink's spec.
+func WithVSphereSinkSpec(spec v1alpha1.VSphereSinkSpec) VSphereSinkOption {
+	return func(vs *v1alpha1.VSphereSink) {
+		vs.Spec = spec
+	}
+}
+
+// WithVSphereSinkGeneration sets the sink's generation.
+func WithVSphereSinkGeneration(gen int64) VSphereSinkOption {
+	return func(vs *v1alpha1.VSphereSink) {
+		vs.Generation = gen
+	}
+}
+
+// WithVSphereSinkObservedGeneration sets the generation of the sink that
+// its status reflects.
+func WithVSphereSinkObservedGeneration(gen int64) VSphereSinkOption {
+	return func(vs *v1alpha1.VSphereSink) {
+		vs.Status.ObservedGeneration = gen
+	}
+}
+
+// WithVSphereSinkStatus applies the ServerStatusOptions to the sink's
+// status.
+func WithVSphereSinkStatus(o ...ServerStatusOption) VSphereSinkOption {
+	return func(vs *v1alpha1.VSphereSink) {
+		for _, opt := range o {
+			opt(vsphereSinkServerStatus{vs})
+		}
+	}
+}
+
+// vsphereSinkServerStatus is the ServerStatus of a VSphereSink, whose server
+// is its receiver.
+type vsphereSinkServerStatus struct {
+	*v1alpha1.VSphereSink
+}
+
+func (s vsphereSinkServerStatus) InitializeConditions() {
+	s.Status.InitializeConditions()
+}
+
+func (s vsphereSinkServerStatus) PropagateAuthStatus(status duckv1.Status) {
+	s.Status.PropagateAuthStatus(status)
+}
+
+func (s vsphereSinkServerStatus) PropagateServerStatus(status appsv1.DeploymentStatus) {
+	s.Status.PropagateReceiverStatus(status)
+}
+
+func (s vsphereSinkServerStatus) SetAddress(url *apis.URL) {
+	s.Status.SetAddress(url)
+}
+
+func (s vsphereSinkServerStatus) MarkResourceNotOwned(kind, name string) {
+	s.Status.MarkResourceNotOwned(kind, name)
+}
+
+func (s vsphereSinkServerStatus) ObserveGeneration() {
+	s.Status.ObservedGeneration = s.Generation
+}
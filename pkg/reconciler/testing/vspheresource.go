@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+// VSphereSourceOption enables further configuration of a VSphereSource.
+type VSphereSourceOption func(*v1alpha1.VSphereSource)
+
+// NewVSphereSource creates a VSphereSource with VSphereSourceOptions.
+func NewVSphereSource(name, namespace string, o ...VSphereSourceOption) *v1alpha1.VSphereSource {
+	vms := &v1alpha1.VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range o {
+		opt(vms)
+	}
+	return vms
+}
+
+// WithVSphereSourceSpec sets the source's spec.
+func WithVSphereSourceSpec(spec v1alpha1.VSphereSourceSpec) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Spec = spec
+	}
+}
+
+// WithVSphereSourceGeneration sets the source's generation.
+func WithVSphereSourceGeneration(gen int64) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Generation = gen
+	}
+}
+
+// WithVSphereSourceObservedGeneration sets the generation of the source
+// that its status reflects.
+func WithVSphereSourceObservedGeneration(gen int64) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.ObservedGeneration = gen
+	}
+}
+
+// WithInitVSphereSourceConditions initializes the source's conditions.
+func WithInitVSphereSourceConditions(vms *v1alpha1.VSphereSource) {
+	vms.Status.InitializeConditions()
+}
+
+// WithVSphereSourceSinkBindingStatus reflects the status of the source's
+// SinkBinding in it.
+func WithVSphereSourceSinkBindingStatus(status duckv1.SourceStatus) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.PropagateSourceStatus(status)
+	}
+}
+
+// WithVSphereSourceAuthStatus reflects the status of the source's
+// VSphereBinding in it.
+func WithVSphereSourceAuthStatus(status duckv1.Status) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.PropagateAuthStatus(status)
+	}
+}
+
+// WithVSphereSourceAuthNotReady marks the source's connection to vSphere
+// as not ready.
+func WithVSphereSourceAuthNotReady(reason, message string) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkAuthNotReady(reason, "%s", message)
+	}
+}
+
+// WithVSphereSourceAdapterStatus reflects the status of the source's
+// Deployment in it.
+func WithVSphereSourceAdapterStatus(status appsv1.DeploymentStatus) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.PropagateAdapterStatus(status)
+	}
+}
+
+// WithVSphereSourceResourceNotOwned marks the source as failing because of
+// an existing resource of the given kind that it doesn't own.
+func WithVSphereSourceResourceNotOwned(kind, name string) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkResourceNotOwned(kind, name)
+	}
+}
+
+// WithVSphereSourceRoutesReady records the resolved sinks of the source's
+// routes.
+func WithVSphereSourceRoutesReady(routes ...v1alpha1.RouteStatus) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkRoutesReady(routes)
+	}
+}
+
+// WithVSphereSourceRoutesNotReady marks the source's routes as not ready.
+func WithVSphereSourceRoutesNotReady(reason, message string) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkRoutesNotReady(reason, "%s", message)
+	}
+}
+
+// WithVSphereSourceExpressionsHealthy marks the source's expressions as
+// healthy.
+func WithVSphereSourceExpressionsHealthy(vms *v1alpha1.VSphereSource) {
+	vms.Status.MarkExpressionsHealthy()
+}
+
+// WithVSphereSourceExpressionsFailing marks the source's expressions as
+// failing.
+func WithVSphereSourceExpressionsFailing(reason, message string) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkExpressionsFailing(reason, "%s", message)
+	}
+}
+
+// WithVSphereSourceStreamingUnknown marks whether the source's adapter is
+// streaming events as unknown.
+func WithVSphereSourceStreamingUnknown(reason, message string) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.MarkStreamingUnknown(reason, "%s", message)
+	}
+}
+
+// WithVSphereSourceSuspended marks the source as suspended.
+func WithVSphereSourceSuspended(vms *v1alpha1.VSphereSource) {
+	vms.Status.MarkSuspended()
+}
+
+// WithVSphereSourceCloudEventAttributes sets the attributes of the
+// CloudEvents that the source may emit.
+func WithVSphereSourceCloudEventAttributes(attrs []duckv1.CloudEventAttributes) VSphereSourceOption {
+	return func(vms *v1alpha1.VSphereSource) {
+		vms.Status.CloudEventAttributes = attrs
+	}
+}
@@ -20,22 +20,28 @@ import (
 	"context"
 	"os"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/tracker"
 
 	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
 	"github.com/mattmoor/vmware-sources/pkg/client/injection/client"
 	vspherebindinginformer "github.com/mattmoor/vmware-sources/pkg/client/injection/informers/sources/v1alpha1/vspherebinding"
 	vsphereinformer "github.com/mattmoor/vmware-sources/pkg/client/injection/informers/sources/v1alpha1/vspheresource"
 	vspherereconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vspheresource"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	eventtypeinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta1/eventtype"
 	sinkbindinginformer "knative.dev/eventing/pkg/client/injection/informers/sources/v1alpha1/sinkbinding"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
 	cminformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	sainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
+	roleinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/role"
 	rbacinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
 )
 
@@ -49,10 +55,13 @@ func NewController(
 	vsphereInformer := vsphereinformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
 	sinkbindingInformer := sinkbindinginformer.Get(ctx)
+	roleInformer := roleinformer.Get(ctx)
 	rbacInformer := rbacinformer.Get(ctx)
 	cmInformer := cminformer.Get(ctx)
 	vspherebindingInformer := vspherebindinginformer.Get(ctx)
 	saInformer := sainformer.Get(ctx)
+	eventtypeInformer := eventtypeinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
 
 	r := &Reconciler{
 		adapterImage:         os.Getenv("VSPHERE_ADAPTER"),
@@ -63,15 +72,33 @@ func NewController(
 		vspherebindingLister: vspherebindingInformer.Lister(),
 		sinkbindingLister:    sinkbindingInformer.Lister(),
 		cmLister:             cmInformer.Lister(),
+		roleLister:           roleInformer.Lister(),
 		rbacLister:           rbacInformer.Lister(),
 		saLister:             saInformer.Lister(),
+		eventtypeLister:      eventtypeInformer.Lister(),
+		secretLister:         secretInformer.Lister(),
 	}
 	impl := vspherereconciler.NewImpl(ctx, r)
+	r.enqueueAfter = impl.EnqueueAfter
 
 	logger.Info("Setting up event handlers.")
 
 	vsphereInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
+	// The Secrets aren't ours, so we track the ones that our sources
+	// reference to roll their adapters when the credentials change.
+	r.tracker = tracker.New(impl.EnqueueKey, controller.GetTrackerLease(ctx))
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		// Call the tracker's OnChanged method, but we've seen the objects
+		// coming through this path missing TypeMeta, so ensure it is properly
+		// populated.
+		controller.EnsureTypeMeta(r.tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret"))))
+
+	// Likewise for the VSphereBindings that our sources reference with
+	// their connectionRef.
+	vspherebindingInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(r.tracker.OnChanged, v1alpha1.SchemeGroupVersion.WithKind("VSphereBinding"))))
+
 	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -82,13 +109,41 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	roleInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	rbacInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
-	// Don't trigger off of CM updates because we don't care about the content
-	// and it is high churn.
+	// Don't trigger off of most CM updates because we don't care about the
+	// content and it is high churn, but do pick up the status that the
+	// adapter reports back to us, which it writes only on change (or once
+	// per heartbeat interval, for its progress, so we skip heartbeats that
+	// change nothing else).  We do need to recreate the CM when it is
+	// deleted, since the adapter may only access its own CM, and so can't
+	// create it.
+	cmInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
+		Handler: cache.ResourceEventHandlerFuncs{
+			DeleteFunc: impl.EnqueueControllerOf,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldCM, newCM := oldObj.(*corev1.ConfigMap), newObj.(*corev1.ConfigMap)
+				for _, key := range []string{vsphere.ExpressionStatusKey, vsphere.EventCatalogKey} {
+					if oldCM.Data[key] != newCM.Data[key] {
+						impl.EnqueueControllerOf(newObj)
+						return
+					}
+				}
+				if progressChanged(oldCM.Data[vsphere.ProgressKey], newCM.Data[vsphere.ProgressKey]) {
+					impl.EnqueueControllerOf(newObj)
+				}
+			},
+		},
+	})
 
 	sinkbindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
@@ -100,5 +155,10 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	eventtypeInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereSource")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	return impl
 }
@@ -18,9 +18,12 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
@@ -31,17 +34,98 @@ import (
 
 func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, adapterImage string) *appsv1.Deployment {
 	labels := map[string]string{
-		"vspheresources.sources.knative.dev/name": vms.Name,
+		v1alpha1.VSphereSourceLabelKey: vms.Name,
 	}
 
-	return &appsv1.Deployment{
+	env := []corev1.EnvVar{{
+		Name: "NAMESPACE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.namespace",
+			},
+		},
+	}, {
+		Name: "NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}, {
+		Name:  "K_METRICS_CONFIG",
+		Value: `{"Domain":"vsphere.vmware.com/source","Component":"source"}`,
+	}, {
+		Name:  "K_LOGGING_CONFIG",
+		Value: "{}",
+	}, {
+		Name:  "VSPHERE_KVSTORE_CONFIGMAP",
+		Value: names.ConfigMap(vms),
+	}}
+
+	if vms.Spec.Scope != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_SCOPE",
+			Value: vms.Spec.Scope,
+		})
+	}
+	if vms.Spec.Delivery != nil {
+		b, _ := json.Marshal(vms.Spec.Delivery)
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_DELIVERY",
+			Value: string(b),
+		})
+	}
+	if vms.Spec.Selector != nil {
+		// The adapter can't depend on our API types, so we hand it the
+		// selector as JSON, which it decodes into a mirror of EntitySelector.
+		b, _ := json.Marshal(vms.Spec.Selector)
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_SELECTOR",
+			Value: string(b),
+		})
+	}
+	if vms.Spec.Filter != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_FILTER",
+			Value: vms.Spec.Filter,
+		})
+	}
+	if vms.Spec.Transform != nil {
+		b, _ := json.Marshal(vms.Spec.Transform)
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_TRANSFORM",
+			Value: string(b),
+		})
+	}
+	if routes := makeRoutes(vms); len(routes) > 0 {
+		b, _ := json.Marshal(routes)
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_ROUTES",
+			Value: string(b),
+		})
+	}
+	if vms.Spec.ReplySink != nil && vms.Status.ReplySinkURI != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_REPLY_SINK",
+			Value: vms.Status.ReplySinkURI.String(),
+		})
+	}
+
+	// A suspended source keeps its Deployment (and checkpoint), but has no
+	// adapter running.
+	replicas := int32(1)
+	if vms.Spec.Suspend {
+		replicas = 0
+	}
+
+	d := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            names.Deployment(vms),
 			Namespace:       vms.Namespace,
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.Int32(1),
+			Replicas: ptr.Int32(replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
@@ -52,35 +136,108 @@ func MakeDeployment(ctx context.Context, vms *v1alpha1.VSphereSource, adapterIma
 				Spec: corev1.PodSpec{
 					ServiceAccountName: names.ServiceAccount(vms),
 					Containers: []corev1.Container{{
-						Name:  "adapter",
+						Name:  v1alpha1.AdapterContainerName,
 						Image: adapterImage,
-						Env: []corev1.EnvVar{{
-							Name: "NAMESPACE",
-							ValueFrom: &corev1.EnvVarSource{
-								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: "metadata.namespace",
-								},
-							},
-						}, {
-							Name: "NAME",
-							ValueFrom: &corev1.EnvVarSource{
-								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: "metadata.name",
-								},
-							},
-						}, {
-							Name:  "K_METRICS_CONFIG",
-							Value: `{"Domain":"vsphere.vmware.com/source","Component":"source"}`,
-						}, {
-							Name:  "K_LOGGING_CONFIG",
-							Value: "{}",
-						}, {
-							Name:  "VSPHERE_KVSTORE_CONFIGMAP",
-							Value: names.ConfigMap(vms),
-						}},
+						Env:   env,
 					}},
 				},
 			},
 		},
 	}
+	if hash := v1alpha1.GetCredentialsHash(ctx); hash != "" {
+		// The adapter only reads its credentials on startup, so we roll it
+		// when they change.
+		d.Spec.Template.Annotations = map[string]string{
+			v1alpha1.CredentialsHashAnnotationKey: hash,
+		}
+	}
+	if vms.Spec.AdapterTemplate != nil {
+		applyAdapterTemplate(&d.Spec.Template, vms.Spec.AdapterTemplate)
+	}
+	return d
+}
+
+// applyAdapterTemplate merges the source's adapterTemplate into the pod
+// template that we generate.  Validation keeps it from overriding the
+// fields that we set, but where they collide anyway ours win.
+func applyAdapterTemplate(pt *corev1.PodTemplateSpec, overlay *corev1.PodTemplateSpec) {
+	overlay = overlay.DeepCopy()
+
+	pt.Labels = kmeta.UnionMaps(overlay.Labels, pt.Labels)
+	if len(overlay.Annotations) > 0 {
+		pt.Annotations = kmeta.UnionMaps(overlay.Annotations, pt.Annotations)
+	}
+
+	ours := pt.Spec.Containers[0]
+	container := corev1.Container{}
+	if len(overlay.Spec.Containers) > 0 {
+		container = overlay.Spec.Containers[0]
+	}
+	container.Name = ours.Name
+	container.Image = ours.Image
+	container.Command, container.Args = nil, nil
+	container.Env = append(ours.Env, container.Env...)
+
+	spec := overlay.Spec
+	spec.ServiceAccountName = pt.Spec.ServiceAccountName
+	spec.Containers = []corev1.Container{container}
+	pt.Spec = spec
+}
+
+// route is the form in which the adapter receives the source's routes,
+// with their sinks resolved.
+type route struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+	Sink   string `json:"sink"`
+}
+
+// makeRoutes pairs the source's routes with the sinks that the reconciler
+// resolved for them.
+func makeRoutes(vms *v1alpha1.VSphereSource) []route {
+	sinks := make(map[string]string, len(vms.Status.Routes))
+	for _, rs := range vms.Status.Routes {
+		if rs.SinkURI != nil {
+			sinks[rs.Name] = rs.SinkURI.String()
+		}
+	}
+	routes := make([]route, 0, len(vms.Spec.Routes))
+	for _, er := range vms.Spec.Routes {
+		routes = append(routes, route{
+			Name:   er.Name,
+			Filter: er.Filter,
+			Sink:   sinks[er.Name],
+		})
+	}
+	return routes
+}
+
+// DeploymentDrifted returns whether the existing Deployment has drifted from
+// the desired one.  The API server defaults many of the fields that we leave
+// unset, and our bindings add to the pod spec, so we only compare the fields
+// that we set.  The exception is the adapter's environment, which must match
+// exactly (once what our bindings inject is set aside), so that we notice
+// the variables that we no longer set.
+func DeploymentDrifted(desired, existing *appsv1.Deployment) bool {
+	if !equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+		return true
+	}
+	var env []corev1.EnvVar
+	for _, c := range existing.Spec.Template.Spec.Containers {
+		if c.Name != v1alpha1.AdapterContainerName {
+			continue
+		}
+		for _, ev := range c.Env {
+			if !boundEnv(ev.Name) {
+				env = append(env, ev)
+			}
+		}
+	}
+	return !equality.Semantic.DeepEqual(desired.Spec.Template.Spec.Containers[0].Env, env)
+}
+
+// boundEnv returns whether the named environment variable is injected into
+// the adapter by the SinkBinding or VSphereBinding, rather than by us.
+func boundEnv(name string) bool {
+	return name == "K_SINK" || name == "K_CE_OVERRIDES" || strings.HasPrefix(name, "GOVC_")
 }
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+func TestMakeDeploymentRoutes(t *testing.T) {
+	vms := source(broker, "")
+	vms.Spec.Routes = []v1alpha1.EventRoute{{
+		Name:   "security",
+		Filter: `eventType == "UserLoginSessionEvent"`,
+	}}
+	vms.Status.Routes = []v1alpha1.RouteStatus{{
+		Name:    "security",
+		SinkURI: apis.HTTP("security.bar.svc.cluster.local"),
+	}}
+
+	d := MakeDeployment(context.Background(), vms, "adapter")
+	want := `[{"name":"security","filter":"eventType == \"UserLoginSessionEvent\"","sink":"http://security.bar.svc.cluster.local"}]`
+	for _, ev := range d.Spec.Template.Spec.Containers[0].Env {
+		if ev.Name == "VSPHERE_ROUTES" {
+			if ev.Value != want {
+				t.Errorf("VSPHERE_ROUTES = %s, wanted %s", ev.Value, want)
+			}
+			return
+		}
+	}
+	t.Error("VSPHERE_ROUTES is not set")
+}
+
+func TestMakeDeploymentReplySink(t *testing.T) {
+	vms := source(broker, "")
+	vms.Spec.ReplySink = &duckv1.Destination{
+		URI: apis.HTTP("enriched.bar.svc.cluster.local"),
+	}
+	replySinkEnv := func(d *appsv1.Deployment) (string, bool) {
+		for _, ev := range d.Spec.Template.Spec.Containers[0].Env {
+			if ev.Name == "VSPHERE_REPLY_SINK" {
+				return ev.Value, true
+			}
+		}
+		return "", false
+	}
+
+	// Until it's resolved, the adapter doesn't make requests for replies.
+	if got, ok := replySinkEnv(MakeDeployment(context.Background(), vms, "adapter")); ok {
+		t.Errorf("VSPHERE_REPLY_SINK = %s, wanted unset", got)
+	}
+
+	vms.Status.ReplySinkURI = apis.HTTP("enriched.bar.svc.cluster.local")
+	got, ok := replySinkEnv(MakeDeployment(context.Background(), vms, "adapter"))
+	if want := "http://enriched.bar.svc.cluster.local"; !ok || got != want {
+		t.Errorf("VSPHERE_REPLY_SINK = %s, wanted %s", got, want)
+	}
+}
+
+func TestMakeDeploymentScopeAndDelivery(t *testing.T) {
+	vms := source(broker, "")
+	vms.Spec.Scope = "/dc1/vm/prod"
+	vms.Spec.Delivery = &eventingduckv1beta1.DeliverySpec{
+		Retry: ptr.Int32(3),
+	}
+
+	d := MakeDeployment(context.Background(), vms, "adapter")
+	got := map[string]string{}
+	for _, ev := range d.Spec.Template.Spec.Containers[0].Env {
+		got[ev.Name] = ev.Value
+	}
+	for name, want := range map[string]string{
+		"VSPHERE_SCOPE":    "/dc1/vm/prod",
+		"VSPHERE_DELIVERY": `{"retry":3}`,
+	} {
+		if got[name] != want {
+			t.Errorf("%s = %q, wanted %q", name, got[name], want)
+		}
+	}
+}
+
+func TestMakeDeploymentAdapterTemplate(t *testing.T) {
+	vms := source(broker, "")
+	vms.Spec.AdapterTemplate = &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"team": "ops",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject": "false",
+			},
+		},
+		Spec: corev1.PodSpec{
+			PriorityClassName: "high",
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpExists,
+			}},
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("100m"),
+					},
+				},
+				Env: []corev1.EnvVar{{
+					Name:  "HTTPS_PROXY",
+					Value: "http://proxy.local:3128",
+				}},
+			}},
+		},
+	}
+
+	got := MakeDeployment(context.Background(), vms, "adapter-image").Spec.Template
+	want := MakeDeployment(context.Background(), source(broker, ""), "adapter-image").Spec.Template
+	want.Labels["team"] = "ops"
+	want.Annotations = vms.Spec.AdapterTemplate.Annotations
+	want.Spec.PriorityClassName = "high"
+	want.Spec.Tolerations = vms.Spec.AdapterTemplate.Spec.Tolerations
+	want.Spec.Containers[0].Resources = vms.Spec.AdapterTemplate.Spec.Containers[0].Resources
+	want.Spec.Containers[0].Env = append(want.Spec.Containers[0].Env, vms.Spec.AdapterTemplate.Spec.Containers[0].Env...)
+	if !equality.Semantic.DeepEqual(got, want) {
+		t.Errorf("Template (-want, +got) = %s", cmp.Diff(want, got))
+	}
+}
+
+func TestDeploymentDrifted(t *testing.T) {
+	vms := source(broker, `eventType == "VmCreatedEvent"`)
+	desired := MakeDeployment(context.Background(), vms, "adapter-image")
+
+	// What the API server and our bindings make of the desired Deployment.
+	existing := desired.DeepCopy()
+	existing.Spec.RevisionHistoryLimit = ptr.Int32(10)
+	existing.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	existing.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	existing.Spec.Template.Spec.Containers[0].Env = append(existing.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  "K_SINK",
+		Value: "http://default-broker.bar.svc.cluster.local",
+	}, corev1.EnvVar{
+		Name:  "GOVC_URL",
+		Value: "https://vcenter.local",
+	})
+	existing.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name: "vsphere-binding",
+	}}
+	if DeploymentDrifted(desired, existing) {
+		t.Error("DeploymentDrifted() = true, wanted false")
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*v1alpha1.VSphereSource)
+	}{{
+		name: "filter removed",
+		mutate: func(vms *v1alpha1.VSphereSource) {
+			vms.Spec.Filter = ""
+		},
+	}, {
+		name: "filter changed",
+		mutate: func(vms *v1alpha1.VSphereSource) {
+			vms.Spec.Filter = `eventType == "VmRemovedEvent"`
+		},
+	}, {
+		name: "suspended",
+		mutate: func(vms *v1alpha1.VSphereSource) {
+			vms.Spec.Suspend = true
+		},
+	}, {
+		name: "template added",
+		mutate: func(vms *v1alpha1.VSphereSource) {
+			vms.Spec.AdapterTemplate = &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					PriorityClassName: "high",
+				},
+			}
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vms := vms.DeepCopy()
+			test.mutate(vms)
+			if !DeploymentDrifted(MakeDeployment(context.Background(), vms, "adapter-image"), existing) {
+				t.Error("DeploymentDrifted() = false, wanted true")
+			}
+		})
+	}
+}
+
+func TestMakeDeploymentCredentialsHash(t *testing.T) {
+	vms := source(broker, "")
+
+	if d := MakeDeployment(context.Background(), vms, "adapter-image"); d.Spec.Template.Annotations != nil {
+		t.Errorf("Annotations = %v, wanted none", d.Spec.Template.Annotations)
+	}
+
+	ctx := v1alpha1.WithCredentialsHash(context.Background(), "abc")
+	existing := MakeDeployment(ctx, vms, "adapter-image")
+	if got, want := existing.Spec.Template.Annotations[v1alpha1.CredentialsHashAnnotationKey], "abc"; got != want {
+		t.Errorf("Annotations[%s] = %s, wanted %s", v1alpha1.CredentialsHashAnnotationKey, got, want)
+	}
+
+	// A change to the credentials rolls the adapter.
+	ctx = v1alpha1.WithCredentialsHash(context.Background(), "def")
+	if !DeploymentDrifted(MakeDeployment(ctx, vms, "adapter-image"), existing) {
+		t.Error("DeploymentDrifted() = false, wanted true")
+	}
+}
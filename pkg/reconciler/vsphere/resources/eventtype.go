@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources/names"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+)
+
+// EventTypeLabels returns the labels placed on the EventTypes for the source.
+func EventTypeLabels(vms *v1alpha1.VSphereSource) map[string]string {
+	return map[string]string{
+		v1alpha1.VSphereSourceLabelKey: vms.Name,
+	}
+}
+
+// MakeCloudEventAttributes returns the attributes of the CloudEvents that
+// the source may emit, given the vCenter's catalog of event types.
+func MakeCloudEventAttributes(vms *v1alpha1.VSphereSource, catalog []events.CatalogEntry) []duckv1.CloudEventAttributes {
+	var attrs []duckv1.CloudEventAttributes
+	for _, et := range eventTypes(vms, catalog) {
+		attrs = append(attrs, duckv1.CloudEventAttributes{
+			Type:   et.ceType,
+			Source: vms.Spec.Address.String(),
+		})
+	}
+	return attrs
+}
+
+// MakeEventTypes creates the EventTypes for the events that the source may
+// emit, when its sink is a Broker.  It returns nil otherwise.
+func MakeEventTypes(ctx context.Context, vms *v1alpha1.VSphereSource, catalog []events.CatalogEntry) []*eventingv1beta1.EventType {
+	broker, ok := brokerSink(vms)
+	if !ok {
+		return nil
+	}
+
+	var ets []*eventingv1beta1.EventType
+	for _, et := range eventTypes(vms, catalog) {
+		ets = append(ets, &eventingv1beta1.EventType{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            names.EventType(vms, et.key),
+				Namespace:       vms.Namespace,
+				Labels:          EventTypeLabels(vms),
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
+			},
+			Spec: eventingv1beta1.EventTypeSpec{
+				Type:        et.ceType,
+				Source:      vms.Spec.Address.DeepCopy(),
+				Broker:      broker,
+				Description: et.description,
+			},
+		})
+	}
+	return ets
+}
+
+// brokerSink returns the name of the Broker that the source sends events
+// to, if any.  EventTypes may only reference Brokers in their namespace.
+func brokerSink(vms *v1alpha1.VSphereSource) (string, bool) {
+	ref := vms.Spec.Sink.Ref
+	if ref == nil || ref.Kind != "Broker" {
+		return "", false
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || gv.Group != eventingv1beta1.SchemeGroupVersion.Group {
+		return "", false
+	}
+	if ref.Namespace != "" && ref.Namespace != vms.Namespace {
+		return "", false
+	}
+	return ref.Name, true
+}
+
+type eventType struct {
+	// key is the vSphere event type name.
+	key         string
+	ceType      string
+	description string
+}
+
+// eventTypes returns the types of events that the source may emit,
+// restricted by the source's filter expression.  These are the concrete
+// event types known to govmomi: those that no other event type embeds, and
+// those that vCenter's catalog describes (some of which are embedded by
+// others, like VmMigratedEvent).  The EventEx eventTypeIds in the catalog
+// all share the EventEx type, and are told apart by the eventtypeid
+// extension rather than listed separately.
+func eventTypes(vms *v1alpha1.VSphereSource, catalog []events.CatalogEntry) []eventType {
+	allowed := func(string) bool { return true }
+	if vms.Spec.Filter != "" {
+		if f, err := expr.NewFilter(vms.Spec.Filter); err == nil {
+			if names, ok := f.EventTypes(); ok {
+				set := make(map[string]struct{}, len(names))
+				for _, name := range names {
+					set[name] = struct{}{}
+				}
+				allowed = func(name string) bool {
+					_, ok := set[name]
+					return ok
+				}
+			}
+		}
+	}
+
+	descriptions := make(map[string]string, len(catalog))
+	for _, entry := range catalog {
+		descriptions[entry.Key] = entry.Description
+	}
+	concrete := make(map[string]struct{})
+	for _, name := range events.LeafTypeNames() {
+		concrete[name] = struct{}{}
+	}
+	for _, entry := range catalog {
+		concrete[entry.Key] = struct{}{}
+	}
+
+	var ets []eventType
+	for _, name := range events.TypeNames() {
+		if _, ok := concrete[name]; !ok || !allowed(name) {
+			continue
+		}
+		ets = append(ets, eventType{
+			key:         name,
+			ceType:      events.TypePrefix + name,
+			description: descriptions[name],
+		})
+	}
+	return ets
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+)
+
+var catalog = []events.CatalogEntry{{
+	Key:         "VmCreatedEvent",
+	Description: "VM created",
+}, {
+	Key:         "com.vmware.vc.HA.ClusterFailoverInProgressEvent",
+	Description: "vSphere HA failover operation in progress",
+}}
+
+func source(sink duckv1.Destination, filter string) *v1alpha1.VSphereSource {
+	return &v1alpha1.VSphereSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.VSphereSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: sink,
+			},
+			VAuthSpec: v1alpha1.VAuthSpec{
+				Address: apis.URL{
+					Scheme: "https",
+					Host:   "vcenter.local",
+				},
+			},
+			Filter: filter,
+		},
+	}
+}
+
+var broker = duckv1.Destination{
+	Ref: &duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1beta1",
+		Kind:       "Broker",
+		Name:       "default",
+	},
+}
+
+func TestMakeEventTypes(t *testing.T) {
+	vms := source(broker, `eventType in ["VmCreatedEvent", "VmRemovedEvent"]`)
+
+	ets := MakeEventTypes(context.Background(), vms, catalog)
+	type summary struct {
+		Name, Type, Broker, Description string
+	}
+	var got []summary
+	for _, et := range ets {
+		if et.Spec.Source.String() != "https://vcenter.local" {
+			t.Errorf("Source = %s, wanted https://vcenter.local", et.Spec.Source)
+		}
+		got = append(got, summary{et.Name, et.Spec.Type, et.Spec.Broker, et.Spec.Description})
+	}
+	want := []summary{{
+		Name:        "foo-vmcreatedevent",
+		Type:        "com.vmware.vsphere.VmCreatedEvent",
+		Broker:      "default",
+		Description: "VM created",
+	}, {
+		Name:   "foo-vmremovedevent",
+		Type:   "com.vmware.vsphere.VmRemovedEvent",
+		Broker: "default",
+	}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("MakeEventTypes (-want, +got) = %s", cmp.Diff(want, got))
+	}
+
+	attrs := MakeCloudEventAttributes(vms, catalog)
+	wantAttrs := []duckv1.CloudEventAttributes{{
+		Type:   "com.vmware.vsphere.VmCreatedEvent",
+		Source: "https://vcenter.local",
+	}, {
+		Type:   "com.vmware.vsphere.VmRemovedEvent",
+		Source: "https://vcenter.local",
+	}}
+	if !cmp.Equal(attrs, wantAttrs) {
+		t.Errorf("MakeCloudEventAttributes (-want, +got) = %s", cmp.Diff(wantAttrs, attrs))
+	}
+}
+
+func TestMakeEventTypesEventEx(t *testing.T) {
+	vms := source(broker, `eventType == "EventEx"`)
+
+	// The eventTypeIds in the catalog all share the one EventEx type.
+	ets := MakeEventTypes(context.Background(), vms, catalog)
+	if got, want := len(ets), 1; got != want {
+		t.Fatalf("len(MakeEventTypes) = %d, wanted %d", got, want)
+	}
+	if got, want := ets[0].Name, "foo-eventex"; got != want {
+		t.Errorf("Name = %s, wanted %s", got, want)
+	}
+	if got, want := ets[0].Spec.Type, "com.vmware.vsphere.EventEx"; got != want {
+		t.Errorf("Type = %s, wanted %s", got, want)
+	}
+	if got, want := len(MakeCloudEventAttributes(vms, catalog)), 1; got != want {
+		t.Errorf("len(MakeCloudEventAttributes) = %d, wanted %d", got, want)
+	}
+}
+
+func TestMakeEventTypesUnfiltered(t *testing.T) {
+	vms := source(broker, "")
+
+	// Without a filter, we list the leaf event types, and not the abstract
+	// base types that they embed, nor the eventTypeIds in the catalog.
+	ets := MakeEventTypes(context.Background(), vms, catalog)
+	if got, want := len(ets), len(events.LeafTypeNames()); got != want {
+		t.Errorf("len(MakeEventTypes) = %d, wanted %d", got, want)
+	}
+	if got, max := len(ets), 400; got > max {
+		t.Errorf("len(MakeEventTypes) = %d, wanted at most %d", got, max)
+	}
+	types := make(map[string]struct{}, len(ets))
+	for _, et := range ets {
+		switch et.Spec.Type {
+		case "com.vmware.vsphere.Event", "com.vmware.vsphere.VmEvent", "com.vmware.vsphere.HostEvent":
+			t.Errorf("Type = %s, wanted a concrete event type", et.Spec.Type)
+		}
+		if _, ok := types[et.Spec.Type]; ok {
+			t.Errorf("Type %s is listed more than once", et.Spec.Type)
+		}
+		types[et.Spec.Type] = struct{}{}
+	}
+	if got, want := len(MakeCloudEventAttributes(vms, catalog)), len(ets); got != want {
+		t.Errorf("len(MakeCloudEventAttributes) = %d, wanted %d", got, want)
+	}
+
+	// Event types that others embed are listed when the catalog describes
+	// them, since vCenter emits those too.
+	withMigrated := append(catalog, events.CatalogEntry{Key: "VmMigratedEvent"})
+	if got, want := len(MakeEventTypes(context.Background(), vms, withMigrated)), len(ets)+1; got != want {
+		t.Errorf("len(MakeEventTypes) = %d, wanted %d", got, want)
+	}
+}
+
+func TestMakeEventTypesNoBroker(t *testing.T) {
+	for name, sink := range map[string]duckv1.Destination{
+		"uri": {
+			URI: &apis.URL{Scheme: "http", Host: "example.com"},
+		},
+		"service": {
+			Ref: &duckv1.KReference{
+				APIVersion: "serving.knative.dev/v1",
+				Kind:       "Service",
+				Name:       "default",
+			},
+		},
+		"broker in another namespace": {
+			Ref: &duckv1.KReference{
+				APIVersion: "eventing.knative.dev/v1alpha1",
+				Kind:       "Broker",
+				Namespace:  "baz",
+				Name:       "default",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			vms := source(sink, "")
+			if ets := MakeEventTypes(context.Background(), vms, catalog); len(ets) != 0 {
+				t.Errorf("MakeEventTypes() = %v, wanted none", ets)
+			}
+			// We still report the attributes of the events we emit.
+			if got, want := len(MakeCloudEventAttributes(vms, catalog)), len(events.LeafTypeNames()); got != want {
+				t.Errorf("len(MakeCloudEventAttributes) = %d, wanted %d", got, want)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package names
 
 import (
+	"strings"
+	"unicode"
+
 	"knative.dev/pkg/kmeta"
 
 	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
@@ -30,6 +33,18 @@ func SinkBinding(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-sinkbinding")
 }
 
+// RouteSinkBinding returns the name of the SinkBinding resolving the sink
+// of the named route.
+func RouteSinkBinding(vms *v1alpha1.VSphereSource, route string) string {
+	return kmeta.ChildName(vms.Name, "-"+route+"-sinkbinding")
+}
+
+// ReplySinkBinding returns the name of the SinkBinding resolving the
+// source's replySink.
+func ReplySinkBinding(vms *v1alpha1.VSphereSource) string {
+	return kmeta.ChildName(vms.Name, "-replysinkbinding")
+}
+
 func VSphereBinding(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-vspherebinding")
 }
@@ -38,6 +53,10 @@ func ConfigMap(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-configmap")
 }
 
+func Role(vms *v1alpha1.VSphereSource) string {
+	return kmeta.ChildName(vms.Name, "-role")
+}
+
 func RoleBinding(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-rolebinding")
 }
@@ -45,3 +64,19 @@ func RoleBinding(vms *v1alpha1.VSphereSource) string {
 func ServiceAccount(vms *v1alpha1.VSphereSource) string {
 	return kmeta.ChildName(vms.Name, "-serviceaccount")
 }
+
+// EventType returns the name of the EventType for the given vSphere event
+// type name or eventTypeId.
+func EventType(vms *v1alpha1.VSphereSource, key string) string {
+	suffix := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', '0' <= r && r <= '9':
+			return r
+		case 'A' <= r && r <= 'Z':
+			return unicode.ToLower(r)
+		default:
+			return '-'
+		}
+	}, key)
+	return kmeta.ChildName(vms.Name, "-"+suffix)
+}
@@ -111,6 +111,15 @@ func TestNames(t *testing.T) {
 		},
 		f:    RoleBinding,
 		want: "baz-rolebinding",
+	}, {
+		name: "role",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    Role,
+		want: "baz-role",
 	}, {
 		name: "serviceaccount",
 		vss: &v1alpha1.VSphereSource{
@@ -120,6 +129,48 @@ func TestNames(t *testing.T) {
 		},
 		f:    ServiceAccount,
 		want: "baz-serviceaccount",
+	}, {
+		name: "eventtype",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f: func(vss *v1alpha1.VSphereSource) string {
+			return EventType(vss, "VmCreatedEvent")
+		},
+		want: "baz-vmcreatedevent",
+	}, {
+		name: "eventtype for EventEx",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f: func(vss *v1alpha1.VSphereSource) string {
+			return EventType(vss, "com.vmware.vc.HA.ClusterFailoverInProgressEvent")
+		},
+		want: "baz-com-vmware-vc-ha-clusterfailoverinprogressevent",
+	}, {
+		name: "route sinkbinding",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f: func(vss *v1alpha1.VSphereSource) string {
+			return RouteSinkBinding(vss, "security")
+		},
+		want: "baz-security-sinkbinding",
+	}, {
+		name: "reply sinkbinding",
+		vss: &v1alpha1.VSphereSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    ReplySinkBinding,
+		want: "baz-replysinkbinding",
 	}}
 
 	for _, test := range tests {
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources/names"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// MakeRole creates a Role granting the receive adapter access to just the
+// ConfigMap in which it stores its state (see MakeConfigMap), which we
+// create before the adapter starts.  The adapter doesn't use leader
+// election, so it needs no Lease.
+func MakeRole(ctx context.Context, vms *v1alpha1.VSphereSource) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
+			Name:            names.Role(vms),
+			Namespace:       vms.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"configmaps"},
+			ResourceNames: []string{names.ConfigMap(vms)},
+			// The adapter checkpoints its progress with update, and reports
+			// its status back to the controller with patch.
+			Verbs: []string{"get", "update", "patch"},
+		}},
+	}
+}
@@ -28,7 +28,7 @@ import (
 
 // MakeRoleBinding creates a RoleBinding object for the receive adapter
 // service account 'sa' in the Namespace 'ns'. This is necessary for
-// the receive adapter to be able to store state in configmaps.
+// the receive adapter to be able to store state in its configmap.
 func MakeRoleBinding(ctx context.Context, vms *v1alpha1.VSphereSource) *rbacv1.RoleBinding {
 	return &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
@@ -38,8 +38,8 @@ func MakeRoleBinding(ctx context.Context, vms *v1alpha1.VSphereSource) *rbacv1.R
 		},
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
-			Name:     "receive-adapter-cm",
+			Kind:     "Role",
+			Name:     names.Role(vms),
 		},
 		Subjects: []rbacv1.Subject{{
 			Kind:      "ServiceAccount",
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestMakeRole(t *testing.T) {
+	vms := source(broker, "")
+
+	role := MakeRole(context.Background(), vms)
+	want := []rbacv1.PolicyRule{{
+		APIGroups:     []string{""},
+		Resources:     []string{"configmaps"},
+		ResourceNames: []string{"foo-configmap"},
+		Verbs:         []string{"get", "update", "patch"},
+	}}
+	if !cmp.Equal(role.Rules, want) {
+		t.Errorf("Rules (-want, +got) = %s", cmp.Diff(want, role.Rules))
+	}
+
+	rb := MakeRoleBinding(context.Background(), vms)
+	if got, want := rb.RoleRef, (rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     role.Name,
+	}); got != want {
+		t.Errorf("RoleRef = %v, wanted %v", got, want)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/tracker"
@@ -51,3 +52,57 @@ func MakeSinkBinding(ctx context.Context, vms *v1alpha1.VSphereSource) *sourcesv
 		},
 	}
 }
+
+// RouteSinkBindingLabels returns the labels placed on the SinkBindings that
+// resolve the sinks of the source's routes.
+func RouteSinkBindingLabels(vms *v1alpha1.VSphereSource) map[string]string {
+	return map[string]string{
+		v1alpha1.VSphereSourceLabelKey:      vms.Name,
+		v1alpha1.VSphereSourceRouteLabelKey: "true",
+	}
+}
+
+// MakeReplySinkBinding creates a SinkBinding that resolves the source's
+// replySink, which like the sinks of routes is handed to the adapter
+// through its Deployment.  It carries the labels of the routes' SinkBindings
+// so that it is cleaned up with them.
+func MakeReplySinkBinding(ctx context.Context, vms *v1alpha1.VSphereSource) *sourcesv1alpha1.SinkBinding {
+	return makeUnboundSinkBinding(vms, names.ReplySinkBinding(vms), *vms.Spec.ReplySink)
+}
+
+// MakeRouteSinkBinding creates a SinkBinding that resolves the sink of the
+// given route.  Its subject matches nothing, since the adapter receives the
+// resolved sinks of its routes through its Deployment instead.
+func MakeRouteSinkBinding(ctx context.Context, vms *v1alpha1.VSphereSource, route v1alpha1.EventRoute) *sourcesv1alpha1.SinkBinding {
+	return makeUnboundSinkBinding(vms, names.RouteSinkBinding(vms, route.Name), route.Sink)
+}
+
+// makeUnboundSinkBinding creates a SinkBinding that resolves the given sink
+// for the source without binding it to anything.
+func makeUnboundSinkBinding(vms *v1alpha1.VSphereSource, name string, sink duckv1.Destination) *sourcesv1alpha1.SinkBinding {
+	return &sourcesv1alpha1.SinkBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       vms.Namespace,
+			Labels:          RouteSinkBindingLabels(vms),
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(vms)},
+		},
+		Spec: sourcesv1alpha1.SinkBindingSpec{
+			SourceSpec: duckv1.SourceSpec{
+				Sink: sink,
+			},
+			BindingSpec: duckv1alpha1.BindingSpec{
+				Subject: tracker.Reference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Namespace:  vms.Namespace,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							v1alpha1.VSphereSourceRouteSubjectLabelKey: name,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+func TestMakeRouteSinkBinding(t *testing.T) {
+	vms := source(broker, "")
+	sb := MakeRouteSinkBinding(context.Background(), vms, v1alpha1.EventRoute{
+		Name:   "alerts",
+		Filter: "true",
+		Sink: duckv1.Destination{
+			URI: &apis.URL{Scheme: "http", Host: "example.com"},
+		},
+	})
+
+	if got, want := sb.Labels[v1alpha1.VSphereSourceRouteLabelKey], "true"; got != want {
+		t.Errorf("Labels[%q] = %q, wanted %q", v1alpha1.VSphereSourceRouteLabelKey, got, want)
+	}
+
+	// The subject selects nothing that we label, including the SinkBindings
+	// of routes themselves, and doesn't overload the label marking them.
+	if _, ok := sb.Spec.Subject.Selector.MatchLabels[v1alpha1.VSphereSourceRouteLabelKey]; ok {
+		t.Errorf("Subject selects on %q", v1alpha1.VSphereSourceRouteLabelKey)
+	}
+	sel, err := metav1.LabelSelectorAsSelector(sb.Spec.Subject.Selector)
+	if err != nil {
+		t.Fatalf("LabelSelectorAsSelector() = %v", err)
+	}
+	for name, ls := range map[string]map[string]string{
+		"route sinkbinding": sb.Labels,
+		"deployment":        MakeDeployment(context.Background(), vms, "").Spec.Template.Labels,
+	} {
+		if sel.Matches(labels.Set(ls)) {
+			t.Errorf("Subject selects the %s labels %v", name, ls)
+		}
+	}
+}
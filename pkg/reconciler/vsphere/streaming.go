@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+const (
+	// missedHeartbeats is how many heartbeats the adapter may miss before
+	// we consider it to have stopped streaming events.
+	missedHeartbeats = 4
+
+	// defaultHeartbeatInterval is the adapter's default, which we assume
+	// when it doesn't report its interval.
+	defaultHeartbeatInterval = 30 * time.Second
+)
+
+// heartbeatTimeout is how long we wait for the heartbeat after the given
+// progress before we consider the adapter to have stopped streaming.
+func heartbeatTimeout(progress vsphere.Progress) time.Duration {
+	interval := progress.HeartbeatInterval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	return missedHeartbeats * interval
+}
+
+// progressChanged returns whether the adapter's progress changed in a way
+// that the VSphereSource reflects, so that we needn't reconcile it for
+// every heartbeat; propagateStreamingStatus checks back on the heartbeat
+// before it would time out.  A heartbeat after the previous one timed out
+// is a change, since the adapter is streaming again.
+func progressChanged(oldRaw, newRaw string) bool {
+	if oldRaw == newRaw {
+		return false
+	}
+	oldProgress, newProgress := vsphere.Progress{}, vsphere.Progress{}
+	if json.Unmarshal([]byte(oldRaw), &oldProgress) != nil || json.Unmarshal([]byte(newRaw), &newProgress) != nil {
+		return true
+	}
+	if newProgress.HeartbeatTime.Sub(oldProgress.HeartbeatTime) > heartbeatTimeout(oldProgress) {
+		return true
+	}
+	oldProgress.HeartbeatTime, newProgress.HeartbeatTime = time.Time{}, time.Time{}
+	return !reflect.DeepEqual(oldProgress, newProgress)
+}
+
+// propagateStreamingStatus reflects the progress that the adapter reports
+// in its ConfigMap in the VSphereSource.  It returns how long until the
+// most recent heartbeat times out, when the adapter is streaming, so that
+// we can check back on it then.
+func propagateStreamingStatus(vms *sourcesv1alpha1.VSphereSource, cm *corev1.ConfigMap, now time.Time) time.Duration {
+	if vms.Spec.Suspend {
+		// We don't expect heartbeats from a suspended source, but we keep
+		// its progress around.
+		vms.Status.ClearStreamingStatus()
+		return 0
+	}
+
+	raw, ok := cm.Data[vsphere.ProgressKey]
+	if !ok {
+		vms.Status.MarkStreamingUnknown("AwaitingHeartbeat", "The adapter has not reported its progress yet.")
+		return 0
+	}
+	progress := vsphere.Progress{}
+	if err := json.Unmarshal([]byte(raw), &progress); err != nil {
+		vms.Status.MarkNotStreaming("InvalidProgress", "Unable to decode the adapter's progress: %v", err)
+		return 0
+	}
+
+	ss := &sourcesv1alpha1.StreamingStatus{
+		LastEventKey:        progress.LastEventKey,
+		EventsSent:          progress.EventsSent,
+		VCenterVersion:      progress.VCenterVersion,
+		VCenterInstanceUUID: progress.InstanceUUID,
+		LastHeartbeatTime:   metav1.NewTime(progress.HeartbeatTime),
+	}
+	if progress.LastEventTime != nil {
+		t := metav1.NewTime(*progress.LastEventTime)
+		ss.LastEventTime = &t
+	}
+	vms.Status.Streaming = ss
+
+	timeout := heartbeatTimeout(progress)
+	age := now.Sub(progress.HeartbeatTime)
+	if age > timeout {
+		vms.Status.MarkNotStreaming("HeartbeatTimeout", "The adapter last reported its progress at %s.",
+			progress.HeartbeatTime.Format(time.RFC3339))
+		return 0
+	}
+	vms.Status.MarkStreaming()
+	return timeout - age
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+func TestPropagateStreamingStatus(t *testing.T) {
+	now := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	progress := `{"lastEventKey":42,"lastEventTime":"2020-04-01T11:58:00Z","eventsSent":7,` +
+		`"vcenterVersion":"7.0.0","instanceUUID":"abc","heartbeatTime":"2020-04-01T11:59:30Z"}`
+	// The same progress, from an adapter that reports every 10s.
+	frequent := `{"lastEventKey":42,"lastEventTime":"2020-04-01T11:58:00Z","eventsSent":7,` +
+		`"vcenterVersion":"7.0.0","instanceUUID":"abc","heartbeatTime":"2020-04-01T11:59:30Z",` +
+		`"heartbeatInterval":10000000000}`
+
+	tests := []struct {
+		name    string
+		suspend bool
+		data    map[string]string
+		now     time.Time
+		want    corev1.ConditionStatus
+		reason  string
+		requeue time.Duration
+	}{{
+		name:   "no heartbeat yet",
+		want:   corev1.ConditionUnknown,
+		reason: "AwaitingHeartbeat",
+		now:    now,
+	}, {
+		name:   "garbage",
+		data:   map[string]string{vsphere.ProgressKey: "{"},
+		want:   corev1.ConditionFalse,
+		reason: "InvalidProgress",
+		now:    now,
+	}, {
+		name:    "recent heartbeat",
+		data:    map[string]string{vsphere.ProgressKey: progress},
+		want:    corev1.ConditionTrue,
+		now:     now,
+		requeue: 2*time.Minute - 30*time.Second,
+	}, {
+		name:   "stale heartbeat",
+		data:   map[string]string{vsphere.ProgressKey: progress},
+		want:   corev1.ConditionFalse,
+		reason: "HeartbeatTimeout",
+		now:    now.Add(2 * time.Minute),
+	}, {
+		name:    "recent heartbeat, reported interval",
+		data:    map[string]string{vsphere.ProgressKey: frequent},
+		want:    corev1.ConditionTrue,
+		now:     now,
+		requeue: 40*time.Second - 30*time.Second,
+	}, {
+		name:   "stale heartbeat, reported interval",
+		data:   map[string]string{vsphere.ProgressKey: frequent},
+		want:   corev1.ConditionFalse,
+		reason: "HeartbeatTimeout",
+		now:    now.Add(time.Minute),
+	}, {
+		name:    "suspended",
+		suspend: true,
+		data:    map[string]string{vsphere.ProgressKey: progress},
+		now:     now.Add(2 * time.Minute),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vms := &sourcesv1alpha1.VSphereSource{}
+			vms.Spec.Suspend = test.suspend
+			vms.Status.InitializeConditions()
+			cm := &corev1.ConfigMap{Data: test.data}
+
+			if got := propagateStreamingStatus(vms, cm, test.now); got != test.requeue {
+				t.Errorf("propagateStreamingStatus() = %v, wanted %v", got, test.requeue)
+			}
+			cond := vms.Status.GetCondition(sourcesv1alpha1.VSphereSourceConditionStreaming)
+			if test.want == "" {
+				if cond != nil {
+					t.Errorf("Streaming = %v, wanted nil", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != test.want || cond.Reason != test.reason {
+				t.Errorf("Streaming = %v, wanted %s with reason %q", cond, test.want, test.reason)
+			}
+		})
+	}
+
+	vms := &sourcesv1alpha1.VSphereSource{}
+	propagateStreamingStatus(vms, &corev1.ConfigMap{Data: map[string]string{vsphere.ProgressKey: progress}}, now)
+	ss := vms.Status.Streaming
+	if ss == nil || ss.LastEventKey != 42 || ss.EventsSent != 7 || ss.VCenterVersion != "7.0.0" ||
+		ss.VCenterInstanceUUID != "abc" || ss.LastEventTime == nil {
+		t.Errorf("Streaming = %#v, wanted the reported progress", ss)
+	}
+}
+
+func TestProgressChanged(t *testing.T) {
+	const progress = `{"lastEventKey":42,"eventsSent":7,"vcenterVersion":"7.0.0","instanceUUID":"abc",` +
+		`"heartbeatTime":"2020-04-01T12:00:00Z","heartbeatInterval":30000000000}`
+
+	tests := []struct {
+		name string
+		new  string
+		want bool
+	}{{
+		name: "unchanged",
+		new:  progress,
+	}, {
+		name: "heartbeat",
+		new: `{"lastEventKey":42,"eventsSent":7,"vcenterVersion":"7.0.0","instanceUUID":"abc",` +
+			`"heartbeatTime":"2020-04-01T12:00:30Z","heartbeatInterval":30000000000}`,
+	}, {
+		name: "heartbeat after a timeout",
+		new: `{"lastEventKey":42,"eventsSent":7,"vcenterVersion":"7.0.0","instanceUUID":"abc",` +
+			`"heartbeatTime":"2020-04-01T12:05:00Z","heartbeatInterval":30000000000}`,
+		want: true,
+	}, {
+		name: "new event",
+		new: `{"lastEventKey":43,"eventsSent":8,"vcenterVersion":"7.0.0","instanceUUID":"abc",` +
+			`"heartbeatTime":"2020-04-01T12:00:30Z","heartbeatInterval":30000000000}`,
+		want: true,
+	}, {
+		name: "new vCenter",
+		new: `{"lastEventKey":42,"eventsSent":7,"vcenterVersion":"7.0.1","instanceUUID":"def",` +
+			`"heartbeatTime":"2020-04-01T12:00:30Z","heartbeatInterval":30000000000}`,
+		want: true,
+	}, {
+		name: "garbage",
+		new:  "{",
+		want: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := progressChanged(progress, test.new); got != test.want {
+				t.Errorf("progressChanged() = %v, wanted %v", got, test.want)
+			}
+		})
+	}
+}
@@ -18,7 +18,11 @@ package vsphere
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
 	clientset "github.com/mattmoor/vmware-sources/pkg/client/clientset/versioned"
@@ -26,15 +30,27 @@ import (
 	v1alpha1lister "github.com/mattmoor/vmware-sources/pkg/client/listers/sources/v1alpha1"
 	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources"
 	resourcenames "github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources/names"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	eventingsourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
 	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventingv1beta1listers "knative.dev/eventing/pkg/client/listers/eventing/v1beta1"
 	sourcesv1alpha1lister "knative.dev/eventing/pkg/client/listers/sources/v1alpha1"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 )
 
 // Reconciler implements vspherereconciler.Interface for
@@ -49,9 +65,15 @@ type Reconciler struct {
 	deploymentLister     appsv1listers.DeploymentLister
 	vspherebindingLister v1alpha1lister.VSphereBindingLister
 	sinkbindingLister    sourcesv1alpha1lister.SinkBindingLister
+	roleLister           rbacv1listers.RoleLister
 	rbacLister           rbacv1listers.RoleBindingLister
 	cmLister             corev1Listers.ConfigMapLister
 	saLister             corev1Listers.ServiceAccountLister
+	eventtypeLister      eventingv1beta1listers.EventTypeLister
+	secretLister         corev1Listers.SecretLister
+
+	tracker      tracker.Interface
+	enqueueAfter func(interface{}, time.Duration)
 }
 
 // Check that our Reconciler implements Interface
@@ -61,12 +83,21 @@ var _ vspherereconciler.Interface = (*Reconciler)(nil)
 func (r *Reconciler) ReconcileKind(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) reconciler.Event {
 	vms.Status.InitializeConditions()
 
+	if ok, err := r.resolveConnection(ctx, vms); err != nil {
+		return err
+	} else if !ok {
+		// We are requeued when the binding shows up.
+		return nil
+	}
 	if err := r.reconcileSinkBinding(ctx, vms); err != nil {
 		return err
 	}
 	if err := r.reconcileVSphereBinding(ctx, vms); err != nil {
 		return err
 	}
+	if err := r.checkSecret(vms); err != nil {
+		return err
+	}
 
 	// Make sure the ConfigMap for storing state exists before we
 	// create the deployment so that it gets created as owned
@@ -74,20 +105,70 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, vms *sourcesv1alpha1.VSp
 	if err := r.reconcileConfigMap(ctx, vms); err != nil {
 		return err
 	}
+	if err := r.reconcileEventTypes(ctx, vms); err != nil {
+		return err
+	}
 	if err := r.reconcileServiceAccount(ctx, vms); err != nil {
 		return err
 	}
+	if err := r.reconcileRole(ctx, vms); err != nil {
+		return err
+	}
 	if err := r.reconcileRoleBinding(ctx, vms); err != nil {
 		return err
 	}
+	if err := r.reconcileRoutes(ctx, vms); err != nil {
+		return err
+	}
+	if !vms.Status.GetCondition(sourcesv1alpha1.VSphereSourceConditionRoutesReady).IsTrue() && !vms.Spec.Suspend {
+		// Hold off on (re)configuring the adapter until we know where to send
+		// the events of each route, rather than have them go to the default
+		// sink.  We are requeued when the route's SinkBinding changes.  There
+		// is no need to wait to suspend the adapter though.
+		return nil
+	}
 	if err := r.reconcileDeployment(ctx, vms); err != nil {
 		return err
 	}
+	if vms.Spec.Suspend {
+		vms.Status.MarkSuspended()
+	} else {
+		vms.Status.MarkResumed()
+	}
 
 	vms.Status.ObservedGeneration = vms.Generation
 	return nil
 }
 
+// resolveConnection fills in the connection settings of a source with a
+// connectionRef from the VSphereBinding that it references.  This only
+// changes our copy of the source's spec, which isn't written back.  It
+// returns false when the binding doesn't exist.
+func (r *Reconciler) resolveConnection(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) (bool, error) {
+	if vms.Spec.ConnectionRef == nil {
+		return true, nil
+	}
+	name := vms.Spec.ConnectionRef.Name
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: sourcesv1alpha1.SchemeGroupVersion.String(),
+		Kind:       "VSphereBinding",
+		Namespace:  vms.Namespace,
+		Name:       name,
+	}, vms); err != nil {
+		return false, fmt.Errorf("failed to track vspherebinding %q: %w", name, err)
+	}
+
+	vsb, err := r.vspherebindingLister.VSphereBindings(vms.Namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		vms.Status.MarkAuthNotReady("ConnectionNotFound", "VSphereBinding %q does not exist.", name)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get vspherebinding %q: %w", name, err)
+	}
+	vms.Spec.VAuthSpec = vsb.Spec.VAuthSpec
+	return true, nil
+}
+
 func (r *Reconciler) reconcileSinkBinding(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	sinkbindingName := resourcenames.SinkBinding(vms)
@@ -102,15 +183,18 @@ func (r *Reconciler) reconcileSinkBinding(ctx context.Context, vms *sourcesv1alp
 		logging.FromContext(ctx).Infof("Created sinkbinding %q", sinkbindingName)
 	} else if err != nil {
 		return fmt.Errorf("failed to get sinkbinding %q: %w", sinkbindingName, err)
-	} else {
-		// The sinkbinding exists, but make sure that it has the shape that we expect.
-		desiredSinkBinding := resources.MakeSinkBinding(ctx, vms)
+	} else if !metav1.IsControlledBy(sinkbinding, vms) {
+		vms.Status.MarkResourceNotOwned("SinkBinding", sinkbindingName)
+		return fmt.Errorf("vspheresource %q does not own sinkbinding %q", vms.Name, sinkbindingName)
+	} else if desired := resources.MakeSinkBinding(ctx, vms); !equality.Semantic.DeepEqual(sinkbinding.Spec, desired.Spec) {
+		// The sinkbinding exists, but it has drifted from the shape that we expect.
 		sinkbinding = sinkbinding.DeepCopy()
-		sinkbinding.Spec = desiredSinkBinding.Spec
+		sinkbinding.Spec = desired.Spec
 		sinkbinding, err = r.eventingclient.SourcesV1alpha1().SinkBindings(ns).Update(sinkbinding)
 		if err != nil {
-			return fmt.Errorf("failed to create sinkbinding %q: %w", sinkbindingName, err)
+			return fmt.Errorf("failed to update sinkbinding %q: %w", sinkbindingName, err)
 		}
+		logging.FromContext(ctx).Infof("Updated sinkbinding %q", sinkbindingName)
 	}
 
 	// Reflect the state of the SinkBinding in the VSphereSource
@@ -133,15 +217,18 @@ func (r *Reconciler) reconcileVSphereBinding(ctx context.Context, vms *sourcesv1
 		logging.FromContext(ctx).Infof("Created vspherebinding %q", vspherebindingName)
 	} else if err != nil {
 		return fmt.Errorf("failed to get vspherebinding %q: %w", vspherebindingName, err)
-	} else {
-		// The vspherebinding exists, but make sure that it has the shape that we expect.
-		desiredVSphereBinding := resources.MakeVSphereBinding(ctx, vms)
+	} else if !metav1.IsControlledBy(vspherebinding, vms) {
+		vms.Status.MarkResourceNotOwned("VSphereBinding", vspherebindingName)
+		return fmt.Errorf("vspheresource %q does not own vspherebinding %q", vms.Name, vspherebindingName)
+	} else if desired := resources.MakeVSphereBinding(ctx, vms); !equality.Semantic.DeepEqual(vspherebinding.Spec, desired.Spec) {
+		// The vspherebinding exists, but it has drifted from the shape that we expect.
 		vspherebinding = vspherebinding.DeepCopy()
-		vspherebinding.Spec = desiredVSphereBinding.Spec
+		vspherebinding.Spec = desired.Spec
 		vspherebinding, err = r.client.SourcesV1alpha1().VSphereBindings(ns).Update(vspherebinding)
 		if err != nil {
-			return fmt.Errorf("failed to create vspherebinding %q: %w", vspherebindingName, err)
+			return fmt.Errorf("failed to update vspherebinding %q: %w", vspherebindingName, err)
 		}
+		logging.FromContext(ctx).Infof("Updated vspherebinding %q", vspherebindingName)
 	}
 
 	// Reflect the state of the VSphereBinding in the VSphereSource
@@ -166,11 +253,119 @@ func (r *Reconciler) reconcileConfigMap(ctx context.Context, vms *sourcesv1alpha
 		logging.FromContext(ctx).Infof("Created configmap %q", name)
 	} else if err != nil {
 		return fmt.Errorf("failed to get configmap %q: %w", name, err)
+	} else if !metav1.IsControlledBy(cm, vms) {
+		vms.Status.MarkResourceNotOwned("ConfigMap", name)
+		return fmt.Errorf("vspheresource %q does not own configmap %q", vms.Name, name)
+	}
+	// The data of the configmap belongs to the adapter, so there is nothing
+	// else for us to keep in shape.  The exception is that we drop the
+	// adapter's checkpoint when resuming a source that skips the events that
+	// happened while it was suspended, so that it starts from the present.
+	if vms.Status.IsSuspended() && !vms.Spec.Suspend && vms.Spec.SkipEventsWhileSuspended {
+		if _, ok := cm.Data[vsphere.CheckpointKey]; ok {
+			cm = cm.DeepCopy()
+			delete(cm.Data, vsphere.CheckpointKey)
+			cm, err = r.kubeclient.CoreV1().ConfigMaps(ns).Update(cm)
+			if err != nil {
+				return fmt.Errorf("failed to drop the checkpoint from configmap %q: %w", name, err)
+			}
+			logging.FromContext(ctx).Infof("Dropped the checkpoint from configmap %q", name)
+		}
+	}
+
+	// Reflect the state of the source's expressions (including the filters
+	// of its routes), as recorded by the adapter, in the VSphereSource.
+	if vms.Spec.Filter != "" || vms.Spec.Transform != nil || len(vms.Spec.Routes) > 0 {
+		propagateExpressionStatus(vms, cm)
+	} else {
+		vms.Status.ClearExpressionsStatus()
+	}
+
+	// Reflect the progress that the adapter reports in its heartbeats, and
+	// check back when the latest one would time out, in case it is the last.
+	if timeout := propagateStreamingStatus(vms, cm, time.Now()); timeout > 0 {
+		r.enqueueAfter(vms, timeout)
 	}
 
 	return nil
 }
 
+func propagateExpressionStatus(vms *sourcesv1alpha1.VSphereSource, cm *corev1.ConfigMap) {
+	raw, ok := cm.Data[vsphere.ExpressionStatusKey]
+	if !ok {
+		// Nothing has failed yet.
+		vms.Status.MarkExpressionsHealthy()
+		return
+	}
+	status := vsphere.ExpressionStatus{}
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		vms.Status.MarkExpressionsFailing("InvalidStatus", "Unable to decode expression status: %v", err)
+		return
+	}
+	if status.Error == "" {
+		vms.Status.MarkExpressionsHealthy()
+		return
+	}
+	vms.Status.MarkExpressionsFailing("EvaluationFailed", "Failed to evaluate %s at %s: %s",
+		status.Expression, status.Time.Format(time.RFC3339), status.Error)
+}
+
+func (r *Reconciler) reconcileEventTypes(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	logger := logging.FromContext(ctx)
+
+	// The adapter publishes the vCenter's catalog of event types in its
+	// ConfigMap once it has started.  Until then we make do without it.
+	var catalog []events.CatalogEntry
+	if cm, err := r.cmLister.ConfigMaps(ns).Get(resourcenames.ConfigMap(vms)); err == nil {
+		if raw, ok := cm.Data[vsphere.EventCatalogKey]; ok {
+			if err := json.Unmarshal([]byte(raw), &catalog); err != nil {
+				logger.Warnw("Failed to decode the event catalog", zap.Error(err))
+			}
+		}
+	}
+
+	vms.Status.CloudEventAttributes = resources.MakeCloudEventAttributes(vms, catalog)
+
+	existing, err := r.eventtypeLister.EventTypes(ns).List(labels.SelectorFromSet(resources.EventTypeLabels(vms)))
+	if err != nil {
+		return fmt.Errorf("failed to list eventtypes: %w", err)
+	}
+	current := make(map[string]*eventingv1beta1.EventType, len(existing))
+	for _, et := range existing {
+		if metav1.IsControlledBy(et, vms) {
+			current[et.Name] = et
+		}
+	}
+
+	for _, desired := range resources.MakeEventTypes(ctx, vms, catalog) {
+		et, ok := current[desired.Name]
+		delete(current, desired.Name)
+		if !ok {
+			if _, err := r.eventingclient.EventingV1beta1().EventTypes(ns).Create(desired); err != nil && !apierrs.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create eventtype %q: %w", desired.Name, err)
+			}
+			logger.Infof("Created eventtype %q", desired.Name)
+		} else if !equality.Semantic.DeepEqual(et.Spec, desired.Spec) {
+			et = et.DeepCopy()
+			et.Spec = desired.Spec
+			if _, err := r.eventingclient.EventingV1beta1().EventTypes(ns).Update(et); err != nil {
+				return fmt.Errorf("failed to update eventtype %q: %w", et.Name, err)
+			}
+		}
+	}
+
+	// Remove the EventTypes for events that the source no longer emits,
+	// or all of them when it no longer sends to a Broker.
+	for name := range current {
+		if err := r.eventingclient.EventingV1beta1().EventTypes(ns).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete eventtype %q: %w", name, err)
+		}
+		logger.Infof("Deleted eventtype %q", name)
+	}
+	return nil
+}
+
 func (r *Reconciler) reconcileServiceAccount(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	name := resourcenames.ServiceAccount(vms)
@@ -185,11 +380,43 @@ func (r *Reconciler) reconcileServiceAccount(ctx context.Context, vms *sourcesv1
 		logging.FromContext(ctx).Infof("Created serviceaccount %q", name)
 	} else if err != nil {
 		return fmt.Errorf("failed to get serviceaccount %q: %w", name, err)
+	} else if !metav1.IsControlledBy(sa, vms) {
+		vms.Status.MarkResourceNotOwned("ServiceAccount", name)
+		return fmt.Errorf("vspheresource %q does not own serviceaccount %q", vms.Name, name)
 	}
+	// The serviceaccount has no fields of ours beyond its metadata (its
+	// secrets are managed by Kubernetes), so there is nothing to keep in shape.
 
 	return nil
 }
 
+func (r *Reconciler) reconcileRole(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	name := resourcenames.Role(vms)
+	role, err := r.roleLister.Roles(ns).Get(name)
+	if apierrs.IsNotFound(err) {
+		role = resources.MakeRole(ctx, vms)
+		role, err = r.kubeclient.RbacV1().Roles(ns).Create(role)
+		if err != nil {
+			return fmt.Errorf("failed to create role %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Created role %q", name)
+	} else if err != nil {
+		return fmt.Errorf("failed to get role %q: %w", name, err)
+	} else if !metav1.IsControlledBy(role, vms) {
+		vms.Status.MarkResourceNotOwned("Role", name)
+		return fmt.Errorf("vspheresource %q does not own role %q", vms.Name, name)
+	} else if desired := resources.MakeRole(ctx, vms); !equality.Semantic.DeepEqual(role.Rules, desired.Rules) {
+		role = role.DeepCopy()
+		role.Rules = desired.Rules
+		if _, err := r.kubeclient.RbacV1().Roles(ns).Update(role); err != nil {
+			return fmt.Errorf("failed to update role %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated role %q", name)
+	}
+	return nil
+}
+
 func (r *Reconciler) reconcileRoleBinding(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	name := resourcenames.RoleBinding(vms)
@@ -203,15 +430,145 @@ func (r *Reconciler) reconcileRoleBinding(ctx context.Context, vms *sourcesv1alp
 		logging.FromContext(ctx).Infof("Created rolebinding %q", name)
 	} else if err != nil {
 		return fmt.Errorf("failed to get rolebinding %q: %w", name, err)
+	} else if !metav1.IsControlledBy(roleBinding, vms) {
+		vms.Status.MarkResourceNotOwned("RoleBinding", name)
+		return fmt.Errorf("vspheresource %q does not own rolebinding %q", vms.Name, name)
+	} else if desired := resources.MakeRoleBinding(ctx, vms); !equality.Semantic.DeepEqual(roleBinding.RoleRef, desired.RoleRef) {
+		// The roleRef of a rolebinding is immutable, so we have to replace it.
+		if err := r.kubeclient.RbacV1().RoleBindings(ns).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete rolebinding %q: %w", name, err)
+		}
+		if _, err := r.kubeclient.RbacV1().RoleBindings(ns).Create(desired); err != nil {
+			return fmt.Errorf("failed to create rolebinding %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Replaced rolebinding %q", name)
+	} else if !equality.Semantic.DeepEqual(roleBinding.Subjects, desired.Subjects) {
+		roleBinding = roleBinding.DeepCopy()
+		roleBinding.Subjects = desired.Subjects
+		if _, err := r.kubeclient.RbacV1().RoleBindings(ns).Update(roleBinding); err != nil {
+			return fmt.Errorf("failed to update rolebinding %q: %w", name, err)
+		}
+		logging.FromContext(ctx).Infof("Updated rolebinding %q", name)
 	}
-	// TODO: diff the roleref / subjects and update as necessary.
 	return nil
 }
 
+func (r *Reconciler) reconcileRoutes(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
+	ns := vms.Namespace
+	logger := logging.FromContext(ctx)
+
+	existing, err := r.sinkbindingLister.SinkBindings(ns).List(labels.SelectorFromSet(resources.RouteSinkBindingLabels(vms)))
+	if err != nil {
+		return fmt.Errorf("failed to list sinkbindings: %w", err)
+	}
+	current := make(map[string]*eventingsourcesv1alpha1.SinkBinding, len(existing))
+	for _, sb := range existing {
+		if metav1.IsControlledBy(sb, vms) {
+			current[sb.Name] = sb
+		}
+	}
+
+	// The sinks of the routes (and the reply sink) are resolved by
+	// SinkBindings that don't bind to anything, from whose status we pick
+	// up the resolved URIs.
+	var routes []sourcesv1alpha1.RouteStatus
+	var unresolved []string
+	for _, route := range vms.Spec.Routes {
+		uri, err := r.resolveSink(ctx, vms, resources.MakeRouteSinkBinding(ctx, vms, route), current)
+		if err != nil {
+			return err
+		}
+		if uri == nil {
+			unresolved = append(unresolved, route.Name)
+			continue
+		}
+		routes = append(routes, sourcesv1alpha1.RouteStatus{
+			Name:    route.Name,
+			SinkURI: uri,
+		})
+	}
+	var replySinkURI *apis.URL
+	if vms.Spec.ReplySink != nil {
+		replySinkURI, err = r.resolveSink(ctx, vms, resources.MakeReplySinkBinding(ctx, vms), current)
+		if err != nil {
+			return err
+		}
+		if replySinkURI == nil {
+			unresolved = append(unresolved, "replySink")
+		}
+	}
+
+	// Remove the SinkBindings of routes that no longer exist.
+	for name := range current {
+		if err := r.eventingclient.SourcesV1alpha1().SinkBindings(ns).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete sinkbinding %q: %w", name, err)
+		}
+		logger.Infof("Deleted sinkbinding %q", name)
+	}
+
+	if len(unresolved) > 0 {
+		vms.Status.MarkRoutesNotReady("SinkNotResolved", "Unable to resolve the sinks of routes: %s",
+			strings.Join(unresolved, ", "))
+		return nil
+	}
+	vms.Status.ReplySinkURI = replySinkURI
+	vms.Status.MarkRoutesReady(routes)
+	return nil
+}
+
+// resolveSink reconciles the desired SinkBinding that resolves one of the
+// sinks of the source's routes, removing it from current, and returns the
+// URI that it resolved, or nil when it hasn't (yet).
+func (r *Reconciler) resolveSink(ctx context.Context, vms *sourcesv1alpha1.VSphereSource, desired *eventingsourcesv1alpha1.SinkBinding, current map[string]*eventingsourcesv1alpha1.SinkBinding) (*apis.URL, error) {
+	ns := vms.Namespace
+	sinkbinding, ok := current[desired.Name]
+	delete(current, desired.Name)
+	if !ok {
+		// Make sure that we aren't about to collide with someone else's.
+		if sb, err := r.sinkbindingLister.SinkBindings(ns).Get(desired.Name); err == nil {
+			if !metav1.IsControlledBy(sb, vms) {
+				vms.Status.MarkRoutesNotReady("NotOwned", "There is an existing SinkBinding %q that we do not own.", desired.Name)
+				return nil, fmt.Errorf("vspheresource %q does not own sinkbinding %q", vms.Name, desired.Name)
+			}
+			// It is ours, but has lost its labels.
+			sinkbinding, ok = sb, true
+		}
+	}
+	var err error
+	if !ok {
+		sinkbinding, err = r.eventingclient.SourcesV1alpha1().SinkBindings(ns).Create(desired)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sinkbinding %q: %w", desired.Name, err)
+		}
+		logging.FromContext(ctx).Infof("Created sinkbinding %q", desired.Name)
+	} else if !equality.Semantic.DeepEqual(sinkbinding.Spec, desired.Spec) || !equality.Semantic.DeepEqual(sinkbinding.Labels, desired.Labels) {
+		sinkbinding = sinkbinding.DeepCopy()
+		sinkbinding.Spec = desired.Spec
+		sinkbinding.Labels = desired.Labels
+		sinkbinding, err = r.eventingclient.SourcesV1alpha1().SinkBindings(ns).Update(sinkbinding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update sinkbinding %q: %w", desired.Name, err)
+		}
+	}
+
+	if sinkbinding.Generation != sinkbinding.Status.ObservedGeneration {
+		return nil, nil
+	}
+	return sinkbinding.Status.SinkURI, nil
+}
+
 func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alpha1.VSphereSource) error {
 	ns := vms.Namespace
 	deploymentName := resourcenames.Deployment(vms)
 
+	hash, err := r.credentialsHash(vms)
+	if err != nil {
+		return err
+	}
+	if hash != "" {
+		ctx = sourcesv1alpha1.WithCredentialsHash(ctx, hash)
+	}
+
 	deployment, err := r.deploymentLister.Deployments(ns).Get(deploymentName)
 	if apierrs.IsNotFound(err) {
 		deployment = resources.MakeDeployment(ctx, vms, r.adapterImage)
@@ -222,15 +579,18 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alph
 		logging.FromContext(ctx).Infof("Created deployment %q", deploymentName)
 	} else if err != nil {
 		return fmt.Errorf("failed to get deployment %q: %w", deploymentName, err)
-	} else {
-		// The deployment exists, but make sure that it has the shape that we expect.
-		desiredDeployment := resources.MakeDeployment(ctx, vms, r.adapterImage)
+	} else if !metav1.IsControlledBy(deployment, vms) {
+		vms.Status.MarkResourceNotOwned("Deployment", deploymentName)
+		return fmt.Errorf("vspheresource %q does not own deployment %q", vms.Name, deploymentName)
+	} else if desired := resources.MakeDeployment(ctx, vms, r.adapterImage); resources.DeploymentDrifted(desired, deployment) {
+		// The deployment exists, but it has drifted from the shape that we expect.
 		deployment = deployment.DeepCopy()
-		deployment.Spec = desiredDeployment.Spec
+		deployment.Spec = desired.Spec
 		deployment, err = r.kubeclient.AppsV1().Deployments(ns).Update(deployment)
 		if err != nil {
-			return fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
+			return fmt.Errorf("failed to update deployment %q: %w", deploymentName, err)
 		}
+		logging.FromContext(ctx).Infof("Updated deployment %q", deploymentName)
 	}
 
 	// Reflect the state of the Adapter Deployment in the VSphereSource
@@ -238,3 +598,41 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, vms *sourcesv1alph
 
 	return nil
 }
+
+// checkSecret checks that the source's Secret holds usable credentials, and
+// reports when it doesn't on the AuthReady condition, rather than leave the
+// adapter's pods stuck starting.  The Secret is tracked so that we are
+// requeued when it changes.
+func (r *Reconciler) checkSecret(vms *sourcesv1alpha1.VSphereSource) error {
+	secretName := vms.Spec.SecretRef.Name
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  vms.Namespace,
+		Name:       secretName,
+	}, vms); err != nil {
+		return fmt.Errorf("failed to track secret %q: %w", secretName, err)
+	}
+
+	var se *sourcesv1alpha1.SecretError
+	if err := vms.Spec.CheckSecret(r.secretLister.Secrets(vms.Namespace)); errors.As(err, &se) {
+		vms.Status.MarkAuthNotReady(se.Reason, "%s", se.Message)
+	} else if err != nil {
+		return fmt.Errorf("failed to check secret %q: %w", secretName, err)
+	}
+	return nil
+}
+
+// credentialsHash returns the hash of the contents of the source's Secret,
+// which checkSecret tracks.  It returns the empty string when the Secret
+// doesn't exist (yet).
+func (r *Reconciler) credentialsHash(vms *sourcesv1alpha1.VSphereSource) (string, error) {
+	secretName := vms.Spec.SecretRef.Name
+	secret, err := r.secretLister.Secrets(vms.Namespace).Get(secretName)
+	if apierrs.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get secret %q: %w", secretName, err)
+	}
+	return vsphere.CredentialsHash(secret.Data), nil
+}
@@ -0,0 +1,1116 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	eventingsourcesv1alpha1 "knative.dev/eventing/pkg/apis/sources/v1alpha1"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	fakeclient "github.com/mattmoor/vmware-sources/pkg/client/injection/client/fake"
+	vspherereconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vspheresource"
+	. "github.com/mattmoor/vmware-sources/pkg/reconciler/testing"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources"
+	resourcenames "github.com/mattmoor/vmware-sources/pkg/reconciler/vsphere/resources/names"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+const (
+	testNS       = "bar"
+	testName     = "foo"
+	testKey      = testNS + "/" + testName
+	secretName   = "vsphere-credentials"
+	adapterImage = "gcr.io/knative-sources/receive-adapter"
+)
+
+var (
+	sinkURI = &apis.URL{Scheme: "http", Host: "sink.bar.svc.cluster.local"}
+
+	testSpec = sourcesv1alpha1.VSphereSourceSpec{
+		SourceSpec: duckv1.SourceSpec{
+			Sink: duckv1.Destination{URI: sinkURI},
+		},
+		VAuthSpec: sourcesv1alpha1.VAuthSpec{
+			Address:   apis.URL{Scheme: "https", Host: "vcenter.local"},
+			SecretRef: corev1.LocalObjectReference{Name: secretName},
+		},
+	}
+
+	credentials = map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte("administrator@vsphere.local"),
+		corev1.BasicAuthPasswordKey: []byte("hunter2"),
+	}
+
+	readyCondition = duckv1.Conditions{{
+		Type:   apis.ConditionReady,
+		Status: corev1.ConditionTrue,
+	}}
+
+	// The statuses of the source's children that we reflect in its status.
+	sinkBindingReady = WithVSphereSourceSinkBindingStatus(duckv1.SourceStatus{
+		Status:  duckv1.Status{Conditions: readyCondition},
+		SinkURI: sinkURI,
+	})
+	sinkBindingUnknown = WithVSphereSourceSinkBindingStatus(duckv1.SourceStatus{})
+	authReady          = WithVSphereSourceAuthStatus(duckv1.Status{Conditions: readyCondition})
+	authUnknown        = WithVSphereSourceAuthStatus(duckv1.Status{})
+	adapterReady       = WithVSphereSourceAdapterStatus(availableStatus)
+	adapterUnknown     = WithVSphereSourceAdapterStatus(appsv1.DeploymentStatus{})
+	awaitingHeartbeat  = WithVSphereSourceStreamingUnknown("AwaitingHeartbeat", "The adapter has not reported its progress yet.")
+
+	availableStatus = appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		}},
+	}
+)
+
+// source returns the VSphereSource under test, at generation 1.  Options
+// that change its spec go first, so that the options for its status can
+// depend upon it.
+func source(o ...VSphereSourceOption) *sourcesv1alpha1.VSphereSource {
+	return NewVSphereSource(testName, testNS,
+		append([]VSphereSourceOption{
+			WithVSphereSourceSpec(*testSpec.DeepCopy()),
+			WithVSphereSourceGeneration(1),
+		}, o...)...)
+}
+
+// withSpec applies f to the source's spec.
+func withSpec(f func(*sourcesv1alpha1.VSphereSourceSpec)) VSphereSourceOption {
+	return func(vms *sourcesv1alpha1.VSphereSource) {
+		f(&vms.Spec)
+	}
+}
+
+// ceAttributes sets the CloudEvent attributes that the source advertises
+// before its adapter has published the vCenter's catalog.
+func ceAttributes(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Status.CloudEventAttributes = resources.MakeCloudEventAttributes(vms, nil)
+}
+
+// created gives the source the status it has once we've created all of its
+// children, which aren't ready yet.
+func created(vms *sourcesv1alpha1.VSphereSource) {
+	for _, opt := range []VSphereSourceOption{
+		WithInitVSphereSourceConditions,
+		sinkBindingUnknown,
+		authUnknown,
+		awaitingHeartbeat,
+		ceAttributes,
+		WithVSphereSourceRoutesReady(),
+		adapterUnknown,
+	} {
+		opt(vms)
+	}
+	vms.Status.ObservedGeneration = vms.Generation
+}
+
+// ready gives the source the status it has once all of its children are
+// ready.
+func ready(vms *sourcesv1alpha1.VSphereSource) {
+	for _, opt := range []VSphereSourceOption{
+		WithInitVSphereSourceConditions,
+		sinkBindingReady,
+		authReady,
+		awaitingHeartbeat,
+		ceAttributes,
+		WithVSphereSourceRoutesReady(),
+		adapterReady,
+	} {
+		opt(vms)
+	}
+	vms.Status.ObservedGeneration = vms.Generation
+}
+
+// unfinished gives the source the status it is left with when reconciling
+// it stops short of its adapter.  The status of its SinkBinding is copied
+// over its own (but for the conditions), so until it is reconciled through
+// it reports the (unset) generation that the SinkBinding observed.
+func unfinished(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Status.ObservedGeneration = 0
+}
+
+// beforeEventTypes gives the source the status it is left with when
+// reconciling it stops before its EventTypes, whose attributes it then
+// doesn't advertise.
+func beforeEventTypes(vms *sourcesv1alpha1.VSphereSource) {
+	unfinished(vms)
+	vms.Status.CloudEventAttributes = nil
+}
+
+func secret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: testNS,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: credentials,
+	}
+}
+
+func sinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	return resources.MakeSinkBinding(context.Background(), vms)
+}
+
+func readySinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	sb := sinkBinding(vms)
+	sb.Status.Conditions = readyCondition
+	sb.Status.SinkURI = sinkURI
+	return sb
+}
+
+func vsphereBinding(vms *sourcesv1alpha1.VSphereSource) *sourcesv1alpha1.VSphereBinding {
+	return resources.MakeVSphereBinding(context.Background(), vms)
+}
+
+func readyVSphereBinding(vms *sourcesv1alpha1.VSphereSource) *sourcesv1alpha1.VSphereBinding {
+	vsb := vsphereBinding(vms)
+	vsb.Status.Conditions = readyCondition
+	return vsb
+}
+
+func configMap(vms *sourcesv1alpha1.VSphereSource) *corev1.ConfigMap {
+	return resources.MakeConfigMap(context.Background(), vms)
+}
+
+func serviceAccount(vms *sourcesv1alpha1.VSphereSource) *corev1.ServiceAccount {
+	return resources.MakeServiceAccount(context.Background(), vms)
+}
+
+func role(vms *sourcesv1alpha1.VSphereSource) *rbacv1.Role {
+	return resources.MakeRole(context.Background(), vms)
+}
+
+func roleBinding(vms *sourcesv1alpha1.VSphereSource) *rbacv1.RoleBinding {
+	return resources.MakeRoleBinding(context.Background(), vms)
+}
+
+// deployment returns the adapter's Deployment, as we make it when the
+// source's Secret holds the test credentials.
+func deployment(vms *sourcesv1alpha1.VSphereSource) *appsv1.Deployment {
+	ctx := sourcesv1alpha1.WithCredentialsHash(context.Background(), vsphere.CredentialsHash(credentials))
+	return resources.MakeDeployment(ctx, vms, adapterImage)
+}
+
+func availableDeployment(vms *sourcesv1alpha1.VSphereSource) *appsv1.Deployment {
+	d := deployment(vms)
+	d.Status = availableStatus
+	return d
+}
+
+// children returns the source's children, all ready, along with its
+// Secret.  The given objects replace the children of the same kind.
+func children(vms *sourcesv1alpha1.VSphereSource, replace ...runtime.Object) []runtime.Object {
+	objs := []runtime.Object{
+		secret(),
+		readySinkBinding(vms),
+		readyVSphereBinding(vms),
+		configMap(vms),
+		serviceAccount(vms),
+		role(vms),
+		roleBinding(vms),
+		availableDeployment(vms),
+	}
+	for _, r := range replace {
+		for i, obj := range objs {
+			if sameType(obj, r) {
+				objs[i] = r
+			}
+		}
+	}
+	return objs
+}
+
+func sameType(a, b runtime.Object) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// notOwned strips the owner references from one of the source's children.
+func notOwned(obj runtime.Object) runtime.Object {
+	obj.(metav1.Object).SetOwnerReferences(nil)
+	return obj
+}
+
+func without(objs []runtime.Object, drop runtime.Object) []runtime.Object {
+	var kept []runtime.Object
+	for _, obj := range objs {
+		if !sameType(obj, drop) {
+			kept = append(kept, obj)
+		}
+	}
+	return kept
+}
+
+func update(obj runtime.Object) clientgotesting.UpdateActionImpl {
+	return clientgotesting.UpdateActionImpl{Object: obj}
+}
+
+func TestReconcile(t *testing.T) {
+	readySource := source(ready)
+
+	table := rtesting.TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "first reconcile creates the children",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			source(),
+			secret(),
+		},
+		WantCreates: []runtime.Object{
+			vsphereBinding(source()),
+			configMap(source()),
+			serviceAccount(source()),
+			role(source()),
+			roleBinding(source()),
+			deployment(source(created)),
+			sinkBinding(source()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(created)),
+		},
+	}, {
+		Name:    "steady state",
+		Key:     testKey,
+		Objects: append([]runtime.Object{readySource}, children(readySource)...),
+	}, {
+		Name: "children become ready",
+		Key:  testKey,
+		Objects: append([]runtime.Object{source(created)},
+			children(readySource)...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(ready)),
+		},
+	}, {
+		Name: "new generation",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(ready, WithVSphereSourceGeneration(2)),
+		}, children(readySource)...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(WithVSphereSourceGeneration(2), ready)),
+		},
+	}, {
+		Name: "sink binding fails",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				sb := readySinkBinding(readySource)
+				sb.Status.Conditions = duckv1.Conditions{{
+					Type:    apis.ConditionReady,
+					Status:  corev1.ConditionFalse,
+					Reason:  "SinkNotFound",
+					Message: "The sink does not exist.",
+				}}
+				return sb
+			}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(ready, WithVSphereSourceSinkBindingStatus(duckv1.SourceStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:    apis.ConditionReady,
+					Status:  corev1.ConditionFalse,
+					Reason:  "SinkNotFound",
+					Message: "The sink does not exist.",
+				}}},
+				SinkURI: sinkURI,
+			}), ceAttributes, WithVSphereSourceObservedGeneration(1))),
+		},
+	}, {
+		Name: "adapter becomes unavailable",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				d := deployment(readySource)
+				d.Status.Conditions = []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  corev1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				}}
+				return d
+			}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(ready, WithVSphereSourceAdapterStatus(appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  corev1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				}},
+			}))),
+		},
+	}, {
+		Name: "secret missing",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			source(),
+		},
+		WantCreates: []runtime.Object{
+			vsphereBinding(source()),
+			configMap(source()),
+			serviceAccount(source()),
+			role(source()),
+			roleBinding(source()),
+			// Without the Secret, there is no hash of the credentials.
+			resources.MakeDeployment(context.Background(), source(created), adapterImage),
+			sinkBinding(source()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(created, WithVSphereSourceAuthNotReady(
+				sourcesv1alpha1.SecretMissingReason, `Secret "vsphere-credentials" does not exist.`))),
+		},
+	}, {
+		Name: "secret missing keys",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: testNS,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: credentials[corev1.BasicAuthUsernameKey],
+				},
+			})...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			// The hash of the credentials changes with the Secret.
+			update(func() runtime.Object {
+				ctx := sourcesv1alpha1.WithCredentialsHash(context.Background(), vsphere.CredentialsHash(map[string][]byte{
+					corev1.BasicAuthUsernameKey: credentials[corev1.BasicAuthUsernameKey],
+				}))
+				d := resources.MakeDeployment(ctx, readySource, adapterImage)
+				d.Status = availableStatus
+				return d
+			}()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(ready, WithVSphereSourceAuthNotReady(sourcesv1alpha1.SecretKeyMissingReason,
+				`Secret "vsphere-credentials" (of type "Opaque") is missing the password key(s); `+
+					`it should be a kubernetes.io/basic-auth Secret with "username" and "password".`))),
+		},
+	}, {
+		Name: "connection not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.ConnectionRef = &corev1.LocalObjectReference{Name: "vcenter"}
+			})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.ConnectionRef = &corev1.LocalObjectReference{Name: "vcenter"}
+			}), WithInitVSphereSourceConditions,
+				WithVSphereSourceAuthNotReady("ConnectionNotFound", `VSphereBinding "vcenter" does not exist.`))),
+		},
+	}, {
+		Name: "connection found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.VAuthSpec = sourcesv1alpha1.VAuthSpec{}
+				spec.ConnectionRef = &corev1.LocalObjectReference{Name: "vcenter"}
+			})),
+			&sourcesv1alpha1.VSphereBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vcenter",
+					Namespace: testNS,
+				},
+				Spec: sourcesv1alpha1.VSphereBindingSpec{
+					VAuthSpec: testSpec.VAuthSpec,
+				},
+			},
+			secret(),
+		},
+		WantCreates: []runtime.Object{
+			// The binding for the adapter copies the connection's settings.
+			vsphereBinding(source()),
+			configMap(source()),
+			serviceAccount(source()),
+			role(source()),
+			roleBinding(source()),
+			deployment(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.ConnectionRef = &corev1.LocalObjectReference{Name: "vcenter"}
+			}), created)),
+			sinkBinding(source()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			// Only our copy of the spec is resolved.
+			update(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.VAuthSpec = sourcesv1alpha1.VAuthSpec{}
+				spec.ConnectionRef = &corev1.LocalObjectReference{Name: "vcenter"}
+			}), func(vms *sourcesv1alpha1.VSphereSource) {
+				resolved := vms.DeepCopy()
+				resolved.Spec.VAuthSpec = testSpec.VAuthSpec
+				created(resolved)
+				vms.Status = resolved.Status
+			})),
+		},
+	}, {
+		Name: "suspend",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.Suspend = true
+			}), ready),
+		}, children(readySource)...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(func() runtime.Object {
+				d := deployment(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+					spec.Suspend = true
+				}), ready))
+				d.Status = availableStatus
+				return d
+			}()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.Suspend = true
+			}), ready, func(vms *sourcesv1alpha1.VSphereSource) {
+				// We don't expect heartbeats from a suspended adapter.
+				vms.Status.ClearStreamingStatus()
+			}, WithVSphereSourceSuspended)),
+		},
+	}, {
+		Name: "broker sink creates event types",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			source(withBroker),
+			secret(),
+		},
+		WantCreates: append([]runtime.Object{
+			vsphereBinding(source(withBroker)),
+			configMap(source(withBroker)),
+			serviceAccount(source(withBroker)),
+			role(source(withBroker)),
+			roleBinding(source(withBroker)),
+			deployment(source(withBroker, created)),
+			sinkBinding(source(withBroker)),
+		}, eventTypes(source(withBroker))...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withBroker, created, WithVSphereSourceExpressionsHealthy)),
+		},
+	}, {
+		Name: "stale event types are deleted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(ready),
+			&eventingv1beta1.EventType{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            resourcenames.EventType(readySource, "VmCreatedEvent"),
+					Namespace:       testNS,
+					Labels:          resources.EventTypeLabels(readySource),
+					OwnerReferences: sinkBinding(readySource).OwnerReferences,
+				},
+			},
+		}, children(readySource)...),
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			Name: resourcenames.EventType(readySource, "VmCreatedEvent"),
+		}},
+	}, {
+		Name: "expressions failing",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withFilter, ready),
+		}, children(source(withFilter), func() runtime.Object {
+			cm := configMap(readySource)
+			cm.Data = map[string]string{
+				vsphere.ExpressionStatusKey: `{"expression":"filter","time":"2020-04-01T12:00:00Z","error":"no such key: Vm"}`,
+			}
+			return cm
+		}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withFilter, ready, WithVSphereSourceExpressionsFailing("EvaluationFailed",
+				"Failed to evaluate filter at 2020-04-01T12:00:00Z: no such key: Vm"))),
+		},
+	}, {
+		Name: "expressions healthy",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withFilter, ready),
+		}, children(source(withFilter))...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withFilter, ready, WithVSphereSourceExpressionsHealthy)),
+		},
+	}, {
+		Name: "route filter failing",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withRoute, ready, routesResolved),
+			resolvedRouteSinkBinding(source(withRoute)),
+		}, children(source(withRoute, ready, routesResolved), func() runtime.Object {
+			cm := configMap(readySource)
+			cm.Data = map[string]string{
+				vsphere.ExpressionStatusKey: `{"expression":"routes[alerts].filter","time":"2020-04-01T12:00:00Z","error":"no such key: Alarm"}`,
+			}
+			return cm
+		}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withRoute, ready, routesResolved, WithVSphereSourceExpressionsFailing("EvaluationFailed",
+				"Failed to evaluate routes[alerts].filter at 2020-04-01T12:00:00Z: no such key: Alarm"))),
+		},
+	}, {
+		Name: "route sink not resolved",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withRoute, ready),
+		}, children(readySource)...),
+		WantCreates: []runtime.Object{
+			routeSinkBinding(source(withRoute)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			// The adapter isn't touched until the route's sink is resolved.
+			update(source(withRoute, ready, unfinished, WithVSphereSourceExpressionsHealthy, WithVSphereSourceRoutesNotReady("SinkNotResolved",
+				"Unable to resolve the sinks of routes: alerts"))),
+		},
+	}, {
+		Name: "route sink resolved",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withRoute, ready, WithVSphereSourceRoutesNotReady("SinkNotResolved",
+				"Unable to resolve the sinks of routes: alerts")),
+			resolvedRouteSinkBinding(source(withRoute)),
+		}, children(readySource)...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(func() runtime.Object {
+				d := deployment(source(withRoute, ready, routesResolved))
+				d.Status = availableStatus
+				return d
+			}()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withRoute, ready, routesResolved, WithVSphereSourceExpressionsHealthy)),
+		},
+	}, {
+		Name: "removed route is deleted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			readySource,
+			resolvedRouteSinkBinding(source(withRoute)),
+		}, children(readySource)...),
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			Name: resourcenames.RouteSinkBinding(readySource, "alerts"),
+		}},
+	}, {
+		Name: "route sink binding not owned",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withRoute, ready),
+			notOwned(resolvedRouteSinkBinding(source(withRoute))),
+		}, children(readySource)...),
+		WantErr: true,
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+				`vspheresource "foo" does not own sinkbinding "foo-alerts-sinkbinding"`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withRoute, ready, unfinished, WithVSphereSourceExpressionsHealthy, WithVSphereSourceRoutesNotReady("NotOwned",
+				`There is an existing SinkBinding "foo-alerts-sinkbinding" that we do not own.`))),
+		},
+	}, {
+		Name: "reply sink not resolved",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withReplySink, ready),
+		}, children(readySource)...),
+		WantCreates: []runtime.Object{
+			replySinkBinding(source(withReplySink)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withReplySink, ready, unfinished, WithVSphereSourceRoutesNotReady("SinkNotResolved",
+				"Unable to resolve the sinks of routes: replySink"))),
+		},
+	}, {
+		Name: "reply sink resolved",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withReplySink, ready, WithVSphereSourceRoutesNotReady("SinkNotResolved",
+				"Unable to resolve the sinks of routes: replySink")),
+			resolvedReplySinkBinding(source(withReplySink)),
+		}, children(readySource)...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(func() runtime.Object {
+				d := deployment(source(withReplySink, ready, replySinkResolved))
+				d.Status = availableStatus
+				return d
+			}()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withReplySink, ready, replySinkResolved)),
+		},
+	}, {
+		Name: "removed reply sink is deleted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			readySource,
+			resolvedReplySinkBinding(source(withReplySink)),
+		}, children(readySource)...),
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			Name: resourcenames.ReplySinkBinding(readySource),
+		}},
+	}, {
+		Name: "sink binding drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				sb := readySinkBinding(readySource)
+				sb.Spec.Sink = duckv1.Destination{URI: &apis.URL{Scheme: "http", Host: "elsewhere"}}
+				return sb
+			}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(readySinkBinding(readySource)),
+		},
+	}, {
+		Name: "vsphere binding drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				vsb := readyVSphereBinding(readySource)
+				vsb.Spec.Address.Host = "elsewhere"
+				return vsb
+			}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(readyVSphereBinding(readySource)),
+		},
+	}, {
+		Name: "role drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				r := role(readySource)
+				r.Rules = nil
+				return r
+			}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(role(readySource)),
+		},
+	}, {
+		Name: "role binding subjects drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				rb := roleBinding(readySource)
+				rb.Subjects = nil
+				return rb
+			}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(roleBinding(readySource)),
+		},
+	}, {
+		Name: "role binding role drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				rb := roleBinding(readySource)
+				rb.RoleRef.Name = "elsewhere"
+				return rb
+			}())...),
+		// The roleRef is immutable, so the rolebinding is replaced.
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			Name: resourcenames.RoleBinding(readySource),
+		}},
+		WantCreates: []runtime.Object{
+			roleBinding(readySource),
+		},
+	}, {
+		Name: "deployment drifted",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			children(readySource, func() runtime.Object {
+				d := availableDeployment(readySource)
+				d.Spec.Template.Spec.Containers[0].Image = "elsewhere"
+				return d
+			}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(availableDeployment(readySource)),
+		},
+	}, {
+		Name: "configmap recreated",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySource},
+			without(children(readySource), &corev1.ConfigMap{})...),
+		WantCreates: []runtime.Object{
+			configMap(readySource),
+		},
+	}, {
+		Name: "checkpoint dropped on resume",
+		Key:  testKey,
+		Objects: append([]runtime.Object{
+			source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.SkipEventsWhileSuspended = true
+			}), ready, WithVSphereSourceSuspended),
+		}, children(readySource, func() runtime.Object {
+			cm := configMap(readySource)
+			cm.Data = map[string]string{
+				vsphere.CheckpointKey: `{"lastEventKey":42}`,
+			}
+			return cm
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			update(configMap(readySource)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(withSpec(func(spec *sourcesv1alpha1.VSphereSourceSpec) {
+				spec.SkipEventsWhileSuspended = true
+			}), ready)),
+		},
+	}}
+
+	// Each of the children that a source may find itself not owning.
+	for _, tc := range []struct {
+		kind   string
+		child  runtime.Object
+		status []VSphereSourceOption
+	}{{
+		kind:   "sinkbinding",
+		child:  notOwned(readySinkBinding(readySource)),
+		status: []VSphereSourceOption{WithVSphereSourceResourceNotOwned("SinkBinding", resourcenames.SinkBinding(readySource))},
+	}, {
+		kind:   "vspherebinding",
+		child:  notOwned(readyVSphereBinding(readySource)),
+		status: []VSphereSourceOption{beforeEventTypes, WithVSphereSourceResourceNotOwned("VSphereBinding", resourcenames.VSphereBinding(readySource))},
+	}, {
+		kind:   "configmap",
+		child:  notOwned(configMap(readySource)),
+		status: []VSphereSourceOption{beforeEventTypes, WithVSphereSourceResourceNotOwned("ConfigMap", resourcenames.ConfigMap(readySource))},
+	}, {
+		kind:   "serviceaccount",
+		child:  notOwned(serviceAccount(readySource)),
+		status: []VSphereSourceOption{unfinished, WithVSphereSourceResourceNotOwned("ServiceAccount", resourcenames.ServiceAccount(readySource))},
+	}, {
+		kind:   "role",
+		child:  notOwned(role(readySource)),
+		status: []VSphereSourceOption{unfinished, WithVSphereSourceResourceNotOwned("Role", resourcenames.Role(readySource))},
+	}, {
+		kind:   "rolebinding",
+		child:  notOwned(roleBinding(readySource)),
+		status: []VSphereSourceOption{unfinished, WithVSphereSourceResourceNotOwned("RoleBinding", resourcenames.RoleBinding(readySource))},
+	}, {
+		kind:   "deployment",
+		child:  notOwned(availableDeployment(readySource)),
+		status: []VSphereSourceOption{unfinished, WithVSphereSourceResourceNotOwned("Deployment", resourcenames.Deployment(readySource))},
+	}} {
+		name := tc.child.(metav1.Object).GetName()
+		table = append(table, rtesting.TableRow{
+			Name:    tc.kind + " not owned",
+			Key:     testKey,
+			Objects: append([]runtime.Object{readySource}, children(readySource, tc.child)...),
+			WantErr: true,
+			WantEvents: []string{
+				rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+					"vspheresource %q does not own %s %q", testName, tc.kind, name),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				update(source(append([]VSphereSourceOption{ready}, tc.status...)...)),
+			},
+		})
+	}
+
+	// Each of the children that may fail to be created.  The creates that
+	// come before the failure are expected, in the order in which the fake
+	// clients record them.
+	for _, tc := range []struct {
+		resource string
+		name     string
+		creates  []runtime.Object
+		status   []VSphereSourceOption
+	}{{
+		resource: "sinkbindings",
+		name:     resourcenames.SinkBinding(readySource),
+		creates:  []runtime.Object{sinkBinding(source())},
+		status:   []VSphereSourceOption{WithInitVSphereSourceConditions},
+	}, {
+		resource: "vspherebindings",
+		name:     resourcenames.VSphereBinding(readySource),
+		creates:  []runtime.Object{vsphereBinding(source()), sinkBinding(source())},
+		status:   []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown},
+	}, {
+		resource: "configmaps",
+		name:     resourcenames.ConfigMap(readySource),
+		creates:  []runtime.Object{vsphereBinding(source()), configMap(source()), sinkBinding(source())},
+		status:   []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown, authUnknown},
+	}, {
+		resource: "serviceaccounts",
+		name:     resourcenames.ServiceAccount(readySource),
+		creates: []runtime.Object{vsphereBinding(source()), configMap(source()),
+			serviceAccount(source()), sinkBinding(source())},
+		status: []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown, authUnknown,
+			awaitingHeartbeat, ceAttributes},
+	}, {
+		resource: "roles",
+		name:     resourcenames.Role(readySource),
+		creates: []runtime.Object{vsphereBinding(source()), configMap(source()),
+			serviceAccount(source()), role(source()), sinkBinding(source())},
+		status: []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown, authUnknown,
+			awaitingHeartbeat, ceAttributes},
+	}, {
+		resource: "rolebindings",
+		name:     resourcenames.RoleBinding(readySource),
+		creates: []runtime.Object{vsphereBinding(source()), configMap(source()),
+			serviceAccount(source()), role(source()), roleBinding(source()), sinkBinding(source())},
+		status: []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown, authUnknown,
+			awaitingHeartbeat, ceAttributes},
+	}, {
+		resource: "deployments",
+		name:     resourcenames.Deployment(readySource),
+		creates: []runtime.Object{vsphereBinding(source()), configMap(source()),
+			serviceAccount(source()), role(source()), roleBinding(source()),
+			deployment(source(created)), sinkBinding(source())},
+		status: []VSphereSourceOption{WithInitVSphereSourceConditions, sinkBindingUnknown, authUnknown,
+			awaitingHeartbeat, ceAttributes, WithVSphereSourceRoutesReady()},
+	}} {
+		singular := tc.resource[:len(tc.resource)-1]
+		table = append(table, rtesting.TableRow{
+			Name: "failed to create " + singular,
+			Key:  testKey,
+			Objects: []runtime.Object{
+				source(),
+				secret(),
+			},
+			WithReactors: []clientgotesting.ReactionFunc{
+				rtesting.InduceFailure("create", tc.resource),
+			},
+			WantErr:     true,
+			WantCreates: tc.creates,
+			WantEvents: []string{
+				rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+					"failed to create %s %q: inducing failure for create %s", singular, tc.name, tc.resource),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				update(source(tc.status...)),
+			},
+		})
+	}
+
+	// Each of the children that may fail to be updated when it drifts.
+	for _, tc := range []struct {
+		resource string
+		child    runtime.Object
+		want     runtime.Object
+		status   []VSphereSourceOption
+	}{{
+		resource: "sinkbindings",
+		child: func() runtime.Object {
+			sb := readySinkBinding(readySource)
+			sb.Spec.Sink = duckv1.Destination{URI: &apis.URL{Scheme: "http", Host: "elsewhere"}}
+			return sb
+		}(),
+		want: readySinkBinding(readySource),
+	}, {
+		resource: "vspherebindings",
+		child: func() runtime.Object {
+			vsb := readyVSphereBinding(readySource)
+			vsb.Spec.Address.Host = "elsewhere"
+			return vsb
+		}(),
+		want:   readyVSphereBinding(readySource),
+		status: []VSphereSourceOption{beforeEventTypes},
+	}, {
+		resource: "roles",
+		child: func() runtime.Object {
+			r := role(readySource)
+			r.Rules = nil
+			return r
+		}(),
+		want:   role(readySource),
+		status: []VSphereSourceOption{unfinished},
+	}, {
+		resource: "rolebindings",
+		child: func() runtime.Object {
+			rb := roleBinding(readySource)
+			rb.Subjects = nil
+			return rb
+		}(),
+		want:   roleBinding(readySource),
+		status: []VSphereSourceOption{unfinished},
+	}, {
+		resource: "deployments",
+		child: func() runtime.Object {
+			d := availableDeployment(readySource)
+			d.Spec.Template.Spec.Containers[0].Image = "elsewhere"
+			return d
+		}(),
+		want:   availableDeployment(readySource),
+		status: []VSphereSourceOption{unfinished},
+	}} {
+		singular := tc.resource[:len(tc.resource)-1]
+		name := tc.child.(metav1.Object).GetName()
+		var statusUpdates []clientgotesting.UpdateActionImpl
+		if len(tc.status) > 0 {
+			statusUpdates = append(statusUpdates, update(source(append([]VSphereSourceOption{ready}, tc.status...)...)))
+		}
+		table = append(table, rtesting.TableRow{
+			Name:    "failed to update " + singular,
+			Key:     testKey,
+			Objects: append([]runtime.Object{readySource}, children(readySource, tc.child)...),
+			WithReactors: []clientgotesting.ReactionFunc{
+				rtesting.InduceFailure("update", tc.resource),
+			},
+			WantErr: true,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				update(tc.want),
+			},
+			WantStatusUpdates: statusUpdates,
+			WantEvents: []string{
+				rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+					"failed to update %s %q: inducing failure for update %s", singular, name, tc.resource),
+			},
+		})
+	}
+
+	table = append(table, rtesting.TableRow{
+		Name: "failed to update status",
+		Key:  testKey,
+		Objects: append([]runtime.Object{source(created)},
+			children(readySource)...),
+		WithReactors: []clientgotesting.ReactionFunc{
+			rtesting.InduceFailure("update", "vspheresources"),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			update(source(ready)),
+		},
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "UpdateFailed",
+				"Failed to update status for %q: inducing failure for update vspheresources", testName),
+		},
+	})
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			adapterImage:         adapterImage,
+			kubeclient:           fakekubeclient.Get(ctx),
+			eventingclient:       fakeeventingclient.Get(ctx),
+			client:               fakeclient.Get(ctx),
+			deploymentLister:     listers.GetDeploymentLister(),
+			vspherebindingLister: listers.GetVSphereBindingLister(),
+			sinkbindingLister:    listers.GetSinkBindingLister(),
+			roleLister:           listers.GetRoleLister(),
+			rbacLister:           listers.GetRoleBindingLister(),
+			cmLister:             listers.GetConfigMapLister(),
+			saLister:             listers.GetServiceAccountLister(),
+			eventtypeLister:      listers.GetEventTypeLister(),
+			secretLister:         listers.GetSecretLister(),
+			tracker:              &rtesting.FakeTracker{},
+			enqueueAfter:         func(interface{}, time.Duration) {},
+		}
+		return vspherereconciler.NewReconciler(ctx, logging.FromContext(ctx), fakeclient.Get(ctx),
+			listers.GetVSphereSourceLister(), controller.GetEventRecorder(ctx), r)
+	}))
+}
+
+// withBroker sends the source's events to a Broker, with a filter that
+// limits them to a couple of types.
+func withBroker(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Spec.Sink = duckv1.Destination{
+		Ref: &duckv1.KReference{
+			APIVersion: "eventing.knative.dev/v1beta1",
+			Kind:       "Broker",
+			Name:       "default",
+		},
+	}
+	vms.Spec.Filter = `eventType in ["VmCreatedEvent", "VmRemovedEvent"]`
+}
+
+// withFilter gives the source a filter expression.
+func withFilter(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Spec.Filter = `eventType == "VmPoweredOnEvent"`
+}
+
+// withRoute sends some of the source's events to a route of their own.
+func withRoute(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Spec.Routes = []sourcesv1alpha1.EventRoute{{
+		Name:   "alerts",
+		Filter: `eventType == "AlarmStatusChangedEvent"`,
+		Sink:   duckv1.Destination{URI: &apis.URL{Scheme: "http", Host: "alerts.bar.svc.cluster.local"}},
+	}}
+}
+
+// routesResolved records the resolved sink of the source's route.
+func routesResolved(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Status.MarkRoutesReady([]sourcesv1alpha1.RouteStatus{{
+		Name:    "alerts",
+		SinkURI: vms.Spec.Routes[0].Sink.URI,
+	}})
+}
+
+func routeSinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	return resources.MakeRouteSinkBinding(context.Background(), vms, vms.Spec.Routes[0])
+}
+
+func resolvedRouteSinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	sb := routeSinkBinding(vms)
+	sb.Status.SinkURI = vms.Spec.Routes[0].Sink.URI
+	return sb
+}
+
+// withReplySink forwards the replies of the source's sink.
+func withReplySink(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Spec.ReplySink = &duckv1.Destination{URI: &apis.URL{Scheme: "http", Host: "enriched.bar.svc.cluster.local"}}
+}
+
+// replySinkResolved records the resolved reply sink of the source.
+func replySinkResolved(vms *sourcesv1alpha1.VSphereSource) {
+	vms.Status.ReplySinkURI = vms.Spec.ReplySink.URI
+	vms.Status.MarkRoutesReady(nil)
+}
+
+func replySinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	return resources.MakeReplySinkBinding(context.Background(), vms)
+}
+
+func resolvedReplySinkBinding(vms *sourcesv1alpha1.VSphereSource) *eventingsourcesv1alpha1.SinkBinding {
+	sb := replySinkBinding(vms)
+	sb.Status.SinkURI = vms.Spec.ReplySink.URI
+	return sb
+}
+
+func eventTypes(vms *sourcesv1alpha1.VSphereSource) []runtime.Object {
+	var objs []runtime.Object
+	for _, et := range resources.MakeEventTypes(context.Background(), vms, nil) {
+		objs = append(objs, et)
+	}
+	return objs
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphereaction
+
+import (
+	"context"
+	"os"
+
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/client/injection/client"
+	vsphereactioninformer "github.com/mattmoor/vmware-sources/pkg/client/injection/informers/sources/v1alpha1/vsphereaction"
+	vspherebindinginformer "github.com/mattmoor/vmware-sources/pkg/client/injection/informers/sources/v1alpha1/vspherebinding"
+	vsphereactionreconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vsphereaction"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+)
+
+// NewController creates a Reconciler and returns the result of NewImpl.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	vsphereactionInformer := vsphereactioninformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	vspherebindingInformer := vspherebindinginformer.Get(ctx)
+
+	r := &Reconciler{
+		Reconciler: vsphereserver.Reconciler{
+			KubeClient:           kubeclient.Get(ctx),
+			Client:               client.Get(ctx),
+			DeploymentLister:     deploymentInformer.Lister(),
+			ServiceLister:        serviceInformer.Lister(),
+			VSphereBindingLister: vspherebindingInformer.Lister(),
+		},
+		executorImage: os.Getenv("VSPHERE_ACTION"),
+	}
+	impl := vsphereactionreconciler.NewImpl(ctx, r)
+
+	logger.Info("Setting up event handlers.")
+
+	vsphereactionInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereAction")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereAction")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	vspherebindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterGroupKind(v1alpha1.Kind("VSphereAction")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
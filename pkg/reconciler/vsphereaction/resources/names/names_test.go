@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name string
+		va   *v1alpha1.VSphereAction
+		f    func(*v1alpha1.VSphereAction) string
+		want string
+	}{{
+		name: "Deployment",
+		va: &v1alpha1.VSphereAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+		},
+		f:    Deployment,
+		want: "foo-deployment",
+	}, {
+		name: "Service",
+		va: &v1alpha1.VSphereAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+		},
+		f:    Service,
+		want: "foo-action",
+	}, {
+		name: "Service too long",
+		va: &v1alpha1.VSphereAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.Repeat("f", 63),
+			},
+		},
+		f:    Service,
+		want: "ffffffffffffffffffffffff105d7597f637e83cc711605ac3ea4957-action",
+	}, {
+		name: "vspherebinding",
+		va: &v1alpha1.VSphereAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    VSphereBinding,
+		want: "baz-vspherebinding",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.f(test.va)
+			if got != test.want {
+				t.Errorf("%s() = %v, wanted %v", test.name, got, test.want)
+			}
+		})
+	}
+}
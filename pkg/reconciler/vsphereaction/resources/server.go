@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereaction/resources/names"
+	serverresources "github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver/resources"
+)
+
+// Source returns the source of the result events that the executor
+// replies with, which names the action.
+func Source(va *v1alpha1.VSphereAction) string {
+	return fmt.Sprintf("/apis/v1/namespaces/%s/vsphereactions/%s", va.Namespace, va.Name)
+}
+
+// Labels returns the labels of the executor's pods, which its Service
+// selects.
+func Labels(va *v1alpha1.VSphereAction) map[string]string {
+	return map[string]string{
+		v1alpha1.VSphereActionLabelKey: va.Name,
+	}
+}
+
+// MakeServer describes the executor that performs the action's operation
+// on the events sent to it.
+func MakeServer(va *v1alpha1.VSphereAction, executorImage string) *serverresources.Server {
+	env := []corev1.EnvVar{{
+		Name:  "VSPHERE_ACTION_SOURCE",
+		Value: Source(va),
+	}}
+	if va.Spec.Filter != nil {
+		b, _ := json.Marshal(va.Spec.Filter)
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_FILTER",
+			Value: string(b),
+		})
+	}
+	b, _ := json.Marshal(va.Spec.Operation)
+	env = append(env, corev1.EnvVar{
+		Name:  "VSPHERE_OPERATION",
+		Value: string(b),
+	}, corev1.EnvVar{
+		Name:  "VSPHERE_SCOPE",
+		Value: va.Spec.Scope,
+	})
+	if va.Spec.DryRun {
+		env = append(env, corev1.EnvVar{
+			Name:  "VSPHERE_DRY_RUN",
+			Value: "true",
+		})
+	}
+
+	return &serverresources.Server{
+		Owner:              va,
+		VAuthSpec:          va.Spec.VAuthSpec,
+		DeploymentName:     names.Deployment(va),
+		ServiceName:        names.Service(va),
+		VSphereBindingName: names.VSphereBinding(va),
+		Labels:             Labels(va),
+		ContainerName:      v1alpha1.ExecutorContainerName,
+		Image:              executorImage,
+		Env:                env,
+	}
+}
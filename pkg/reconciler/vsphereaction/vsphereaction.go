@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphereaction
+
+import (
+	"context"
+
+	"knative.dev/pkg/reconciler"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	vsphereactionreconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vsphereaction"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereaction/resources"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver"
+)
+
+// Reconciler implements vsphereactionreconciler.Interface for
+// VSphereAction resources.
+type Reconciler struct {
+	vsphereserver.Reconciler
+
+	executorImage string
+}
+
+// Check that our Reconciler implements Interface
+var _ vsphereactionreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, va *sourcesv1alpha1.VSphereAction) reconciler.Event {
+	va.Status.InitializeConditions()
+
+	server := resources.MakeServer(va, r.executorImage)
+	if err := r.ReconcileVSphereBinding(ctx, server, &va.Status); err != nil {
+		return err
+	}
+	deployment, err := r.ReconcileDeployment(ctx, server, &va.Status)
+	if err != nil {
+		return err
+	}
+	// Reflect the state of the Executor Deployment in the VSphereAction
+	va.Status.PropagateExecutorStatus(deployment.Status)
+	if err := r.ReconcileService(ctx, server, &va.Status); err != nil {
+		return err
+	}
+
+	va.Status.ObservedGeneration = va.Generation
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphereaction
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	fakeclient "github.com/mattmoor/vmware-sources/pkg/client/injection/client/fake"
+	vsphereactionreconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vsphereaction"
+	. "github.com/mattmoor/vmware-sources/pkg/reconciler/testing"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereaction/resources"
+)
+
+const (
+	testNS        = "bar"
+	testName      = "foo"
+	testKey       = testNS + "/" + testName
+	executorImage = "gcr.io/knative-sources/vsphere-action"
+)
+
+var (
+	testSpec = sourcesv1alpha1.VSphereActionSpec{
+		VAuthSpec: sourcesv1alpha1.VAuthSpec{
+			Address:   apis.URL{Scheme: "https", Host: "vcenter.local"},
+			SecretRef: corev1.LocalObjectReference{Name: "vsphere-credentials"},
+		},
+		Scope: "/dc1/vm",
+		Filter: &sourcesv1alpha1.ActionFilter{
+			Attributes: map[string]string{"type": "com.vmware.vsphere.VmCreatedEvent"},
+		},
+		Operation: sourcesv1alpha1.ActionOperation{
+			AttachTag: &sourcesv1alpha1.TagAction{Tag: "prod", Category: "env"},
+		},
+	}
+
+	actionAddress = &apis.URL{Scheme: "http", Host: "foo-action.bar.svc.cluster.local"}
+)
+
+// The reconciliation of the children that VSphereActions share with
+// VSphereSinks is tested with the latter, so these only cover what is
+// specific to actions.
+
+// action returns the VSphereAction under test, at generation 1.  Options that
+// change its spec go first, so that the options for its status can depend
+// upon it.
+func action(o ...VSphereActionOption) *sourcesv1alpha1.VSphereAction {
+	return NewVSphereAction(testName, testNS,
+		append([]VSphereActionOption{
+			WithVSphereActionSpec(*testSpec.DeepCopy()),
+			WithVSphereActionGeneration(1),
+		}, o...)...)
+}
+
+// dryRun puts the action in dry-run mode.
+func dryRun(va *sourcesv1alpha1.VSphereAction) *sourcesv1alpha1.VSphereAction {
+	va.Spec.DryRun = true
+	return va
+}
+
+func TestReconcile(t *testing.T) {
+	created := WithVSphereActionStatus(WithServerCreated(actionAddress))
+	ready := WithVSphereActionStatus(WithServerReady(actionAddress))
+	readyAction := action(ready)
+	readyServer := resources.MakeServer(readyAction, executorImage)
+	newServer := resources.MakeServer(action(), executorImage)
+
+	table := rtesting.TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "first reconcile creates the children",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			action(),
+		},
+		WantCreates: []runtime.Object{
+			ServerVSphereBinding(newServer),
+			ServerDeployment(newServer),
+			ServerService(newServer),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(action(created)),
+		},
+	}, {
+		Name: "operation changes",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readyAction}, ServerChildren(readyServer, func() runtime.Object {
+			d := AvailableDeployment(readyServer)
+			d.Spec.Template.Spec.Containers[0].Env[3].Value = `{"detachTag":{"tag":"prod"}}`
+			return d
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(AvailableDeployment(readyServer)),
+		},
+	}, {
+		Name: "scope changes",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readyAction}, ServerChildren(readyServer, func() runtime.Object {
+			d := AvailableDeployment(readyServer)
+			d.Spec.Template.Spec.Containers[0].Env[4].Value = "/dc1"
+			return d
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(AvailableDeployment(readyServer)),
+		},
+	}, {
+		Name: "dry run is turned on",
+		Key:  testKey,
+		Objects: append([]runtime.Object{dryRun(readyAction.DeepCopy())},
+			ServerChildren(readyServer)...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(AvailableDeployment(resources.MakeServer(dryRun(readyAction.DeepCopy()), executorImage))),
+		},
+	}, {
+		Name: "executor becomes unavailable",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readyAction}, ServerChildren(readyServer, func() runtime.Object {
+			d := ServerDeployment(readyServer)
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentAvailable,
+				Status:  corev1.ConditionFalse,
+				Reason:  "MinimumReplicasUnavailable",
+				Message: "Deployment does not have minimum availability.",
+			}}
+			return d
+		}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(action(ready, WithVSphereActionStatus(WithServerDeploymentStatus(appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  corev1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				}},
+			})))),
+		},
+	}}
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			Reconciler:    MakeServerReconciler(ctx, listers),
+			executorImage: executorImage,
+		}
+		return vsphereactionreconciler.NewReconciler(ctx, logging.FromContext(ctx), fakeclient.Get(ctx),
+			listers.GetVSphereActionLister(), controller.GetEventRecorder(ctx), r)
+	}))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vspherebinding
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/tracker"
+	"knative.dev/pkg/webhook/psbinding"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+// WithContextFactory returns a psbinding.BindableContext that infuses the
+// context with the hash of the Secret of the VSphereBindings that opted in
+// to rolling out their subjects when it changes.  The handler is called
+// with the key of the VSphereBindings whenever their Secret changes.
+func WithContextFactory(ctx context.Context, handler func(types.NamespacedName)) psbinding.BindableContext {
+	secretInformer := secret.Get(ctx)
+	t := tracker.New(handler, controller.GetTrackerLease(ctx))
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		// The tracker matches on apiVersion and kind, which the informer's
+		// objects lack.
+		controller.EnsureTypeMeta(t.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret"))))
+
+	return func(ctx context.Context, b psbinding.Bindable) (context.Context, error) {
+		vsb := b.(*v1alpha1.VSphereBinding)
+		if err := t.TrackReference(tracker.Reference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  vsb.Namespace,
+			Name:       vsb.Spec.SecretRef.Name,
+		}, vsb); err != nil {
+			return nil, err
+		}
+		if !vsb.RolloutOnCredentialsChange() {
+			return ctx, nil
+		}
+
+		s, err := secretInformer.Lister().Secrets(vsb.Namespace).Get(vsb.Spec.SecretRef.Name)
+		if apierrs.IsNotFound(err) {
+			// The subjects can't start without the Secret anyways, and we
+			// will hear about it when it is created.
+			return ctx, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return v1alpha1.WithCredentialsHash(ctx, vsphere.CredentialsHash(s.Data)), nil
+	}
+}
@@ -18,7 +18,9 @@ package vspherebinding
 
 import (
 	"context"
+	"errors"
 
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
 	vsbinformer "github.com/mattmoor/vmware-sources/pkg/client/injection/informers/sources/v1alpha1/vspherebinding"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/podspecable"
 
@@ -29,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"knative.dev/eventing/pkg/apis/sources/v1alpha1"
 	"knative.dev/pkg/apis/duck"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/clients/dynamicclient"
@@ -68,6 +71,19 @@ func NewController(
 	vsbInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
 	c.Tracker = tracker.New(impl.EnqueueKey, controller.GetTrackerLease(ctx))
+	secretLister := secret.Get(ctx).Lister()
+	withContext := WithContextFactory(ctx, impl.EnqueueKey)
+	c.WithContext = func(ctx context.Context, b psbinding.Bindable) (context.Context, error) {
+		// Report whether the subjects will be able to use the credentials,
+		// before the binding is marked available.
+		vsb := b.(*sourcesv1alpha1.VSphereBinding)
+		var se *sourcesv1alpha1.SecretError
+		if err := vsb.Spec.CheckSecret(secretLister.Secrets(vsb.Namespace)); err != nil && !errors.As(err, &se) {
+			return nil, err
+		}
+		vsb.Status.PropagateSecretStatus(se)
+		return withContext(ctx, b)
+	}
 	c.Factory = &duck.CachedInformerFactory{
 		Delegate: &duck.EnqueueInformerFactory{
 			Delegate:     psInformerFactory,
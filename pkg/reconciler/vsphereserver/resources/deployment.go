@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+)
+
+func MakeDeployment(ctx context.Context, s *Server) *appsv1.Deployment {
+	env := append([]corev1.EnvVar{{
+		Name:  "PORT",
+		Value: strconv.Itoa(Port),
+	}}, s.Env...)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            s.DeploymentName,
+			Namespace:       s.Namespace(),
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(s.Owner)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: s.Labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: s.Labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  s.ContainerName,
+						Image: s.Image,
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: Port,
+						}},
+						Env: env,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// DeploymentDrifted returns whether the existing Deployment has drifted from
+// the desired one.  DeepDerivative takes our empty values to mean unset, so
+// the server's environment, less what the VSphereBinding injects, is
+// compared exactly.
+func DeploymentDrifted(desired, existing *appsv1.Deployment) bool {
+	if !equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+		return true
+	}
+	want := desired.Spec.Template.Spec.Containers[0]
+	var env []corev1.EnvVar
+	for _, c := range existing.Spec.Template.Spec.Containers {
+		if c.Name != want.Name {
+			continue
+		}
+		for _, ev := range c.Env {
+			if !strings.HasPrefix(ev.Name, "GOVC_") {
+				env = append(env, ev)
+			}
+		}
+	}
+	return !equality.Semantic.DeepEqual(want.Env, env)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/kmeta"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+// Port is the port on which servers listen.
+const Port = 8080
+
+// ClusterDomain is the domain of the cluster's Services, which we assume is
+// the default.
+const ClusterDomain = "cluster.local"
+
+// Server describes a server that runs with vSphere credentials behind a
+// Service, on behalf of the resource that owns it (e.g. a VSphereSink).
+type Server struct {
+	// Owner is the resource that the server runs for.
+	Owner kmeta.OwnerRefable
+
+	// VAuthSpec holds the credentials that the server is bound to.
+	VAuthSpec v1alpha1.VAuthSpec
+
+	// DeploymentName, ServiceName and VSphereBindingName are the names of
+	// the server's resources.
+	DeploymentName     string
+	ServiceName        string
+	VSphereBindingName string
+
+	// Labels are the labels of the server's pods, which its Service
+	// selects.
+	Labels map[string]string
+
+	// ContainerName and Image identify the server's container, and Env
+	// is its environment, beyond PORT.
+	ContainerName string
+	Image         string
+	Env           []corev1.EnvVar
+}
+
+// Namespace returns the namespace of the server's resources, which is that
+// of its owner.
+func (s *Server) Namespace() string {
+	return s.Owner.GetObjectMeta().GetNamespace()
+}
+
+// Hostname returns the hostname of the server's Service, which is the host
+// of its owner's address.
+func (s *Server) Hostname() string {
+	return fmt.Sprintf("%s.%s.svc.%s", s.ServiceName, s.Namespace(), ClusterDomain)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vsphereserver reconciles the resources shared by the kinds that
+// run a server with vSphere credentials behind a Service (e.g.
+// VSphereSink): its VSphereBinding, Deployment and Service.
+package vsphereserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+
+	clientset "github.com/mattmoor/vmware-sources/pkg/client/clientset/versioned"
+	v1alpha1lister "github.com/mattmoor/vmware-sources/pkg/client/listers/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver/resources"
+)
+
+// Status is the status of the resource that a server runs for, which
+// reflects the state of the server's resources.
+type Status interface {
+	PropagateAuthStatus(duckv1.Status)
+	SetAddress(*apis.URL)
+	MarkResourceNotOwned(kind, name string)
+}
+
+// Reconciler reconciles the resources of servers.  It is embedded in the
+// reconcilers of the kinds that run them.
+type Reconciler struct {
+	KubeClient kubernetes.Interface
+	Client     clientset.Interface
+
+	DeploymentLister     appsv1listers.DeploymentLister
+	ServiceLister        corev1listers.ServiceLister
+	VSphereBindingLister v1alpha1lister.VSphereBindingLister
+}
+
+// notOwned returns the error for a resource of the server that its owner
+// doesn't own.
+func notOwned(s *resources.Server, kind, name string) error {
+	return fmt.Errorf("%s %q does not own %s %q", strings.ToLower(s.Owner.GetGroupVersionKind().Kind),
+		s.Owner.GetObjectMeta().GetName(), strings.ToLower(kind), name)
+}
+
+// ReconcileVSphereBinding reconciles the server's VSphereBinding, and
+// reflects its state in the status.
+func (r *Reconciler) ReconcileVSphereBinding(ctx context.Context, s *resources.Server, status Status) error {
+	ns := s.Namespace()
+	vspherebindingName := s.VSphereBindingName
+
+	vspherebinding, err := r.VSphereBindingLister.VSphereBindings(ns).Get(vspherebindingName)
+	if apierrs.IsNotFound(err) {
+		vspherebinding = resources.MakeVSphereBinding(ctx, s)
+		vspherebinding, err = r.Client.SourcesV1alpha1().VSphereBindings(ns).Create(vspherebinding)
+		if err != nil {
+			return fmt.Errorf("failed to create vspherebinding %q: %w", vspherebindingName, err)
+		}
+		logging.FromContext(ctx).Infof("Created vspherebinding %q", vspherebindingName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get vspherebinding %q: %w", vspherebindingName, err)
+	} else if !metav1.IsControlledBy(vspherebinding, s.Owner.GetObjectMeta()) {
+		status.MarkResourceNotOwned("VSphereBinding", vspherebindingName)
+		return notOwned(s, "VSphereBinding", vspherebindingName)
+	} else if desired := resources.MakeVSphereBinding(ctx, s); !equality.Semantic.DeepEqual(vspherebinding.Spec, desired.Spec) ||
+		!equality.Semantic.DeepDerivative(desired.Annotations, vspherebinding.Annotations) {
+		// The vspherebinding exists, but it has drifted from the shape that we expect.
+		vspherebinding = vspherebinding.DeepCopy()
+		vspherebinding.Spec = desired.Spec
+		if vspherebinding.Annotations == nil {
+			vspherebinding.Annotations = make(map[string]string, len(desired.Annotations))
+		}
+		for k, v := range desired.Annotations {
+			vspherebinding.Annotations[k] = v
+		}
+		vspherebinding, err = r.Client.SourcesV1alpha1().VSphereBindings(ns).Update(vspherebinding)
+		if err != nil {
+			return fmt.Errorf("failed to update vspherebinding %q: %w", vspherebindingName, err)
+		}
+		logging.FromContext(ctx).Infof("Updated vspherebinding %q", vspherebindingName)
+	}
+
+	// Reflect the state of the VSphereBinding in the owner.
+	status.PropagateAuthStatus(vspherebinding.Status.Status)
+
+	return nil
+}
+
+// ReconcileDeployment reconciles the server's Deployment, and returns it so
+// that the caller can reflect its state in the status.
+func (r *Reconciler) ReconcileDeployment(ctx context.Context, s *resources.Server, status Status) (*appsv1.Deployment, error) {
+	ns := s.Namespace()
+	deploymentName := s.DeploymentName
+
+	deployment, err := r.DeploymentLister.Deployments(ns).Get(deploymentName)
+	if apierrs.IsNotFound(err) {
+		deployment = resources.MakeDeployment(ctx, s)
+		deployment, err = r.KubeClient.AppsV1().Deployments(ns).Create(deployment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create deployment %q: %w", deploymentName, err)
+		}
+		logging.FromContext(ctx).Infof("Created deployment %q", deploymentName)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %q: %w", deploymentName, err)
+	} else if !metav1.IsControlledBy(deployment, s.Owner.GetObjectMeta()) {
+		status.MarkResourceNotOwned("Deployment", deploymentName)
+		return nil, notOwned(s, "Deployment", deploymentName)
+	} else if desired := resources.MakeDeployment(ctx, s); resources.DeploymentDrifted(desired, deployment) {
+		// The deployment exists, but it has drifted from the shape that we expect.
+		deployment = deployment.DeepCopy()
+		deployment.Spec = desired.Spec
+		deployment, err = r.KubeClient.AppsV1().Deployments(ns).Update(deployment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update deployment %q: %w", deploymentName, err)
+		}
+		logging.FromContext(ctx).Infof("Updated deployment %q", deploymentName)
+	}
+
+	return deployment, nil
+}
+
+// ReconcileService reconciles the server's Service, and records its
+// address in the status.
+func (r *Reconciler) ReconcileService(ctx context.Context, s *resources.Server, status Status) error {
+	ns := s.Namespace()
+	serviceName := s.ServiceName
+
+	service, err := r.ServiceLister.Services(ns).Get(serviceName)
+	if apierrs.IsNotFound(err) {
+		service = resources.MakeService(ctx, s)
+		_, err = r.KubeClient.CoreV1().Services(ns).Create(service)
+		if err != nil {
+			return fmt.Errorf("failed to create service %q: %w", serviceName, err)
+		}
+		logging.FromContext(ctx).Infof("Created service %q", serviceName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get service %q: %w", serviceName, err)
+	} else if !metav1.IsControlledBy(service, s.Owner.GetObjectMeta()) {
+		status.MarkResourceNotOwned("Service", serviceName)
+		return notOwned(s, "Service", serviceName)
+	} else if desired := resources.MakeService(ctx, s); !equality.Semantic.DeepDerivative(desired.Spec, service.Spec) {
+		// The service exists, but it has drifted from the shape that we expect.
+		// The API server fills in its clusterIP, which we must keep.
+		service = service.DeepCopy()
+		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.Ports = desired.Spec.Ports
+		_, err = r.KubeClient.CoreV1().Services(ns).Update(service)
+		if err != nil {
+			return fmt.Errorf("failed to update service %q: %w", serviceName, err)
+		}
+		logging.FromContext(ctx).Infof("Updated service %q", serviceName)
+	}
+
+	status.SetAddress(&apis.URL{
+		Scheme: "http",
+		Host:   s.Hostname(),
+	})
+
+	return nil
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import (
+	"knative.dev/pkg/kmeta"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+)
+
+func Deployment(vs *v1alpha1.VSphereSink) string {
+	return kmeta.ChildName(vs.Name, "-deployment")
+}
+
+// Service returns the name of the Service addressing the receiver, which
+// is the host of the sink's address.
+func Service(vs *v1alpha1.VSphereSink) string {
+	return kmeta.ChildName(vs.Name, "-sink")
+}
+
+func VSphereBinding(vs *v1alpha1.VSphereSink) string {
+	return kmeta.ChildName(vs.Name, "-vspherebinding")
+}
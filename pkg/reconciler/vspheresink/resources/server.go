@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	serverresources "github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver/resources"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vspheresink/resources/names"
+)
+
+// Labels returns the labels of the receiver's pods, which its Service
+// selects.
+func Labels(vs *v1alpha1.VSphereSink) map[string]string {
+	return map[string]string{
+		v1alpha1.VSphereSinkLabelKey: vs.Name,
+	}
+}
+
+// MakeServer describes the receiver that records the events sent to the
+// sink.
+func MakeServer(vs *v1alpha1.VSphereSink, receiverImage string) *serverresources.Server {
+	return &serverresources.Server{
+		Owner:              vs,
+		VAuthSpec:          vs.Spec.VAuthSpec,
+		DeploymentName:     names.Deployment(vs),
+		ServiceName:        names.Service(vs),
+		VSphereBindingName: names.VSphereBinding(vs),
+		Labels:             Labels(vs),
+		ContainerName:      v1alpha1.ReceiverContainerName,
+		Image:              receiverImage,
+		Env: []corev1.EnvVar{{
+			Name:  "VSPHERE_ENTITY",
+			Value: vs.Spec.Entity,
+		}, {
+			Name:  "VSPHERE_RECORD_AS",
+			Value: string(vs.Spec.RecordAs),
+		}},
+	}
+}
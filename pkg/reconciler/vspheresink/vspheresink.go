@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vspheresink
+
+import (
+	"context"
+
+	"knative.dev/pkg/reconciler"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	vspheresinkreconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vspheresink"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vsphereserver"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vspheresink/resources"
+)
+
+// Reconciler implements vspheresinkreconciler.Interface for
+// VSphereSink resources.
+type Reconciler struct {
+	vsphereserver.Reconciler
+
+	receiverImage string
+}
+
+// Check that our Reconciler implements Interface
+var _ vspheresinkreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, vs *sourcesv1alpha1.VSphereSink) reconciler.Event {
+	vs.Status.InitializeConditions()
+
+	server := resources.MakeServer(vs, r.receiverImage)
+	if err := r.ReconcileVSphereBinding(ctx, server, &vs.Status); err != nil {
+		return err
+	}
+	deployment, err := r.ReconcileDeployment(ctx, server, &vs.Status)
+	if err != nil {
+		return err
+	}
+	// Reflect the state of the Receiver Deployment in the VSphereSink
+	vs.Status.PropagateReceiverStatus(deployment.Status)
+	if err := r.ReconcileService(ctx, server, &vs.Status); err != nil {
+		return err
+	}
+
+	vs.Status.ObservedGeneration = vs.Generation
+	return nil
+}
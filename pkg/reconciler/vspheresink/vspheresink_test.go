@@ -0,0 +1,253 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vspheresink
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	sourcesv1alpha1 "github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	fakeclient "github.com/mattmoor/vmware-sources/pkg/client/injection/client/fake"
+	vspheresinkreconciler "github.com/mattmoor/vmware-sources/pkg/client/injection/reconciler/sources/v1alpha1/vspheresink"
+	. "github.com/mattmoor/vmware-sources/pkg/reconciler/testing"
+	"github.com/mattmoor/vmware-sources/pkg/reconciler/vspheresink/resources"
+	resourcenames "github.com/mattmoor/vmware-sources/pkg/reconciler/vspheresink/resources/names"
+)
+
+const (
+	testNS        = "bar"
+	testName      = "foo"
+	testKey       = testNS + "/" + testName
+	receiverImage = "gcr.io/knative-sources/vsphere-sink"
+)
+
+var (
+	testSpec = sourcesv1alpha1.VSphereSinkSpec{
+		VAuthSpec: sourcesv1alpha1.VAuthSpec{
+			Address:   apis.URL{Scheme: "https", Host: "vcenter.local"},
+			SecretRef: corev1.LocalObjectReference{Name: "vsphere-credentials"},
+		},
+		Entity:   "/dc1/vm",
+		RecordAs: sourcesv1alpha1.RecordAsUserEvent,
+	}
+
+	sinkAddress = &apis.URL{Scheme: "http", Host: "foo-sink.bar.svc.cluster.local"}
+)
+
+// sink returns the VSphereSink under test, at generation 1.  Options that
+// change its spec go first, so that the options for its status can depend
+// upon it.
+func sink(o ...VSphereSinkOption) *sourcesv1alpha1.VSphereSink {
+	return NewVSphereSink(testName, testNS,
+		append([]VSphereSinkOption{
+			WithVSphereSinkSpec(*testSpec.DeepCopy()),
+			WithVSphereSinkGeneration(1),
+		}, o...)...)
+}
+
+func TestReconcile(t *testing.T) {
+	created := WithVSphereSinkStatus(WithServerCreated(sinkAddress))
+	ready := WithVSphereSinkStatus(WithServerReady(sinkAddress))
+	readySink := sink(ready)
+	readyServer := resources.MakeServer(readySink, receiverImage)
+	newServer := resources.MakeServer(sink(), receiverImage)
+
+	table := rtesting.TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "first reconcile creates the children",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			sink(),
+		},
+		WantCreates: []runtime.Object{
+			ServerVSphereBinding(newServer),
+			ServerDeployment(newServer),
+			ServerService(newServer),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(created)),
+		},
+	}, {
+		Name:    "steady state",
+		Key:     testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer)...),
+	}, {
+		Name:    "children become ready",
+		Key:     testKey,
+		Objects: append([]runtime.Object{sink(created)}, ServerChildren(readyServer)...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(ready)),
+		},
+	}, {
+		Name: "entity changes",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, func() runtime.Object {
+			d := AvailableDeployment(readyServer)
+			d.Spec.Template.Spec.Containers[0].Env[1].Value = "/dc1/host"
+			return d
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(AvailableDeployment(readyServer)),
+		},
+	}, {
+		Name: "binding's variables are not drift",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, func() runtime.Object {
+			d := AvailableDeployment(readyServer)
+			d.Spec.Template.Spec.Containers[0].Env = append(d.Spec.Template.Spec.Containers[0].Env,
+				corev1.EnvVar{Name: "GOVC_URL", Value: "vcenter.local"})
+			return d
+		}())...),
+	}, {
+		Name: "service drifts",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, func() runtime.Object {
+			svc := ServerService(readyServer)
+			svc.Spec.ClusterIP = "10.0.0.1"
+			svc.Spec.Ports[0].Port = 8080
+			return svc
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(func() runtime.Object {
+				svc := ServerService(readyServer)
+				// We keep what the API server filled in.
+				svc.Spec.ClusterIP = "10.0.0.1"
+				return svc
+			}()),
+		},
+	}, {
+		Name: "binding is missing its annotation",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, func() runtime.Object {
+			vsb := ReadyVSphereBinding(readyServer)
+			vsb.Annotations = nil
+			return vsb
+		}())...),
+		WantUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(ReadyVSphereBinding(readyServer)),
+		},
+	}, {
+		Name: "receiver becomes unavailable",
+		Key:  testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, func() runtime.Object {
+			d := ServerDeployment(readyServer)
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentAvailable,
+				Status:  corev1.ConditionFalse,
+				Reason:  "MinimumReplicasUnavailable",
+				Message: "Deployment does not have minimum availability.",
+			}}
+			return d
+		}())...),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(ready, WithVSphereSinkStatus(WithServerDeploymentStatus(appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  corev1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				}},
+			})))),
+		},
+	}, {
+		Name:    "vspherebinding not owned",
+		Key:     testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, NotOwned(ReadyVSphereBinding(readyServer)))...),
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(ready, WithVSphereSinkStatus(WithServerResourceNotOwned("VSphereBinding", resourcenames.VSphereBinding(readySink))))),
+		},
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+				`vspheresink "foo" does not own vspherebinding "foo-vspherebinding"`),
+		},
+	}, {
+		Name:    "deployment not owned",
+		Key:     testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, NotOwned(AvailableDeployment(readyServer)))...),
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(ready, WithVSphereSinkStatus(WithServerResourceNotOwned("Deployment", resourcenames.Deployment(readySink))))),
+		},
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+				`vspheresink "foo" does not own deployment "foo-deployment"`),
+		},
+	}, {
+		Name:    "service not owned",
+		Key:     testKey,
+		Objects: append([]runtime.Object{readySink}, ServerChildren(readyServer, NotOwned(ServerService(readyServer)))...),
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(ready, WithVSphereSinkStatus(WithServerResourceNotOwned("Service", resourcenames.Service(readySink))))),
+		},
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+				`vspheresink "foo" does not own service "foo-sink"`),
+		},
+	}, {
+		Name: "create deployment fails",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			sink(),
+			ReadyVSphereBinding(newServer),
+		},
+		WithReactors: []clientgotesting.ReactionFunc{
+			rtesting.InduceFailure("create", "deployments"),
+		},
+		WantErr: true,
+		WantCreates: []runtime.Object{
+			ServerDeployment(newServer),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+			NewUpdate(sink(WithVSphereSinkStatus(
+				WithInitServerConditions,
+				WithServerAuthStatus(duckv1.Status{Conditions: ReadyConditions})))),
+		},
+		WantEvents: []string{
+			rtesting.Eventf(corev1.EventTypeWarning, "InternalError",
+				`failed to create deployment "foo-deployment": inducing failure for create deployments`),
+		},
+	}}
+
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			Reconciler:    MakeServerReconciler(ctx, listers),
+			receiverImage: receiverImage,
+		}
+		return vspheresinkreconciler.NewReconciler(ctx, logging.FromContext(ctx), fakeclient.Get(ctx),
+			listers.GetVSphereSinkLister(), controller.GetEventRecorder(ctx), r)
+	}))
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package action implements the executor of a VSphereAction, which performs
+// an operation in vCenter on the entity concerned by each of the CloudEvents
+// sent to it, and replies with an event reporting how that went.
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/uuid"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/sink"
+)
+
+const (
+	// SucceededEventType is the type of the events replied for the
+	// operations that succeed, or would succeed in a dry run.
+	SucceededEventType = "dev.knative.sources.vsphereaction.succeeded"
+
+	// FailedEventType is the type of the events replied for the operations
+	// that fail.
+	FailedEventType = "dev.knative.sources.vsphereaction.failed"
+)
+
+// EnvConfig is the configuration of the executor, which the controller sets
+// from the VSphereAction's spec.
+type EnvConfig struct {
+	// Port is the port on which events are received.
+	Port int `envconfig:"PORT" default:"8080"`
+
+	// Source is the source of the result events, which names the action.
+	Source string `envconfig:"VSPHERE_ACTION_SOURCE" required:"true"`
+
+	// Filter is the JSON encoded v1alpha1.ActionFilter, if any.
+	Filter string `envconfig:"VSPHERE_FILTER"`
+
+	// Operation is the JSON encoded v1alpha1.ActionOperation.
+	Operation string `envconfig:"VSPHERE_OPERATION" required:"true"`
+
+	// Scope is the inventory path of the part of the inventory that the
+	// operation is confined to.
+	Scope string `envconfig:"VSPHERE_SCOPE" required:"true"`
+
+	// DryRun has the executor report what it would do, without doing it.
+	DryRun bool `envconfig:"VSPHERE_DRY_RUN"`
+}
+
+// Parse decodes the filter and operation.
+func (env *EnvConfig) Parse() (*v1alpha1.ActionFilter, *v1alpha1.ActionOperation, error) {
+	var af *v1alpha1.ActionFilter
+	if env.Filter != "" {
+		af = &v1alpha1.ActionFilter{}
+		if err := json.Unmarshal([]byte(env.Filter), af); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse filter: %w", err)
+		}
+	}
+	op := &v1alpha1.ActionOperation{}
+	if err := json.Unmarshal([]byte(env.Operation), op); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse operation: %w", err)
+	}
+	return af, op, nil
+}
+
+// Result is the data of the result events.
+type Result struct {
+	// Operation is the name of the operation, e.g. "attachTag".
+	Operation string `json:"operation"`
+
+	// Entity is the managed object reference of the entity that the
+	// operation was performed on, e.g. "VirtualMachine:vm-42", unless
+	// it couldn't be determined.
+	Entity string `json:"entity,omitempty"`
+
+	// DryRun is whether the operation was only checked.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Event identifies the event that the operation was performed for.
+	Event EventReference `json:"event"`
+
+	// Error is why the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// EventReference identifies a CloudEvent.
+type EventReference struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// Executor is an http.Handler that performs the action's operation for the
+// CloudEvents posted to it.
+type Executor struct {
+	Logger *zap.SugaredLogger
+	Client *vim25.Client
+
+	// Tags is used by the tag operations, and may be nil for the others.
+	Tags *tags.Manager
+
+	// Source is the source of the result events.
+	Source string
+
+	// Scope is the inventory path of the entity that the operation is
+	// confined to, along with the entities beneath it.
+	Scope string
+
+	Filter    *Filter
+	Operation v1alpha1.ActionOperation
+	DryRun    bool
+}
+
+var _ http.Handler = (*Executor)(nil)
+
+// ServeHTTP implements http.Handler
+func (e *Executor) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+	event, err := binding.ToEvent(ctx, cehttp.NewMessageFromHttpRequest(req))
+	if err == nil {
+		err = event.Validate()
+	}
+	if err != nil {
+		e.Logger.Infow("Rejecting malformed event", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ok, err := e.Filter.Matches(*event); err != nil {
+		// We can't tell whether the event would have been acted on, so
+		// rather than quietly drop it, we reject it for the sender to
+		// dead-letter.
+		e.Logger.Errorw("Failed to evaluate filter", zap.String("id", event.ID()), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if !ok {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	// Operations aren't generally idempotent (think snapshots), so rather
+	// than have the sender retry them, we report their failures in the
+	// result.
+	result, err := e.Execute(ctx, *event)
+	if err != nil {
+		e.Logger.Infow("Rejecting event", zap.String("id", event.ID()), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	reply, err := e.reply(result)
+	if err != nil {
+		e.Logger.Errorw("Failed to make result event", zap.String("id", event.ID()), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := cehttp.WriteResponseWriter(ctx, binding.ToMessage(&reply), http.StatusOK, w); err != nil {
+		e.Logger.Errorw("Failed to write result event", zap.String("id", event.ID()), zap.Error(err))
+	}
+}
+
+// Execute performs the operation on the entity concerned by the event,
+// which is the entity named by its sink.EntityExtension, or else the most
+// specific entity named by the vSphere event that it carries.  The result
+// reports whether the operation succeeded; an error is returned, and nothing
+// is done, for the entities outside of the executor's scope.
+func (e *Executor) Execute(ctx context.Context, event cloudevents.Event) (Result, error) {
+	result := Result{
+		Operation: Name(e.Operation),
+		DryRun:    e.DryRun,
+		Event: EventReference{
+			ID:     event.ID(),
+			Source: event.Source(),
+			Type:   event.Type(),
+		},
+	}
+	logger := e.Logger.With(zap.String("id", event.ID()), zap.String("operation", result.Operation))
+
+	ref, err := e.entity(ctx, event)
+	if err == nil {
+		result.Entity = ref.String()
+		logger = logger.With(zap.String("entity", result.Entity))
+		err = e.inScope(ctx, ref)
+		if _, ok := err.(outOfScopeError); ok {
+			return Result{}, err
+		}
+	}
+	if err == nil {
+		err = e.perform(ctx, event, ref)
+	}
+	switch {
+	case err != nil:
+		logger.Errorw("Operation failed", zap.Error(err))
+		result.Error = err.Error()
+	case e.DryRun:
+		logger.Info("Would perform operation")
+	default:
+		logger.Info("Performed operation")
+	}
+	return result, nil
+}
+
+// entity resolves the entity that the operation is performed on.
+func (e *Executor) entity(ctx context.Context, event cloudevents.Event) (types.ManagedObjectReference, error) {
+	if ext, ok := event.Extensions()[sink.EntityExtension]; ok {
+		return sink.FindEntity(ctx, e.Client, fmt.Sprint(ext))
+	}
+	if _, ok := events.TypeName(event); !ok {
+		return types.ManagedObjectReference{}, fmt.Errorf("event of type %q names no entity", event.Type())
+	}
+	be, err := events.Decode(event)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	ref := vsphere.AffectedEntity(be.GetEvent())
+	if ref == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("event of type %q names no entity", event.Type())
+	}
+	return *ref, nil
+}
+
+// outOfScopeError is returned for the entities outside of the executor's
+// scope.
+type outOfScopeError struct {
+	ref   types.ManagedObjectReference
+	scope string
+}
+
+func (err outOfScopeError) Error() string {
+	return fmt.Sprintf("%s is not within %q", err.ref, err.scope)
+}
+
+// inScope checks that the entity is the executor's scope, or beneath it in
+// the inventory.
+func (e *Executor) inScope(ctx context.Context, ref types.ManagedObjectReference) error {
+	scope, err := sink.FindEntity(ctx, e.Client, e.Scope)
+	if err != nil {
+		return fmt.Errorf("failed to find scope: %w", err)
+	}
+	// The ancestors run from the root folder down to the entity itself.
+	ancestors, err := mo.Ancestors(ctx, e.Client, e.Client.ServiceContent.PropertyCollector, ref)
+	if err != nil {
+		return fmt.Errorf("failed to get the ancestors of %s: %w", ref, err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.Self == scope {
+			return nil
+		}
+	}
+	return outOfScopeError{ref: ref, scope: e.Scope}
+}
+
+// perform performs the operation on the entity, having checked that it can,
+// unless this is a dry run.
+func (e *Executor) perform(ctx context.Context, event cloudevents.Event, ref types.ManagedObjectReference) error {
+	op := e.Operation
+	switch {
+	case op.AttachTag != nil:
+		tag, err := e.tag(ctx, op.AttachTag)
+		if err != nil || e.DryRun {
+			return err
+		}
+		return e.Tags.AttachTag(ctx, tag.ID, ref)
+
+	case op.DetachTag != nil:
+		tag, err := e.tag(ctx, op.DetachTag)
+		if err != nil || e.DryRun {
+			return err
+		}
+		return e.Tags.DetachTag(ctx, tag.ID, ref)
+
+	case op.SetCustomAttribute != nil:
+		cfm, err := object.GetCustomFieldsManager(e.Client)
+		if err != nil {
+			return err
+		}
+		key, err := cfm.FindKey(ctx, op.SetCustomAttribute.Name)
+		if err != nil {
+			return fmt.Errorf("failed to find custom attribute %q: %w", op.SetCustomAttribute.Name, err)
+		}
+		if e.DryRun {
+			return nil
+		}
+		return cfm.Set(ctx, ref, key, op.SetCustomAttribute.Value)
+
+	case op.PowerOn != nil:
+		vm, err := e.vm(ref)
+		if err != nil || e.DryRun {
+			return err
+		}
+		return wait(ctx)(vm.PowerOn(ctx))
+
+	case op.PowerOff != nil:
+		vm, err := e.vm(ref)
+		if err != nil || e.DryRun {
+			return err
+		}
+		return wait(ctx)(vm.PowerOff(ctx))
+
+	case op.Snapshot != nil:
+		vm, err := e.vm(ref)
+		if err != nil || e.DryRun {
+			return err
+		}
+		name := op.Snapshot.Name
+		if name == "" {
+			name = event.ID()
+		}
+		return wait(ctx)(vm.CreateSnapshot(ctx, name, op.Snapshot.Description, op.Snapshot.Memory, op.Snapshot.Quiesce))
+
+	case op.Migrate != nil:
+		vm, err := e.vm(ref)
+		if err != nil {
+			return err
+		}
+		spec, err := e.relocateSpec(ctx, op.Migrate)
+		if err != nil || e.DryRun {
+			return err
+		}
+		return wait(ctx)(vm.Relocate(ctx, spec, types.VirtualMachineMovePriorityDefaultPriority))
+
+	case op.Annotate != nil:
+		vm, err := e.vm(ref)
+		if err != nil || e.DryRun {
+			return err
+		}
+		annotation := op.Annotate.Annotation
+		if annotation == "" {
+			annotation = sink.Message(event)
+		}
+		return wait(ctx)(vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{Annotation: annotation}))
+
+	default:
+		return errors.New("no operation is set")
+	}
+}
+
+// tag looks up the tag of the operation.
+func (e *Executor) tag(ctx context.Context, ta *v1alpha1.TagAction) (*tags.Tag, error) {
+	if e.Tags == nil {
+		return nil, errors.New("no tag manager is configured")
+	}
+	tag, err := e.Tags.GetTagForCategory(ctx, ta.Tag, ta.Category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tag %q: %w", ta.Tag, err)
+	}
+	return tag, nil
+}
+
+// vm returns the VM to operate on, checking that the entity is one.
+func (e *Executor) vm(ref types.ManagedObjectReference) (*object.VirtualMachine, error) {
+	if ref.Type != "VirtualMachine" {
+		return nil, fmt.Errorf("%s is not a VirtualMachine", ref)
+	}
+	return object.NewVirtualMachine(e.Client, ref), nil
+}
+
+// relocateSpec resolves the inventory paths that VMs are migrated to.
+func (e *Executor) relocateSpec(ctx context.Context, ma *v1alpha1.MigrateAction) (types.VirtualMachineRelocateSpec, error) {
+	var spec types.VirtualMachineRelocateSpec
+	for _, target := range []struct {
+		path string
+		ref  **types.ManagedObjectReference
+	}{
+		{ma.Host, &spec.Host},
+		{ma.ResourcePool, &spec.Pool},
+		{ma.Datastore, &spec.Datastore},
+	} {
+		if target.path == "" {
+			continue
+		}
+		ref, err := sink.FindEntity(ctx, e.Client, target.path)
+		if err != nil {
+			return spec, err
+		}
+		*target.ref = &ref
+	}
+	return spec, nil
+}
+
+// wait returns a function that waits for the task that it is passed to
+// complete.
+func wait(ctx context.Context) func(*object.Task, error) error {
+	return func(task *object.Task, err error) error {
+		if err != nil {
+			return err
+		}
+		return task.Wait(ctx)
+	}
+}
+
+// reply makes the event reporting the result.
+func (e *Executor) reply(result Result) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(uuid.New().String())
+	event.SetSource(e.Source)
+	event.SetSubject(result.Entity)
+	if result.Error != "" {
+		event.SetType(FailedEventType)
+	} else {
+		event.SetType(SucceededEventType)
+	}
+	err := event.SetData(cloudevents.ApplicationJSON, result)
+	return event, err
+}
+
+// Name returns the name of the operation, as it appears in the spec of a
+// VSphereAction.
+func Name(op v1alpha1.ActionOperation) string {
+	switch {
+	case op.AttachTag != nil:
+		return "attachTag"
+	case op.DetachTag != nil:
+		return "detachTag"
+	case op.SetCustomAttribute != nil:
+		return "setCustomAttribute"
+	case op.PowerOn != nil:
+		return "powerOn"
+	case op.PowerOff != nil:
+		return "powerOff"
+	case op.Snapshot != nil:
+		return "snapshot"
+	case op.Migrate != nil:
+		return "migrate"
+	case op.Annotate != nil:
+		return "annotate"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/vspheretest"
+
+	_ "github.com/vmware/govmomi/vapi/simulator"
+)
+
+const source = "/apis/v1/namespaces/default/vsphereactions/act"
+
+func TestEnvConfig(t *testing.T) {
+	env := EnvConfig{
+		Filter:    `{"attributes":{"type":"com.vmware.vsphere.VmCreatedEvent"}}`,
+		Operation: `{"attachTag":{"tag":"prod","category":"env"}}`,
+	}
+	af, op, err := env.Parse()
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	if got, want := af.Attributes["type"], "com.vmware.vsphere.VmCreatedEvent"; got != want {
+		t.Errorf("Attributes[type] = %q, wanted %q", got, want)
+	}
+	if op.AttachTag == nil || op.AttachTag.Tag != "prod" || op.AttachTag.Category != "env" {
+		t.Errorf("AttachTag = %v, wanted prod in env", op.AttachTag)
+	}
+	if got, want := Name(*op), "attachTag"; got != want {
+		t.Errorf("Name() = %q, wanted %q", got, want)
+	}
+
+	env.Filter = ""
+	if af, _, err := env.Parse(); err != nil {
+		t.Errorf("Parse() = %v", err)
+	} else if af != nil {
+		t.Errorf("Parse() = %v, wanted no filter", af)
+	}
+
+	env.Operation = "{"
+	if _, _, err := env.Parse(); err == nil {
+		t.Error("Parse() = nil, wanted an error")
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	rc := rest.NewClient(sim.Client.Client)
+	if err := rc.Login(ctx, url.UserPassword(vspheretest.Username, vspheretest.Password)); err != nil {
+		t.Fatalf("Login() = %v", err)
+	}
+	defer rc.Logout(ctx)
+	tm := tags.NewManager(rc)
+	catID, err := tm.CreateCategory(ctx, &tags.Category{Name: "env"})
+	if err != nil {
+		t.Fatalf("CreateCategory() = %v", err)
+	}
+	if _, err := tm.CreateTag(ctx, &tags.Tag{Name: "prod", CategoryID: catID}); err != nil {
+		t.Fatalf("CreateTag() = %v", err)
+	}
+	cfm := object.NewCustomFieldsManager(sim.Client.Client)
+	if _, err := cfm.Add(ctx, "owner", "VirtualMachine", nil, nil); err != nil {
+		t.Fatalf("Add() = %v", err)
+	}
+
+	vm0 := sim.VM(ctx, "DC0_H0_VM0")
+	vm1 := sim.VM(ctx, "DC0_H0_VM1")
+	target, err := sim.Finder(ctx).HostSystem(ctx, "/DC0/host/DC0_C0/DC0_C0_H0")
+	if err != nil {
+		t.Fatalf("HostSystem() = %v", err)
+	}
+
+	vmProperties := func(t *testing.T, vm *object.VirtualMachine, props ...string) mo.VirtualMachine {
+		t.Helper()
+		var mvm mo.VirtualMachine
+		if err := vm.Properties(ctx, vm.Reference(), props, &mvm); err != nil {
+			t.Fatalf("Properties() = %v", err)
+		}
+		return mvm
+	}
+	wantPowerState := func(t *testing.T, vm *object.VirtualMachine, want types.VirtualMachinePowerState) {
+		t.Helper()
+		if got := vmProperties(t, vm, "runtime.powerState").Runtime.PowerState; got != want {
+			t.Errorf("PowerState = %s, wanted %s", got, want)
+		}
+	}
+	wantTagged := func(t *testing.T, vm *object.VirtualMachine, want bool) {
+		t.Helper()
+		attached, err := tm.GetAttachedTags(ctx, vm.Reference())
+		if err != nil {
+			t.Fatalf("GetAttachedTags() = %v", err)
+		}
+		if got := len(attached) == 1 && attached[0].Name == "prod"; got != want {
+			t.Errorf("tagged = %v (%v), wanted %v", got, attached, want)
+		}
+	}
+
+	// poweredOn is a vSphere event about VM0, as the VSphereSource sends.
+	poweredOn := cloudevents.NewEvent()
+	poweredOn.SetID("42")
+	poweredOn.SetSource(sim.URL)
+	poweredOn.SetType(events.Type(&types.VmPoweredOnEvent{}))
+	if err := poweredOn.SetData(cloudevents.ApplicationXML, &types.VmPoweredOnEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Key: 42,
+				Vm: &types.VmEventArgument{
+					EntityEventArgument: types.EntityEventArgument{Name: "DC0_H0_VM0"},
+					Vm:                  vm0.Reference(),
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("SetData() = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		operation v1alpha1.ActionOperation
+		filter    *v1alpha1.ActionFilter
+		scope     string
+		dryRun    bool
+		noTags    bool
+		entity    string
+		event     *cloudevents.Event
+		method    string
+		body      string
+		want      int
+		wantType  string
+		wantError string
+		check     func(*testing.T, Result)
+	}{{
+		name:      "attach tag",
+		operation: v1alpha1.ActionOperation{AttachTag: &v1alpha1.TagAction{Tag: "prod", Category: "env"}},
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			if got, want := r.Entity, vm0.Reference().String(); got != want {
+				t.Errorf("Entity = %q, wanted %q", got, want)
+			}
+			wantTagged(t, vm0, true)
+		},
+	}, {
+		name:      "attach tag, dry run",
+		operation: v1alpha1.ActionOperation{AttachTag: &v1alpha1.TagAction{Tag: "prod", Category: "env"}},
+		dryRun:    true,
+		entity:    vm1.Reference().String(),
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			if !r.DryRun {
+				t.Error("DryRun = false, wanted true")
+			}
+			wantTagged(t, vm1, false)
+		},
+	}, {
+		name:      "detach tag",
+		operation: v1alpha1.ActionOperation{DetachTag: &v1alpha1.TagAction{Tag: "prod"}},
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			wantTagged(t, vm0, false)
+		},
+	}, {
+		name:      "unknown tag",
+		operation: v1alpha1.ActionOperation{AttachTag: &v1alpha1.TagAction{Tag: "staging"}},
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: `failed to find tag "staging"`,
+	}, {
+		name:      "no tag manager",
+		operation: v1alpha1.ActionOperation{AttachTag: &v1alpha1.TagAction{Tag: "prod"}},
+		noTags:    true,
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: "no tag manager is configured",
+	}, {
+		name:      "set custom attribute",
+		operation: v1alpha1.ActionOperation{SetCustomAttribute: &v1alpha1.CustomAttributeAction{Name: "owner", Value: "team-x"}},
+		entity:    "/DC0/vm/DC0_H0_VM1",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			values := vmProperties(t, vm1, "customValue").CustomValue
+			if len(values) != 1 || values[0].(*types.CustomFieldStringValue).Value != "team-x" {
+				t.Errorf("CustomValue = %v, wanted owner=team-x", values)
+			}
+		},
+	}, {
+		name:      "unknown custom attribute",
+		operation: v1alpha1.ActionOperation{SetCustomAttribute: &v1alpha1.CustomAttributeAction{Name: "cost-center"}},
+		entity:    "/DC0/vm/DC0_H0_VM1",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: `failed to find custom attribute "cost-center"`,
+	}, {
+		name:      "power off, dry run",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		dryRun:    true,
+		event:     &poweredOn,
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "power off the vSphere event's VM",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		event:     &poweredOn,
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			if got, want := r.Entity, vm0.Reference().String(); got != want {
+				t.Errorf("Entity = %q, wanted %q", got, want)
+			}
+			if got, want := r.Event.ID, "42"; got != want {
+				t.Errorf("Event.ID = %q, wanted %q", got, want)
+			}
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOff)
+		},
+	}, {
+		name:      "power on",
+		operation: v1alpha1.ActionOperation{PowerOn: &v1alpha1.PowerAction{}},
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "snapshot",
+		operation: v1alpha1.ActionOperation{Snapshot: &v1alpha1.SnapshotAction{Description: "before the upgrade"}},
+		entity:    "/DC0/vm/DC0_H0_VM1",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			// Snapshots are named after the event by default.
+			if _, err := vm1.FindSnapshot(ctx, "1234"); err != nil {
+				t.Errorf("FindSnapshot() = %v", err)
+			}
+		},
+	}, {
+		name:      "migrate",
+		operation: v1alpha1.ActionOperation{Migrate: &v1alpha1.MigrateAction{Host: "/DC0/host/DC0_C0/DC0_C0_H0"}},
+		entity:    "/DC0/vm/DC0_H0_VM1",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			if got := vmProperties(t, vm1, "runtime.host").Runtime.Host; got == nil || *got != target.Reference() {
+				t.Errorf("Host = %v, wanted %v", got, target.Reference())
+			}
+		},
+	}, {
+		name:      "migrate to nowhere",
+		operation: v1alpha1.ActionOperation{Migrate: &v1alpha1.MigrateAction{Datastore: "/DC0/datastore/nope"}},
+		entity:    "/DC0/vm/DC0_H0_VM1",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: `no entity found at inventory path "/DC0/datastore/nope"`,
+	}, {
+		name:      "annotate with the event's message",
+		operation: v1alpha1.ActionOperation{Annotate: &v1alpha1.AnnotateAction{}},
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+		check: func(t *testing.T, r Result) {
+			if got, want := vmProperties(t, vm0, "config.annotation").Config.Annotation, "deployed v1.2.3"; got != want {
+				t.Errorf("Annotation = %q, wanted %q", got, want)
+			}
+		},
+	}, {
+		name:      "not a VM",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		entity:    "/DC0/host/DC0_H0",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: "is not a VirtualMachine",
+	}, {
+		name:      "no entity",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: `event of type "dev.example.deployed" names no entity`,
+		check: func(t *testing.T, r Result) {
+			if r.Entity != "" {
+				t.Errorf("Entity = %q, wanted none", r.Entity)
+			}
+		},
+	}, {
+		name:      "filtered out",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"type": "com.vmware.vsphere.VmPoweredOnEvent"},
+		},
+		entity: "/DC0/vm/DC0_H0_VM0",
+		want:   http.StatusAccepted,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "within a narrower scope",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		dryRun:    true,
+		scope:     "/DC0/vm",
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  SucceededEventType,
+	}, {
+		name:      "outside the scope",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		scope:     "/DC0/host",
+		event:     &poweredOn,
+		want:      http.StatusForbidden,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "missing scope",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		scope:     "/DC1",
+		entity:    "/DC0/vm/DC0_H0_VM0",
+		want:      http.StatusOK,
+		wantType:  FailedEventType,
+		wantError: `failed to find scope: no entity found at inventory path "/DC1"`,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "filter fails to evaluate",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		filter:    &v1alpha1.ActionFilter{Expression: `event.UserName != "automation"`},
+		event: func() *cloudevents.Event {
+			event := cloudevents.NewEvent()
+			event.SetID("1234")
+			event.SetType("com.vmware.vsphere.VmPoweredOnEvent")
+			event.SetSource("https://vcenter.local/sdk")
+			if err := event.SetData(cloudevents.ApplicationJSON, "not a vSphere event"); err != nil {
+				t.Fatalf("SetData() = %v", err)
+			}
+			return &event
+		}(),
+		entity: "/DC0/vm/DC0_H0_VM0",
+		want:   http.StatusBadRequest,
+		check: func(t *testing.T, r Result) {
+			wantPowerState(t, vm0, types.VirtualMachinePowerStatePoweredOn)
+		},
+	}, {
+		name:      "not a cloudevent",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		body:      `{"message": "deployed v1.2.3"}`,
+		want:      http.StatusBadRequest,
+	}, {
+		name:      "not a POST",
+		operation: v1alpha1.ActionOperation{PowerOff: &v1alpha1.PowerAction{}},
+		method:    http.MethodGet,
+		want:      http.StatusMethodNotAllowed,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := NewFilter(test.filter)
+			if err != nil {
+				t.Fatalf("NewFilter() = %v", err)
+			}
+			e := &Executor{
+				Logger:    zap.NewNop().Sugar(),
+				Client:    sim.Client.Client,
+				Tags:      tm,
+				Source:    source,
+				Scope:     "/DC0",
+				Filter:    filter,
+				Operation: test.operation,
+				DryRun:    test.dryRun,
+			}
+			if test.noTags {
+				e.Tags = nil
+			}
+			if test.scope != "" {
+				e.Scope = test.scope
+			}
+			server := httptest.NewServer(e)
+			defer server.Close()
+
+			method := test.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req, err := http.NewRequest(method, server.URL, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("NewRequest() = %v", err)
+			}
+			if test.body == "" && method == http.MethodPost {
+				event := cloudevents.NewEvent()
+				if test.event != nil {
+					event = *test.event
+				} else {
+					event.SetID("1234")
+					event.SetType("dev.example.deployed")
+					event.SetSource("https://ci.example.com")
+					if err := event.SetData(cloudevents.ApplicationJSON, map[string]string{"message": "deployed v1.2.3"}); err != nil {
+						t.Fatalf("SetData() = %v", err)
+					}
+				}
+				if test.entity != "" {
+					event.SetExtension("vsphereentity", test.entity)
+				}
+				if err := cehttp.WriteRequest(ctx, binding.ToMessage(&event), req); err != nil {
+					t.Fatalf("WriteRequest() = %v", err)
+				}
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() = %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != test.want {
+				t.Fatalf("StatusCode = %d, wanted %d", resp.StatusCode, test.want)
+			}
+
+			var result Result
+			if test.wantType != "" {
+				reply, err := binding.ToEvent(ctx, cehttp.NewMessageFromHttpResponse(resp))
+				if err != nil {
+					t.Fatalf("ToEvent() = %v", err)
+				}
+				if got := reply.Type(); got != test.wantType {
+					t.Errorf("Type() = %q, wanted %q", got, test.wantType)
+				}
+				if got := reply.Source(); got != source {
+					t.Errorf("Source() = %q, wanted %q", got, source)
+				}
+				if err := json.Unmarshal(reply.Data(), &result); err != nil {
+					t.Fatalf("Unmarshal() = %v", err)
+				}
+				if got, want := result.Operation, Name(test.operation); got != want {
+					t.Errorf("Operation = %q, wanted %q", got, want)
+				}
+				if got := reply.Subject(); got != result.Entity {
+					t.Errorf("Subject() = %q, wanted %q", got, result.Entity)
+				}
+				if !strings.Contains(result.Error, test.wantError) || (test.wantError == "") != (result.Error == "") {
+					t.Errorf("Error = %q, wanted %q", result.Error, test.wantError)
+				}
+			}
+			if test.check != nil {
+				test.check(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+)
+
+// Filter selects the events on which an action is taken.
+type Filter struct {
+	attributes map[string]string
+	expression *expr.Filter
+}
+
+// NewFilter compiles the action's filter.  A nil filter matches every
+// event.
+func NewFilter(af *v1alpha1.ActionFilter) (*Filter, error) {
+	f := &Filter{}
+	if af == nil {
+		return f, nil
+	}
+	f.attributes = af.Attributes
+	if af.Expression != "" {
+		ef, err := expr.NewFilter(af.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile expression: %w", err)
+		}
+		f.expression = ef
+	}
+	return f, nil
+}
+
+// Matches returns whether the event matches the filter.  Expressions can
+// only match the events that carry a vSphere event.  A nil Filter matches
+// every event.
+func (f *Filter) Matches(event cloudevents.Event) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	for name, want := range f.attributes {
+		if got, ok := attribute(event, name); !ok || got != want {
+			return false, nil
+		}
+	}
+	if f.expression == nil {
+		return true, nil
+	}
+	if _, ok := events.TypeName(event); !ok {
+		return false, nil
+	}
+	be, err := events.Decode(event)
+	if err != nil {
+		return false, err
+	}
+	in, err := expr.NewInput(be)
+	if err != nil {
+		return false, err
+	}
+	return f.expression.Matches(in)
+}
+
+// attribute returns the value of the named attribute or extension of the
+// event, as a Trigger's filter sees it.
+func attribute(event cloudevents.Event, name string) (string, bool) {
+	switch name {
+	case "specversion":
+		return event.SpecVersion(), true
+	case "type":
+		return event.Type(), true
+	case "source":
+		return event.Source(), true
+	case "subject":
+		return event.Subject(), event.Subject() != ""
+	case "id":
+		return event.ID(), true
+	case "datacontenttype":
+		return event.DataContentType(), event.DataContentType() != ""
+	case "dataschema":
+		return event.DataSchema(), event.DataSchema() != ""
+	}
+	ext, ok := event.Extensions()[name]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(ext), true
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/mattmoor/vmware-sources/pkg/apis/sources/v1alpha1"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+)
+
+func TestFilter(t *testing.T) {
+	created := cloudevents.NewEvent()
+	created.SetType(events.Type(&types.VmCreatedEvent{}))
+	created.SetSource("https://vcenter.local/sdk")
+	created.SetID("42")
+	created.SetExtension("vsphereeventtypeid", "")
+	if err := created.SetData(cloudevents.ApplicationXML, &types.VmCreatedEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Key:      42,
+				UserName: "administrator",
+			},
+		},
+	}); err != nil {
+		t.Fatalf("SetData() = %v", err)
+	}
+
+	deployed := cloudevents.NewEvent()
+	deployed.SetType("dev.example.deployed")
+	deployed.SetSource("https://ci.example.com")
+	deployed.SetID("1234")
+	deployed.SetExtension("vsphereentity", "/DC0/vm/DC0_H0_VM0")
+
+	tests := []struct {
+		name   string
+		filter *v1alpha1.ActionFilter
+		event  cloudevents.Event
+		want   bool
+	}{{
+		name:  "no filter",
+		event: deployed,
+		want:  true,
+	}, {
+		name: "type matches",
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"type": "com.vmware.vsphere.VmCreatedEvent"},
+		},
+		event: created,
+		want:  true,
+	}, {
+		name: "type doesn't match",
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"type": "com.vmware.vsphere.VmCreatedEvent"},
+		},
+		event: deployed,
+	}, {
+		name: "extension matches",
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"vsphereentity": "/DC0/vm/DC0_H0_VM0"},
+		},
+		event: deployed,
+		want:  true,
+	}, {
+		name: "missing subject",
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"subject": ""},
+		},
+		event: deployed,
+	}, {
+		name: "expression matches",
+		filter: &v1alpha1.ActionFilter{
+			Attributes: map[string]string{"source": "https://vcenter.local/sdk"},
+			Expression: `event.UserName == "administrator"`,
+		},
+		event: created,
+		want:  true,
+	}, {
+		name: "expression doesn't match",
+		filter: &v1alpha1.ActionFilter{
+			Expression: `event.UserName == "root"`,
+		},
+		event: created,
+	}, {
+		name: "expression on an event from elsewhere",
+		filter: &v1alpha1.ActionFilter{
+			Expression: `true`,
+		},
+		event: deployed,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewFilter(test.filter)
+			if err != nil {
+				t.Fatalf("NewFilter() = %v", err)
+			}
+			got, err := f.Matches(test.event)
+			if err != nil {
+				t.Fatalf("Matches() = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Matches() = %v, wanted %v", got, test.want)
+			}
+		})
+	}
+}
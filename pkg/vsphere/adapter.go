@@ -19,25 +19,99 @@ package vsphere
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"os"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/types"
 	"go.uber.org/zap"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/kvstore"
 	"knative.dev/pkg/logging"
+)
+
+// EventTypeIDExtension is the CloudEvent extension that carries the
+// eventTypeId of EventEx and ExtendedEvent events.
+const EventTypeIDExtension = "eventtypeid"
 
-	kubeclient "knative.dev/pkg/client/injection/kube/client"
+// ReplyToExtension is the CloudEvent extension that carries the ID of the
+// event that a sink replied to on the reply, as it is forwarded to the
+// reply sink.
+const ReplyToExtension = "vsphereeventid"
+
+// selectorRetryInterval is how often the adapter retries priming its
+// selector when vCenter's tagging service is unavailable on startup, and
+// selectorAttempts is how many times it tries before it exits, so that a
+// selector that can never be primed surfaces as a crashing adapter.
+const (
+	selectorRetryInterval = 10 * time.Second
+	selectorAttempts      = 30
 )
 
 type envConfig struct {
 	adapter.EnvConfig
 
-	// The name of the configmap to use as our kvstore.
-	KVConfigMap string `envconfig:"VSPHERE_KVSTORE_CONFIGMAP" required:"true"`
+	// KVStore selects where the adapter keeps its checkpoint, as a URL
+	// whose scheme is one of KVStoreConfigMap (the default), KVStoreFile or
+	// KVStoreMemory.
+	KVStore string `envconfig:"VSPHERE_KVSTORE"`
+
+	// The name of the configmap to use as our kvstore, unless KVStore
+	// names one.
+	KVConfigMap string `envconfig:"VSPHERE_KVSTORE_CONFIGMAP"`
+
+	// Selector is the JSON encoded EntitySelector used to select the
+	// events that are sent.
+	Selector string `envconfig:"VSPHERE_SELECTOR"`
+
+	// SelectorRefreshInterval is how often the tag associations used to
+	// evaluate Selector are refreshed.
+	SelectorRefreshInterval time.Duration `envconfig:"VSPHERE_SELECTOR_REFRESH_INTERVAL" default:"5m"`
+
+	// Filter is the CEL expression used to select the events that are sent.
+	Filter string `envconfig:"VSPHERE_FILTER"`
+
+	// Transform is the JSON encoded EventTransform used to reshape the
+	// events that are sent.
+	Transform string `envconfig:"VSPHERE_TRANSFORM"`
+
+	// Routes is the JSON encoded list of routes, with resolved sinks, to
+	// which matching events are sent instead of the default sink.
+	Routes string `envconfig:"VSPHERE_ROUTES"`
+
+	// ReplySink is the resolved URI to which the events with which sinks
+	// reply are forwarded.  Replies are dropped when it is empty.
+	ReplySink string `envconfig:"VSPHERE_REPLY_SINK"`
+
+	// Scope is the inventory path of the entity whose events (and those of
+	// the entities beneath it) are sent, rather than the root folder's.
+	Scope string `envconfig:"VSPHERE_SCOPE"`
+
+	// Delivery is the JSON encoded DeliverySpec configuring how sending
+	// events is retried.
+	Delivery string `envconfig:"VSPHERE_DELIVERY"`
+
+	// HeartbeatInterval is how often the adapter reports its progress.
+	HeartbeatInterval time.Duration `envconfig:"VSPHERE_HEARTBEAT_INTERVAL" default:"30s"`
+
+	// Record is the path of a file to which the events that the adapter
+	// receives are appended, as RecordedEvent JSON lines.
+	Record string `envconfig:"VSPHERE_RECORD"`
+
+	// Replay is the path of a recording that the adapter plays back as its
+	// event stream, rather than connecting to a vCenter.
+	Replay string `envconfig:"VSPHERE_REPLAY"`
+
+	// ReplaySpeed is how many times faster than they originally happened
+	// the recorded events are played back, or zero for without pausing.
+	ReplaySpeed float64 `envconfig:"VSPHERE_REPLAY_SPEED" default:"1"`
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -52,6 +126,53 @@ type vAdapter struct {
 	VClient   *govmomi.Client
 	CEClient  cloudevents.Client
 	KVStore   kvstore.Interface
+
+	// ConfigMaps and ConfigMapName identify the ConfigMap backing KVStore,
+	// through which the adapter reports state back to the controller.
+	ConfigMaps    corev1client.ConfigMapInterface
+	ConfigMapName string
+
+	// Filter restricts the events sent to those whose entity matches
+	// the source's selector, when one was specified.
+	Filter                *entityFilter
+	FilterRefreshInterval time.Duration
+
+	// EventFilter and Transform are the source's compiled expressions, and
+	// Expressions records the outcome of evaluating them.
+	EventFilter *expr.Filter
+	Transform   *expr.Transform
+	Expressions *expressionReporter
+
+	// Routes send the events matching their filters to their own sinks,
+	// rather than to the default sink.
+	Routes []route
+
+	// ReplySink is where the replies of sinks are forwarded, if anywhere.
+	ReplySink string
+
+	// Scope is the inventory path of the entity whose events are sent, or
+	// empty for the root folder.
+	Scope string
+
+	// Retries is how sending events that their sink rejects is retried,
+	// if at all.
+	Retries *retryPolicy
+
+	// Checkpoint is the last event that the adapter has handled, which is
+	// saved to KVStore after each batch of events when it has moved.
+	Checkpoint      *Checkpoint
+	checkpointDirty bool
+
+	// Progress is reported back to the controller every HeartbeatInterval.
+	Progress          *progressReporter
+	HeartbeatInterval time.Duration
+
+	// Recorder records the events received, when recording.
+	Recorder *recorder
+
+	// Replay plays a recording back in place of the vCenter's event stream,
+	// when VClient is nil.
+	Replay *replayer
 }
 
 func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClient cloudevents.Client) adapter.Adapter {
@@ -59,30 +180,137 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 
 	logger := logging.FromContext(ctx)
 
-	vClient, err := New(ctx)
-	if err != nil {
-		logger.Fatalf("Unable to create vSphere client: %v", err)
+	var (
+		vClient  *govmomi.Client
+		source   string
+		replay   *replayer
+		store    kvstore.Interface
+		cmClient corev1client.ConfigMapInterface
+		cmName   string
+		err      error
+	)
+	if env.Replay != "" {
+		// Replaying only needs the recording, and must not disturb the
+		// checkpoint of a source.
+		f, err := os.Open(env.Replay)
+		if err != nil {
+			logger.Fatalf("Unable to open recording: %v", err)
+		}
+		replay = newReplayer(f, env.ReplaySpeed)
+		store = newMemoryKVStore()
+	} else {
+		vClient, err = New(ctx)
+		if err != nil {
+			logger.Fatalf("Unable to create vSphere client: %v", err)
+		}
+
+		source, err = Address(ctx)
+		if err != nil {
+			logger.Fatalf("Unable to determine source: %v", err)
+		}
+
+		store, cmClient, cmName, err = newKVStore(ctx, env)
+		if err != nil {
+			logger.Fatalf("couldn't initialize kv store: %v", err)
+		}
 	}
 
-	source, err := Address(ctx)
-	if err != nil {
-		logger.Fatalf("Unable to determine source: %v", err)
+	var rec *recorder
+	if env.Record != "" {
+		f, err := os.OpenFile(env.Record, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			logger.Fatalf("Unable to open recording: %v", err)
+		}
+		rec = newRecorder(f)
 	}
 
-	store := kvstore.NewConfigMapKVStore(ctx, env.KVConfigMap, env.Namespace, kubeclient.Get(ctx).CoreV1())
-	err = store.Init(ctx)
-	if err != nil {
-		logger.Fatalf("couldn't initialize kv store: %v", err)
+	var filter *entityFilter
+	if env.Selector != "" {
+		if replay != nil {
+			logger.Fatal("Selectors need a vCenter to look up entities, so can't be used when replaying")
+		}
+		sel, err := parseSelector(env.Selector)
+		if err != nil {
+			logger.Fatalf("Unable to parse selector: %v", err)
+		}
+		var tm *tags.Manager
+		if len(sel.Tags) > 0 {
+			restClient, err := NewREST(ctx)
+			if err != nil {
+				logger.Fatalf("Unable to create vSphere REST client: %v", err)
+			}
+			tm = tags.NewManager(restClient)
+		}
+		filter, err = newEntityFilter(sel, vClient.Client, tm)
+		if err != nil {
+			logger.Fatalf("Unable to create selector: %v", err)
+		}
+	}
+
+	var eventFilter *expr.Filter
+	if env.Filter != "" {
+		eventFilter, err = expr.NewFilter(env.Filter)
+		if err != nil {
+			logger.Fatalf("Unable to compile filter: %v", err)
+		}
+	}
+	var transform *expr.Transform
+	if env.Transform != "" {
+		transform, err = parseTransform(env.Transform)
+		if err != nil {
+			logger.Fatalf("Unable to compile transform: %v", err)
+		}
+	}
+	var routes []route
+	if env.Routes != "" {
+		routes, err = parseRoutes(env.Routes)
+		if err != nil {
+			logger.Fatalf("Unable to compile routes: %v", err)
+		}
+	}
+
+	var retries *retryPolicy
+	if env.Delivery != "" {
+		retries, err = parseDelivery(env.Delivery)
+		if err != nil {
+			logger.Fatalf("Unable to parse delivery: %v", err)
+		}
 	}
 
-	return &vAdapter{
-		Logger:    logger,
-		Namespace: env.Namespace,
-		Source:    source,
-		VClient:   vClient,
-		CEClient:  ceClient,
-		KVStore:   store,
+	checkpoint := loadCheckpoint(ctx, store)
+
+	a := &vAdapter{
+		Logger:                logger,
+		Namespace:             env.Namespace,
+		Source:                source,
+		VClient:               vClient,
+		CEClient:              ceClient,
+		KVStore:               store,
+		Filter:                filter,
+		FilterRefreshInterval: env.SelectorRefreshInterval,
+		EventFilter:           eventFilter,
+		Transform:             transform,
+		Routes:                routes,
+		ReplySink:             env.ReplySink,
+		Scope:                 env.Scope,
+		Retries:               retries,
+		Checkpoint:            checkpoint,
+		HeartbeatInterval:     env.HeartbeatInterval,
+		Recorder:              rec,
+		Replay:                replay,
 	}
+	// There is only a controller to report back to when the kvstore is a
+	// ConfigMap, rather than when running locally.
+	if cmClient != nil {
+		a.ConfigMaps = cmClient
+		a.ConfigMapName = cmName
+		a.Expressions = &expressionReporter{
+			client: cmClient,
+			name:   cmName,
+		}
+		a.Progress = newProgressReporter(cmClient, cmName, checkpoint)
+	}
+	return a
 }
 
 // Start implements adapter.Adapter
@@ -94,42 +322,218 @@ func (a *vAdapter) Start(stopCh <-chan struct{}) error {
 		cancel()
 	}()
 	// Below here use ctx.Done() instead of stopCh.
+	return a.run(ctx)
+}
+
+// run streams the vCenter's events until the context is cancelled, or plays
+// back the recording until its end.
+func (a *vAdapter) run(ctx context.Context) error {
+	if a.Replay != nil {
+		// There is no vCenter to talk to, just the recording.
+		send := a.sendEvents(ctx)
+		return a.Replay.run(ctx, func(source string, be types.BaseEvent) error {
+			a.Source = source
+			return send(types.ManagedObjectReference{}, []types.BaseEvent{be})
+		})
+	}
+
+	if a.Filter != nil {
+		// Prime the tag associations before we evaluate any events.  The
+		// tagging service may be briefly unavailable, so we wait for it.
+		if err := a.Filter.Prime(ctx, selectorRetryInterval, selectorAttempts); err != nil {
+			return err
+		}
+		go a.Filter.Run(ctx, a.FilterRefreshInterval)
+	}
+
+	if a.ConfigMaps != nil {
+		// Publish the vCenter's catalog of event types, from which the
+		// controller builds the EventTypes for the source.
+		if err := a.publishCatalog(ctx); err != nil {
+			a.Logger.Errorw("failed to publish event catalog", zap.Error(err))
+		}
+	}
+
+	if a.Progress != nil {
+		a.Progress.connected(a.VClient.ServiceContent.About)
+		go a.Progress.run(ctx, a.Logger, a.HeartbeatInterval)
+	}
 
 	manager := event.NewManager(a.VClient.Client)
 
-	managedTypes := []types.ManagedObjectReference{a.VClient.ServiceContent.RootFolder}
+	root := a.VClient.ServiceContent.RootFolder
+	if a.Scope != "" {
+		ref, err := object.NewSearchIndex(a.VClient.Client).FindByInventoryPath(ctx, a.Scope)
+		if err != nil {
+			return err
+		}
+		if ref == nil {
+			return fmt.Errorf("no entity found at inventory path %q", a.Scope)
+		}
+		root = ref.Reference()
+	}
+
+	managedTypes := []types.ManagedObjectReference{root}
+	if a.Checkpoint != nil {
+		// Catch up on what we missed while we weren't running.
+		if err := a.catchUp(ctx, manager, managedTypes[0]); err != nil {
+			return err
+		}
+	}
 	return manager.Events(ctx, managedTypes, 1, true /* tail */, false /* force */, a.sendEvents(ctx))
 }
 
+func (a *vAdapter) publishCatalog(ctx context.Context) error {
+	catalog, err := events.FetchCatalog(ctx, a.VClient.Client)
+	if err != nil {
+		return err
+	}
+	return patchConfigMap(a.ConfigMaps, a.ConfigMapName, EventCatalogKey, catalog)
+}
+
 func (a *vAdapter) sendEvents(ctx context.Context) func(moref types.ManagedObjectReference, baseEvents []types.BaseEvent) error {
 	return func(moref types.ManagedObjectReference, baseEvents []types.BaseEvent) error {
 		for _, be := range baseEvents {
-			event := cloudevents.NewEvent(cloudevents.VersionV1)
-
-			event.SetType("com.vmware.vsphere." + reflect.TypeOf(be).Elem().Name())
-			event.SetTime(be.GetEvent().CreatedTime)
-			event.SetID(fmt.Sprintf("%d", be.GetEvent().Key))
-			event.SetSource(a.Source)
-
-			switch e := be.(type) {
-			case *types.EventEx:
-				event.SetExtension("EventEx", e)
-			case *types.ExtendedEvent:
-				event.SetExtension("ExtendedEvent", e)
+			if a.Checkpoint.Handled(be) {
+				// We have seen this one already, e.g. it was replayed.
+				continue
 			}
-			// TODO(mattmoor): Consider setting the subject
-
-			if err := event.SetData(cloudevents.ApplicationXML, be); err != nil {
-				logging.FromContext(ctx).Errorw("failed to set data on event", zap.Error(err))
+			if err := a.Recorder.record(a.Source, be); err != nil {
+				a.Logger.Errorw("failed to record event", zap.Error(err))
 			}
-
-			result := a.CEClient.Send(ctx, event)
-			if !cloudevents.IsACK(result) {
-				a.Logger.Error("failed to send cloudevent", zap.Error(result))
-				return result
+			if err := a.sendEvent(ctx, be); err != nil {
+				if err := a.saveCheckpoint(ctx); err != nil {
+					a.Logger.Errorw("failed to save checkpoint", zap.Error(err))
+				}
+				return err
 			}
+			a.advance(be)
+			a.Progress.handled(be)
 		}
 
+		if err := a.saveCheckpoint(ctx); err != nil {
+			a.Logger.Errorw("failed to save checkpoint", zap.Error(err))
+		}
 		return nil
 	}
 }
+
+// sendEvent sends the event to its sinks, unless it is filtered out.
+func (a *vAdapter) sendEvent(ctx context.Context, be types.BaseEvent) error {
+	eventType := events.Type(be)
+
+	if a.Filter != nil {
+		ok, err := a.Filter.Matches(ctx, be)
+		if err != nil {
+			a.Logger.Errorw("failed to evaluate selector", zap.Error(err))
+			return err
+		}
+		if !ok {
+			reportFiltered(ctx, eventType, filterReasonSelector)
+			return nil
+		}
+	}
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+
+	event.SetType(eventType)
+	event.SetTime(be.GetEvent().CreatedTime)
+	event.SetID(fmt.Sprintf("%d", be.GetEvent().Key))
+	event.SetSource(a.Source)
+
+	// EventEx and ExtendedEvent events are all emitted with the same type,
+	// so surface which of them they are where it can be routed on.
+	switch e := be.(type) {
+	case *types.EventEx:
+		event.SetExtension(EventTypeIDExtension, e.EventTypeId)
+	case *types.ExtendedEvent:
+		event.SetExtension(EventTypeIDExtension, e.EventTypeId)
+	}
+	// TODO(mattmoor): Consider setting the subject
+
+	if err := event.SetData(cloudevents.ApplicationXML, be); err != nil {
+		logging.FromContext(ctx).Errorw("failed to set data on event", zap.Error(err))
+	}
+
+	sinks, ok := a.applyExpressions(ctx, be, &event)
+	if !ok {
+		return nil
+	}
+
+	if len(sinks) == 0 {
+		// The event matched none of the routes (if any), so it goes
+		// to the default sink.
+		if result := a.send(ctx, event); !cloudevents.IsACK(result) {
+			a.Logger.Error("failed to send cloudevent", zap.Error(result))
+			return result
+		}
+		a.Progress.sent()
+		return nil
+	}
+	// Several routes may share a sink, which should only get the event once.
+	seen := make(map[string]struct{}, len(sinks))
+	for _, sink := range sinks {
+		if _, ok := seen[sink]; ok {
+			continue
+		}
+		seen[sink] = struct{}{}
+		if result := a.send(cloudevents.ContextWithTarget(ctx, sink), event); !cloudevents.IsACK(result) {
+			a.Logger.Error("failed to send cloudevent", zap.String("sink", sink), zap.Error(result))
+			return result
+		}
+	}
+	a.Progress.sent()
+	return nil
+}
+
+// send sends the event, retrying as the source's delivery spec asks when
+// it isn't acknowledged, and forwards the sink's reply (if any) to the
+// reply sink.  The event only counts as sent once its reply has been
+// forwarded too, so a reply that can't be is retried by resending the
+// event, as for a sink that doesn't acknowledge it.
+func (a *vAdapter) send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	reply, result := a.retry(ctx, func() (*cloudevents.Event, cloudevents.Result) {
+		return a.request(ctx, event)
+	})
+	if !cloudevents.IsACK(result) || reply == nil {
+		return result
+	}
+
+	if err := reply.Validate(); err != nil {
+		// Resending the event wouldn't fix the sink.
+		a.Logger.Errorw("dropping invalid reply", zap.String("id", event.ID()), zap.Error(err))
+		return result
+	}
+	reply.SetExtension(ReplyToExtension, event.ID())
+	_, result = a.retry(ctx, func() (*cloudevents.Event, cloudevents.Result) {
+		return nil, a.CEClient.Send(cloudevents.ContextWithTarget(ctx, a.ReplySink), *reply)
+	})
+	return result
+}
+
+// request sends the event, and returns the sink's reply to it when there is
+// a reply sink to forward it to.
+func (a *vAdapter) request(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	if a.ReplySink == "" {
+		return nil, a.CEClient.Send(ctx, event)
+	}
+	return a.CEClient.Request(ctx, event)
+}
+
+// retry calls send until its result is acknowledged, as many times as the
+// source's delivery spec allows.
+func (a *vAdapter) retry(ctx context.Context, send func() (*cloudevents.Event, cloudevents.Result)) (*cloudevents.Event, cloudevents.Result) {
+	reply, result := send()
+	if a.Retries == nil {
+		return reply, result
+	}
+	for retry := int32(0); !cloudevents.IsACK(result) && retry < a.Retries.retries; retry++ {
+		select {
+		case <-ctx.Done():
+			return reply, result
+		case <-time.After(a.Retries.backoff(retry)):
+		}
+		reply, result = send()
+	}
+	return reply, result
+}
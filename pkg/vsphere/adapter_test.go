@@ -0,0 +1,456 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/pkg/logging"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/vspheretest"
+)
+
+// These tests run the adapter against an in-process vCenter simulator, so
+// like the simulator they must not run in parallel.
+
+// recordingClient is a cloudevents.Client that keeps the events that it
+// sends, after validating them as the real client does.
+type recordingClient struct {
+	cloudevents.Client
+
+	m      sync.Mutex
+	events []cloudevents.Event
+}
+
+func (c *recordingClient) Send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.events = append(c.events, event)
+	return cloudevents.ResultACK
+}
+
+// sent returns the events sent so far.
+func (c *recordingClient) sent() []cloudevents.Event {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return append([]cloudevents.Event(nil), c.events...)
+}
+
+// waitFor waits for the events sent to satisfy done.
+func (c *recordingClient) waitFor(t *testing.T, what string, done func([]cloudevents.Event) bool) []cloudevents.Event {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if sent := c.sent(); done(sent) {
+			return sent
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s, got %v", what, names(c.sent()))
+	return nil
+}
+
+// names returns the vSphere event type names of the events.
+func names(ces []cloudevents.Event) []string {
+	got := make([]string, 0, len(ces))
+	for _, ce := range ces {
+		name, _ := events.TypeName(ce)
+		got = append(got, name)
+	}
+	return got
+}
+
+// decode decodes the vSphere events carried by the events.
+func decode(t *testing.T, ces []cloudevents.Event) []types.BaseEvent {
+	t.Helper()
+	bes := make([]types.BaseEvent, 0, len(ces))
+	for _, ce := range ces {
+		be, err := events.Decode(ce)
+		if err != nil {
+			t.Fatalf("Decode(%s) = %v", ce.ID(), err)
+		}
+		bes = append(bes, be)
+	}
+	return bes
+}
+
+// about returns the names of the events about the virtual machine, other
+// than those posted by waitUntilTailing.
+func about(t *testing.T, ces []cloudevents.Event, vm *object.VirtualMachine) []string {
+	t.Helper()
+	var got []string
+	for _, be := range decode(t, ces) {
+		if _, ok := be.(*types.GeneralUserEvent); ok {
+			continue
+		}
+		if arg := be.GetEvent().Vm; arg != nil && arg.Vm == vm.Reference() {
+			got = append(got, reflect.TypeOf(be).Elem().Name())
+		}
+	}
+	return got
+}
+
+// sentAbout returns a done func for waitFor that is satisfied once the named
+// event about the virtual machine has been sent.
+func sentAbout(t *testing.T, vm *object.VirtualMachine, name string) func([]cloudevents.Event) bool {
+	return func(ces []cloudevents.Event) bool {
+		for _, got := range about(t, ces, vm) {
+			if got == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// waitUntilTailing posts events about the virtual machine until the adapter
+// sends one, so that we know that it is following the event stream before
+// we make anything happen.
+func (c *recordingClient) waitUntilTailing(t *testing.T, sim *vspheretest.Simulator, vm *object.VirtualMachine) {
+	t.Helper()
+	ctx := context.Background()
+	manager := event.NewManager(sim.Client.Client)
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		err := manager.PostEvent(ctx, &types.GeneralUserEvent{
+			GeneralEvent: types.GeneralEvent{
+				Event: types.Event{
+					Vm: &types.VmEventArgument{
+						EntityEventArgument: types.EntityEventArgument{Name: "ping"},
+						Vm:                  vm.Reference(),
+					},
+				},
+				Message: "ping",
+			},
+		})
+		if err != nil {
+			t.Fatalf("PostEvent() = %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+		for _, name := range names(c.sent()) {
+			if name == "GeneralUserEvent" {
+				return
+			}
+		}
+	}
+	t.Fatalf("timed out waiting for the adapter, got %v", names(c.sent()))
+}
+
+// newAdapter creates an adapter configured by env, as the receive adapter
+// does.
+func newAdapter(t *testing.T, env map[string]string, ce cloudevents.Client) adapter.Adapter {
+	t.Helper()
+	env["NAMESPACE"] = "default"
+	env["K_METRICS_CONFIG"] = "{}"
+	env["K_LOGGING_CONFIG"] = "{}"
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key := range env {
+			os.Unsetenv(key)
+		}
+	}()
+
+	processed := vsphere.NewEnvConfig()
+	if err := envconfig.Process("", processed); err != nil {
+		t.Fatalf("Process() = %v", err)
+	}
+	ctx := logging.WithLogger(context.Background(), zap.NewNop().Sugar())
+	return vsphere.NewAdapter(ctx, processed, ce)
+}
+
+// startAdapter starts an adapter configured by env, and returns a func that
+// stops it.
+func startAdapter(t *testing.T, env map[string]string, ce cloudevents.Client) (stop func()) {
+	t.Helper()
+	a := newAdapter(t, env, ce)
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		err := a.Start(stopCh)
+		select {
+		case <-stopCh:
+			// Whatever it was doing was interrupted.
+		default:
+			t.Errorf("Start() = %v", err)
+		}
+	}()
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
+func TestAdapterSendsEvents(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	ce := &recordingClient{}
+	stop := startAdapter(t, map[string]string{"VSPHERE_KVSTORE": "memory://"}, ce)
+	defer stop()
+	ce.waitUntilTailing(t, sim, sim.VM(ctx, "DC0_H0_VM0"))
+
+	vm := sim.CreateVM(ctx, "e2e")
+	sim.PowerOn(ctx, vm)
+	sim.Reconfigure(ctx, vm, types.VirtualMachineConfigSpec{Annotation: "e2e"})
+	sim.PowerOff(ctx, vm)
+	sent := ce.waitFor(t, "VmPoweredOffEvent", sentAbout(t, vm, "VmPoweredOffEvent"))
+
+	want := []string{
+		"VmBeingCreatedEvent", "VmInstanceUuidAssignedEvent", "VmUuidAssignedEvent", "VmCreatedEvent",
+		"VmStartingEvent", "VmPoweredOnEvent",
+		"VmReconfiguredEvent",
+		"VmStoppingEvent", "VmPoweredOffEvent",
+	}
+	if got := about(t, sent, vm); !cmp.Equal(want, got) {
+		t.Errorf("events (-want, +got) = %s", cmp.Diff(want, got))
+	}
+
+	// Check exactly how a vSphere event maps onto a CloudEvent.
+	for i, be := range decode(t, sent) {
+		on, ok := be.(*types.VmPoweredOnEvent)
+		if !ok {
+			continue
+		}
+		got := sent[i]
+		if got, want := got.Type(), "com.vmware.vsphere.VmPoweredOnEvent"; got != want {
+			t.Errorf("Type() = %s, wanted %s", got, want)
+		}
+		if got, want := got.ID(), fmt.Sprint(on.Key); got != want {
+			t.Errorf("ID() = %s, wanted %s", got, want)
+		}
+		if got, want := got.Source(), sim.URL; got != want {
+			t.Errorf("Source() = %s, wanted %s", got, want)
+		}
+		if got, want := got.Time(), on.CreatedTime; !got.Equal(want) {
+			t.Errorf("Time() = %v, wanted %v", got, want)
+		}
+		if got, want := got.DataContentType(), cloudevents.ApplicationXML; got != want {
+			t.Errorf("DataContentType() = %s, wanted %s", got, want)
+		}
+		if len(got.Extensions()) != 0 {
+			t.Errorf("Extensions() = %v, wanted none", got.Extensions())
+		}
+		if got, want := on.Vm.Name, "e2e"; got != want {
+			t.Errorf("Vm.Name = %s, wanted %s", got, want)
+		}
+		if got, want := on.Host.Name, "DC0_H0"; got != want {
+			t.Errorf("Host.Name = %s, wanted %s", got, want)
+		}
+	}
+
+	// Each event is sent once, in order.
+	seen := make(map[string]bool, len(sent))
+	var last int32
+	for _, be := range decode(t, sent) {
+		key := be.GetEvent().Key
+		if seen[fmt.Sprint(key)] {
+			t.Errorf("event %d was sent twice", key)
+		}
+		seen[fmt.Sprint(key)] = true
+		if key < last {
+			t.Errorf("event %d was sent after event %d", key, last)
+		}
+		last = key
+	}
+}
+
+func TestAdapterSendsEventEx(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	ce := &recordingClient{}
+	stop := startAdapter(t, map[string]string{"VSPHERE_KVSTORE": "memory://"}, ce)
+	defer stop()
+	vm := sim.VM(ctx, "DC0_H0_VM0")
+	ce.waitUntilTailing(t, sim, vm)
+
+	err := event.NewManager(sim.Client.Client).PostEvent(ctx, &types.EventEx{
+		Event: types.Event{
+			Vm: &types.VmEventArgument{
+				EntityEventArgument: types.EntityEventArgument{Name: "DC0_H0_VM0"},
+				Vm:                  vm.Reference(),
+			},
+		},
+		EventTypeId: "com.example.e2e",
+		Severity:    "info",
+	})
+	if err != nil {
+		t.Fatalf("PostEvent() = %v", err)
+	}
+	sent := ce.waitFor(t, "EventEx", sentAbout(t, vm, "EventEx"))
+
+	for i, be := range decode(t, sent) {
+		ex, ok := be.(*types.EventEx)
+		if !ok {
+			continue
+		}
+		if got, want := ex.EventTypeId, "com.example.e2e"; got != want {
+			t.Errorf("EventTypeId = %s, wanted %s", got, want)
+		}
+		got, err := sent[i].Context.GetExtension(vsphere.EventTypeIDExtension)
+		if err != nil {
+			t.Fatalf("GetExtension() = %v", err)
+		}
+		if got != "com.example.e2e" {
+			t.Errorf("%s = %v, wanted com.example.e2e", vsphere.EventTypeIDExtension, got)
+		}
+	}
+}
+
+func TestAdapterResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	dir, err := ioutil.TempDir("", "adapter")
+	if err != nil {
+		t.Fatalf("TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+	state := filepath.Join(dir, "state.json")
+	env := func() map[string]string {
+		return map[string]string{"VSPHERE_KVSTORE": "file://" + state}
+	}
+
+	first := &recordingClient{}
+	stop := startAdapter(t, env(), first)
+	vm := sim.CreateVM(ctx, "e2e")
+	first.waitUntilTailing(t, sim, vm)
+	sim.PowerOn(ctx, vm)
+	first.waitFor(t, "VmPoweredOnEvent", sentAbout(t, vm, "VmPoweredOnEvent"))
+	stop()
+	// Include anything sent between waiting and stopping.
+	sent := first.sent()
+
+	// The checkpoint is the last event sent.
+	b, err := ioutil.ReadFile(state)
+	if err != nil {
+		t.Fatalf("ReadFile() = %v", err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	var cp vsphere.Checkpoint
+	if err := json.Unmarshal([]byte(data[vsphere.CheckpointKey]), &cp); err != nil {
+		t.Fatalf("Unmarshal(%s) = %v", data[vsphere.CheckpointKey], err)
+	}
+	bes := decode(t, sent)
+	lastSent := bes[len(bes)-1].GetEvent()
+	if cp.LastEventKey != lastSent.Key || !cp.LastEventTime.Equal(lastSent.CreatedTime) {
+		t.Errorf("checkpoint = %+v, wanted key %d at %v", cp, lastSent.Key, lastSent.CreatedTime)
+	}
+
+	// When the adapter reconnects, it doesn't send anything that it already
+	// has again, and carries on with what happens next.  (The simulator's
+	// event history collectors don't page through the history, so we can't
+	// check here that it catches up on what happened while it was away.)
+	second := &recordingClient{}
+	stop = startAdapter(t, env(), second)
+	defer stop()
+	second.waitUntilTailing(t, sim, vm)
+	sim.PowerOff(ctx, vm)
+	resent := second.waitFor(t, "VmPoweredOffEvent", sentAbout(t, vm, "VmPoweredOffEvent"))
+	for _, be := range decode(t, resent) {
+		if key := be.GetEvent().Key; key <= cp.LastEventKey {
+			t.Errorf("event %d was sent again after reconnecting", key)
+		}
+	}
+	want := []string{"VmStoppingEvent", "VmPoweredOffEvent"}
+	if got := about(t, resent, vm); !cmp.Equal(want, got) {
+		t.Errorf("events after reconnecting (-want, +got) = %s", cmp.Diff(want, got))
+	}
+}
+
+func TestAdapterFilter(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	ce := &recordingClient{}
+	stop := startAdapter(t, map[string]string{
+		"VSPHERE_KVSTORE": "memory://",
+		"VSPHERE_FILTER":  `eventType != "VmPoweredOnEvent" || event.Vm.Name == "keep"`,
+	}, ce)
+	defer stop()
+	ce.waitUntilTailing(t, sim, sim.VM(ctx, "DC0_H0_VM0"))
+
+	drop := sim.CreateVM(ctx, "drop")
+	keep := sim.CreateVM(ctx, "keep")
+	sim.PowerOn(ctx, drop)
+	sim.PowerOn(ctx, keep)
+	sent := ce.waitFor(t, "VmPoweredOnEvent", sentAbout(t, keep, "VmPoweredOnEvent"))
+
+	// Only drop's VmPoweredOnEvent was filtered out.
+	if got, want := about(t, sent, drop), []string{
+		"VmBeingCreatedEvent", "VmInstanceUuidAssignedEvent", "VmUuidAssignedEvent", "VmCreatedEvent",
+		"VmStartingEvent",
+	}; !cmp.Equal(want, got) {
+		t.Errorf("events about drop (-want, +got) = %s", cmp.Diff(want, got))
+	}
+	if got, want := about(t, sent, keep), []string{
+		"VmBeingCreatedEvent", "VmInstanceUuidAssignedEvent", "VmUuidAssignedEvent", "VmCreatedEvent",
+		"VmStartingEvent", "VmPoweredOnEvent",
+	}; !cmp.Equal(want, got) {
+		t.Errorf("events about keep (-want, +got) = %s", cmp.Diff(want, got))
+	}
+}
+
+func TestAdapterScopeNotFound(t *testing.T) {
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	// (The simulator's event history collectors match the events of the
+	// entities above the one they are scoped to, rather than beneath it, so
+	// we can't check here which events a scope selects.)
+	a := newAdapter(t, map[string]string{
+		"VSPHERE_KVSTORE": "memory://",
+		"VSPHERE_SCOPE":   "/DC0/host/nope",
+	}, &recordingClient{})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if err := a.Start(stopCh); err == nil {
+		t.Error("Start() = nil, wanted an error for a scope that doesn't exist")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"time"
+
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/kvstore"
+)
+
+// catchUpPageSize is the number of events read at a time when catching up
+// on the events that happened since the checkpoint.
+const catchUpPageSize = 100
+
+// Checkpoint records the last event that the adapter has handled, so that
+// it picks up where it left off when it is restarted (or resumed).
+type Checkpoint struct {
+	// LastEventKey is the key of the last event handled.  vCenter assigns
+	// event keys in increasing order.
+	LastEventKey int32 `json:"lastEventKey"`
+
+	// LastEventTime is when the last event handled was created.
+	LastEventTime time.Time `json:"lastEventTime"`
+}
+
+// Handled returns whether the event is at or before the checkpoint.
+func (cp *Checkpoint) Handled(be types.BaseEvent) bool {
+	return cp != nil && be.GetEvent().Key <= cp.LastEventKey
+}
+
+// loadCheckpoint reads the adapter's checkpoint from its kvstore, and
+// returns nil if it doesn't have one.
+func loadCheckpoint(ctx context.Context, store kvstore.Interface) *Checkpoint {
+	cp := &Checkpoint{}
+	if err := store.Get(ctx, CheckpointKey, cp); err != nil {
+		// The kvstore doesn't distinguish missing keys from bad values, and
+		// either way we start from the present.
+		return nil
+	}
+	return cp
+}
+
+// advance moves the adapter's checkpoint past the event.
+func (a *vAdapter) advance(be types.BaseEvent) {
+	a.Checkpoint = &Checkpoint{
+		LastEventKey:  be.GetEvent().Key,
+		LastEventTime: be.GetEvent().CreatedTime,
+	}
+	a.checkpointDirty = true
+}
+
+// saveCheckpoint writes the adapter's checkpoint to its kvstore, if it has
+// moved since it was last saved.
+func (a *vAdapter) saveCheckpoint(ctx context.Context) error {
+	if !a.checkpointDirty {
+		return nil
+	}
+	if err := a.writeCheckpoint(ctx); err != nil {
+		return err
+	}
+	a.checkpointDirty = false
+	return nil
+}
+
+// writeCheckpoint writes the adapter's checkpoint to its kvstore.  Saving
+// the ConfigMap kvstore replaces all of the ConfigMap's data, including the
+// keys that the adapter's other writers patch, so we patch the checkpoint
+// in the same way instead.
+func (a *vAdapter) writeCheckpoint(ctx context.Context) error {
+	if a.ConfigMaps != nil {
+		return patchConfigMap(a.ConfigMaps, a.ConfigMapName, CheckpointKey, a.Checkpoint)
+	}
+	if err := a.KVStore.Set(ctx, CheckpointKey, a.Checkpoint); err != nil {
+		return err
+	}
+	return a.KVStore.Save(ctx)
+}
+
+// catchUp sends the events that happened since the checkpoint, before we
+// start tailing the event stream.  Unlike Replay, which plays back a
+// recording, it reads them from the vCenter.
+func (a *vAdapter) catchUp(ctx context.Context, manager *event.Manager, root types.ManagedObjectReference) error {
+	begin := a.Checkpoint.LastEventTime
+	collector, err := manager.CreateCollectorForEvents(ctx, types.EventFilterSpec{
+		Entity: &types.EventFilterSpecByEntity{
+			Entity:    root,
+			Recursion: types.EventFilterSpecRecursionOptionAll,
+		},
+		Time: &types.EventFilterSpecByTime{
+			BeginTime: &begin,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := collector.Destroy(context.Background()); err != nil {
+			a.Logger.Warnw("failed to destroy event history collector", zap.Error(err))
+		}
+	}()
+
+	if err := collector.Rewind(ctx); err != nil {
+		return err
+	}
+	send := a.sendEvents(ctx)
+	for {
+		page, err := collector.ReadNextEvents(ctx, catchUpPageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		event.Sort(page)
+		if err := send(root, page); err != nil {
+			return err
+		}
+	}
+}
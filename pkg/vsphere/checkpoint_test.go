@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// memKVStore is a kvstore.Interface backed by a map.
+type memKVStore struct {
+	data  map[string]string
+	saves int
+}
+
+func (s *memKVStore) Init(ctx context.Context) error { return nil }
+func (s *memKVStore) Load(ctx context.Context) error { return nil }
+func (s *memKVStore) Save(ctx context.Context) error {
+	s.saves++
+	return nil
+}
+
+func (s *memKVStore) Get(ctx context.Context, key string, value interface{}) error {
+	v, ok := s.data[key]
+	if !ok {
+		return fmt.Errorf("key %s does not exist", key)
+	}
+	return json.Unmarshal([]byte(v), value)
+}
+
+func (s *memKVStore) Set(ctx context.Context, key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.data[key] = string(b)
+	return nil
+}
+
+// fakeClient is a cloudevents.Client that records the IDs of the events
+// that it sends, and fails to send the event with the ID in nack.
+type fakeClient struct {
+	cloudevents.Client
+	sent     []string
+	nack     string
+	attempts int
+}
+
+func (c *fakeClient) Send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	c.attempts++
+	if event.ID() == c.nack {
+		return cloudevents.ResultNACK
+	}
+	c.sent = append(c.sent, event.ID())
+	return cloudevents.ResultACK
+}
+
+func keyed(key int32) types.BaseEvent {
+	return &types.VmPoweredOnEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Key:         key,
+				CreatedTime: time.Date(2020, 4, 1, 12, 0, int(key), 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestSendEventsCheckpoint(t *testing.T) {
+	store := &memKVStore{data: map[string]string{}}
+	client := &fakeClient{}
+	a := &vAdapter{
+		Logger:   zap.NewNop().Sugar(),
+		Source:   "https://vcenter.local/sdk",
+		CEClient: client,
+		KVStore:  store,
+		Progress: newProgressReporter(nil, "", nil),
+	}
+	ctx := context.Background()
+	send := a.sendEvents(ctx)
+
+	if err := send(types.ManagedObjectReference{}, []types.BaseEvent{keyed(1), keyed(2)}); err != nil {
+		t.Fatalf("sendEvents() = %v", err)
+	}
+	want := &Checkpoint{LastEventKey: 2, LastEventTime: keyed(2).GetEvent().CreatedTime}
+	if got := loadCheckpoint(ctx, store); !cmp.Equal(got, want) {
+		t.Errorf("loadCheckpoint (-want, +got) = %s", cmp.Diff(want, got))
+	}
+
+	// Events at or before the checkpoint (e.g. ones we caught up on) aren't resent,
+	// and the checkpoint isn't saved again when it hasn't moved.
+	if err := send(types.ManagedObjectReference{}, []types.BaseEvent{keyed(1), keyed(2)}); err != nil {
+		t.Fatalf("sendEvents() = %v", err)
+	}
+	if got, want := client.sent, []string{"1", "2"}; !cmp.Equal(got, want) {
+		t.Errorf("sent = %v, wanted %v", got, want)
+	}
+	if got, want := store.saves, 1; got != want {
+		t.Errorf("saves = %d, wanted %d", got, want)
+	}
+
+	// When sending fails, the checkpoint stops at the last event sent.
+	client.nack = "4"
+	if err := send(types.ManagedObjectReference{}, []types.BaseEvent{keyed(3), keyed(4), keyed(5)}); err == nil {
+		t.Error("sendEvents() = nil, wanted error")
+	}
+	if got, want := loadCheckpoint(ctx, store).LastEventKey, int32(3); got != want {
+		t.Errorf("LastEventKey = %d, wanted %d", got, want)
+	}
+
+	// The progress that the adapter reports reflects the same.
+	if got, want := a.Progress.progress.EventsSent, int64(3); got != want {
+		t.Errorf("EventsSent = %d, wanted %d", got, want)
+	}
+	if got, want := a.Progress.progress.LastEventKey, int32(3); got != want {
+		t.Errorf("Progress.LastEventKey = %d, wanted %d", got, want)
+	}
+}
+
+func TestSaveCheckpointConfigMap(t *testing.T) {
+	kc := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cm"},
+		Data: map[string]string{
+			ExpressionStatusKey: `{"filter":{}}`,
+		},
+	})
+	store := &memKVStore{data: map[string]string{}}
+	a := &vAdapter{
+		KVStore:       store,
+		ConfigMaps:    kc.CoreV1().ConfigMaps("ns"),
+		ConfigMapName: "cm",
+	}
+	a.advance(keyed(7))
+	if err := a.saveCheckpoint(context.Background()); err != nil {
+		t.Fatalf("saveCheckpoint() = %v", err)
+	}
+
+	cm, err := kc.CoreV1().ConfigMaps("ns").Get("cm", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	// The checkpoint is patched in, rather than saved through the kvstore,
+	// so that the keys of the adapter's other writers survive.
+	if got, want := cm.Data[ExpressionStatusKey], `{"filter":{}}`; got != want {
+		t.Errorf("Data[%q] = %q, wanted %q", ExpressionStatusKey, got, want)
+	}
+	got := &Checkpoint{}
+	if err := json.Unmarshal([]byte(cm.Data[CheckpointKey]), got); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if !cmp.Equal(got, a.Checkpoint) {
+		t.Errorf("Checkpoint (-want, +got) = %s", cmp.Diff(a.Checkpoint, got))
+	}
+	if got, want := store.saves, 0; got != want {
+		t.Errorf("saves = %d, wanted %d", got, want)
+	}
+}
+
+func TestLoadCheckpointMissing(t *testing.T) {
+	store := &memKVStore{data: map[string]string{}}
+	if got := loadCheckpoint(context.Background(), store); got != nil {
+		t.Errorf("loadCheckpoint() = %v, wanted nil", got)
+	}
+	var cp *Checkpoint
+	if cp.Handled(keyed(1)) {
+		t.Error("Handled() = true, wanted false")
+	}
+}
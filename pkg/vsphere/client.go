@@ -18,9 +18,13 @@ package vsphere
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io/ioutil"
 	"net/url"
+	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/vmware/govmomi"
@@ -32,6 +36,11 @@ import (
 const (
 	VolumeName = "vsphere-binding"
 	MountPath  = "/var/bindings/vsphere" // filepath.Join isn't const.
+
+	// MountPathEnv is the environment variable that may point ReadKey
+	// somewhere other than MountPath, e.g. when running outside of a
+	// cluster or under test.
+	MountPathEnv = "VSPHERE_MOUNT_PATH"
 )
 
 type EnvConfig struct {
@@ -39,9 +48,33 @@ type EnvConfig struct {
 	Address  string `envconfig:"GOVC_URL" required:"true"`
 }
 
+// CredentialsHash returns a hash of the contents of the secret, which is
+// stamped onto the pod templates of bound resources so that they roll out
+// when the secret changes, since New only reads it once.
+func CredentialsHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write(data[key])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // ReadKey may be used to read keys from the secret.
 func ReadKey(key string) (string, error) {
-	data, err := ioutil.ReadFile(filepath.Join(MountPath, key))
+	dir := MountPath
+	if override := os.Getenv(MountPathEnv); override != "" {
+		dir = override
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, key))
 	if err != nil {
 		return "", err
 	}
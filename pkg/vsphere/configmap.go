@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"encoding/json"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// The adapter reports information back to the controller through the
+// ConfigMap that backs its kvstore, under the following keys.
+const (
+	// ExpressionStatusKey holds the outcome of evaluating the source's
+	// expressions, as an ExpressionStatus.
+	ExpressionStatusKey = "expressions"
+
+	// EventCatalogKey holds the event types described by vCenter's
+	// EventManager, as a list of events.CatalogEntry.
+	EventCatalogKey = "eventCatalog"
+
+	// CheckpointKey holds the last event that the adapter has handled, as
+	// a Checkpoint.
+	CheckpointKey = "checkpoint"
+
+	// ProgressKey holds the adapter's most recent heartbeat, as a Progress.
+	ProgressKey = "progress"
+)
+
+// patchConfigMap sets the key of the named ConfigMap to the JSON encoding
+// of value, leaving its other keys as they are.  Each of the adapter's
+// writers owns one key, and they all write through here (rather than
+// saving the kvstore, which replaces all of the keys) so that none of them
+// clobbers another.
+func patchConfigMap(client corev1client.ConfigMapInterface, name, key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			key: string(b),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.Patch(name, k8stypes.MergePatchType, patch)
+	return err
+}
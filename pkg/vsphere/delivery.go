@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	eventingduckv1beta1 "knative.dev/eventing/pkg/apis/duck/v1beta1"
+)
+
+// defaultBackoffDelay is the delay between retries when the delivery spec
+// doesn't specify one.
+const defaultBackoffDelay = time.Second
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseBackoffDelay parses the ISO 8601 duration (e.g. "PT0.5S") that is
+// the backoffDelay of a DeliverySpec.  Only days, hours, minutes and
+// seconds are supported, since the lengths of years and months vary.
+func ParseBackoffDelay(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || s[len(s)-1] == 'T' {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(f * float64(unit))
+	}
+	return d, nil
+}
+
+// retryPolicy is how the adapter retries sending events that their sink
+// rejects.
+type retryPolicy struct {
+	retries     int32
+	exponential bool
+	delay       time.Duration
+}
+
+// parseDelivery decodes the JSON encoded DeliverySpec that the adapter is
+// handed into a retryPolicy.
+func parseDelivery(raw string) (*retryPolicy, error) {
+	ds := &eventingduckv1beta1.DeliverySpec{}
+	if err := json.Unmarshal([]byte(raw), ds); err != nil {
+		return nil, err
+	}
+	rp := &retryPolicy{delay: defaultBackoffDelay}
+	if ds.Retry != nil {
+		rp.retries = *ds.Retry
+	}
+	if ds.BackoffPolicy != nil {
+		rp.exponential = *ds.BackoffPolicy == eventingduckv1beta1.BackoffPolicyExponential
+	}
+	if ds.BackoffDelay != nil {
+		d, err := ParseBackoffDelay(*ds.BackoffDelay)
+		if err != nil {
+			return nil, err
+		}
+		rp.delay = d
+	}
+	return rp, nil
+}
+
+// backoff returns how long to wait before the given retry (counting from
+// zero).
+func (rp *retryPolicy) backoff(retry int32) time.Duration {
+	if rp.exponential {
+		return rp.delay << uint(retry)
+	}
+	return rp.delay
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+)
+
+func TestParseBackoffDelay(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{{
+		in:   "PT0.5S",
+		want: 500 * time.Millisecond,
+	}, {
+		in:   "PT1M30S",
+		want: 90 * time.Second,
+	}, {
+		in:   "P1DT2H",
+		want: 26 * time.Hour,
+	}, {
+		in:      "P",
+		wantErr: true,
+	}, {
+		in:      "PT",
+		wantErr: true,
+	}, {
+		in:      "P1Y",
+		wantErr: true,
+	}, {
+		in:      "1s",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := ParseBackoffDelay(test.in)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseBackoffDelay() = %v, wanted error: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseBackoffDelay() = %v, wanted %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseDelivery(t *testing.T) {
+	rp, err := parseDelivery(`{"retry":3,"backoffPolicy":"exponential","backoffDelay":"PT0.1S"}`)
+	if err != nil {
+		t.Fatalf("parseDelivery() = %v", err)
+	}
+	var got []time.Duration
+	for retry := int32(0); retry < rp.retries; retry++ {
+		got = append(got, rp.backoff(retry))
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+	if !cmp.Equal(got, want) {
+		t.Errorf("backoffs = %v, wanted %v", got, want)
+	}
+
+	rp, err = parseDelivery(`{"retry":1}`)
+	if err != nil {
+		t.Fatalf("parseDelivery() = %v", err)
+	}
+	if got, want := rp.backoff(3), defaultBackoffDelay; got != want {
+		t.Errorf("backoff() = %v, wanted %v", got, want)
+	}
+
+	if _, err := parseDelivery(`{"backoffDelay":"1s"}`); err == nil {
+		t.Error("parseDelivery() = nil, wanted error")
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	client := &fakeClient{nack: "1"}
+	a := &vAdapter{
+		Logger:   zap.NewNop().Sugar(),
+		CEClient: client,
+		Retries:  &retryPolicy{retries: 2, delay: time.Millisecond},
+	}
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+
+	if result := a.send(context.Background(), event); cloudevents.IsACK(result) {
+		t.Errorf("send() = %v, wanted NACK", result)
+	}
+	if got, want := client.attempts, 3; got != want {
+		t.Errorf("attempts = %d, wanted %d", got, want)
+	}
+
+	// Events that are acknowledged aren't retried.
+	client.attempts = 0
+	event.SetID("2")
+	if result := a.send(context.Background(), event); !cloudevents.IsACK(result) {
+		t.Errorf("send() = %v, wanted ACK", result)
+	}
+	if got, want := client.attempts, 1; got != want {
+		t.Errorf("attempts = %d, wanted %d", got, want)
+	}
+}
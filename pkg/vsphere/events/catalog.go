@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// TypeNames returns the names of the vSphere event types known to govmomi,
+// e.g. VmCreatedEvent, in sorted order.
+func TypeNames() []string {
+	names := make([]string, len(typeNames))
+	copy(names, typeNames)
+	return names
+}
+
+// LeafTypeNames returns the names of the vSphere event types that no other
+// event type embeds, in sorted order.  These leave out the abstract base
+// types, like VmEvent, which vCenter never emits.
+func LeafTypeNames() []string {
+	names := make([]string, len(leafTypeNames))
+	copy(names, leafTypeNames)
+	return names
+}
+
+var knownTypes = func() map[string]struct{} {
+	m := make(map[string]struct{}, len(typeNames))
+	for _, name := range typeNames {
+		m[name] = struct{}{}
+	}
+	return m
+}()
+
+// IsTypeName returns whether name is one of TypeNames.
+func IsTypeName(name string) bool {
+	_, ok := knownTypes[name]
+	return ok
+}
+
+// CatalogEntry describes one of the event types in vCenter's catalog.  Its
+// Key is either the name of an event type (see IsTypeName), or the
+// eventTypeId of an EventEx or ExtendedEvent.
+type CatalogEntry struct {
+	Key         string `json:"key"`
+	Description string `json:"description,omitempty"`
+}
+
+// FetchCatalog retrieves the catalog of event types that vCenter's
+// EventManager describes.
+func FetchCatalog(ctx context.Context, c *vim25.Client) ([]CatalogEntry, error) {
+	var em mo.EventManager
+	err := property.DefaultCollector(c).RetrieveOne(ctx, *c.ServiceContent.EventManager,
+		[]string{"description.eventInfo"}, &em)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make([]CatalogEntry, 0, len(em.Description.EventInfo))
+	for _, info := range em.Description.EventInfo {
+		catalog = append(catalog, CatalogEntry{
+			Key:         info.Key,
+			Description: info.Description,
+		})
+	}
+	return catalog, nil
+}
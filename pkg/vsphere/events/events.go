@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events holds helpers for consuming the CloudEvents emitted by
+// the VSphereSource, which carry vSphere events as their payload.
+package events
+
+//go:generate go run ./gen -o zz_generated.events.go
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vim25/xml"
+)
+
+// TypePrefix is the prefix of the CloudEvent type of the events emitted by
+// the source.  It is followed by the name of the vSphere event type, e.g.
+// com.vmware.vsphere.VmCreatedEvent
+const TypePrefix = "com.vmware.vsphere."
+
+// Type returns the CloudEvent type for the given vSphere event.
+func Type(be types.BaseEvent) string {
+	return TypePrefix + reflect.TypeOf(be).Elem().Name()
+}
+
+// TypeName returns the name of the vSphere event type carried by the
+// CloudEvent, e.g. VmCreatedEvent, and whether it was emitted by the
+// source.
+func TypeName(event cloudevents.Event) (string, bool) {
+	if !strings.HasPrefix(event.Type(), TypePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(event.Type(), TypePrefix), true
+}
+
+var typeFunc = types.TypeFunc()
+
+// Decode reconstructs the concrete vSphere event carried by the CloudEvent,
+// e.g. a *types.VmCreatedEvent.
+func Decode(event cloudevents.Event) (types.BaseEvent, error) {
+	name, ok := TypeName(event)
+	if !ok {
+		return nil, fmt.Errorf("unexpected event type %q", event.Type())
+	}
+	t, ok := typeFunc(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown vSphere event type %q", name)
+	}
+	be, ok := reflect.New(t).Interface().(types.BaseEvent)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a vSphere event type", name)
+	}
+
+	switch mt := event.DataMediaType(); mt {
+	case cloudevents.ApplicationXML, "text/xml":
+		// Decode with govmomi's xml package, which understands the xsi:type
+		// annotations on polymorphic fields.
+		dec := xml.NewDecoder(bytes.NewReader(event.Data()))
+		dec.TypeFunc = typeFunc
+		if err := dec.Decode(be); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	case "", cloudevents.ApplicationJSON, "text/json":
+		if err := event.DataAs(be); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", mt)
+	}
+	return be, nil
+}
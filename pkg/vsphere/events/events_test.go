@@ -0,0 +1,212 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var created = &types.VmCreatedEvent{
+	VmEvent: types.VmEvent{
+		Event: types.Event{
+			Key:         42,
+			CreatedTime: time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+			UserName:    "administrator",
+			Vm: &types.VmEventArgument{
+				EntityEventArgument: types.EntityEventArgument{
+					Name: "my-vm",
+				},
+				Vm: types.ManagedObjectReference{
+					Type:  "VirtualMachine",
+					Value: "vm-42",
+				},
+			},
+		},
+	},
+}
+
+func newEvent(t *testing.T, be types.BaseEvent, contentType string) cloudevents.Event {
+	t.Helper()
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetType(Type(be))
+	event.SetSource("https://vcenter.local/sdk")
+	event.SetID("42")
+	if err := event.SetData(contentType, be); err != nil {
+		t.Fatalf("SetData() = %v", err)
+	}
+	return event
+}
+
+func TestDecode(t *testing.T) {
+	for _, ct := range []string{cloudevents.ApplicationXML, cloudevents.ApplicationJSON} {
+		t.Run(ct, func(t *testing.T) {
+			got, err := Decode(newEvent(t, created, ct))
+			if err != nil {
+				t.Fatalf("Decode() = %v", err)
+			}
+			if !cmp.Equal(got, types.BaseEvent(created)) {
+				t.Errorf("Decode (-want, +got) = %s", cmp.Diff(created, got))
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func() cloudevents.Event
+	}{{
+		name: "foreign event",
+		event: func() cloudevents.Event {
+			event := newEvent(t, created, cloudevents.ApplicationJSON)
+			event.SetType("dev.knative.foo")
+			return event
+		},
+	}, {
+		name: "unknown vSphere type",
+		event: func() cloudevents.Event {
+			event := newEvent(t, created, cloudevents.ApplicationJSON)
+			event.SetType(TypePrefix + "NoSuchEvent")
+			return event
+		},
+	}, {
+		name: "not an event",
+		event: func() cloudevents.Event {
+			event := newEvent(t, created, cloudevents.ApplicationJSON)
+			event.SetType(TypePrefix + "VirtualMachineConfigSpec")
+			return event
+		},
+	}, {
+		name: "unsupported content type",
+		event: func() cloudevents.Event {
+			event := newEvent(t, created, cloudevents.ApplicationJSON)
+			event.SetDataContentType("text/plain")
+			return event
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, err := Decode(test.event()); err == nil {
+				t.Errorf("Decode() = %v, wanted error", got)
+			}
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	r := NewRouter()
+
+	var gotCreated *types.VmCreatedEvent
+	r.OnVmCreated(func(ctx context.Context, e *types.VmCreatedEvent) error {
+		gotCreated = e
+		return nil
+	})
+	wantErr := errors.New("powered off")
+	r.OnVmPoweredOff(func(ctx context.Context, e *types.VmPoweredOffEvent) error {
+		return wantErr
+	})
+
+	ctx := context.Background()
+	if err := r.Receive(ctx, newEvent(t, created, cloudevents.ApplicationXML)); err != nil {
+		t.Fatalf("Receive() = %v", err)
+	}
+	if !cmp.Equal(gotCreated, created) {
+		t.Errorf("OnVmCreated (-want, +got) = %s", cmp.Diff(created, gotCreated))
+	}
+
+	if err := r.Receive(ctx, newEvent(t, &types.VmPoweredOffEvent{}, cloudevents.ApplicationXML)); err != wantErr {
+		t.Errorf("Receive() = %v, wanted %v", err, wantErr)
+	}
+
+	// Events without a handler are dropped, unless there is a default.
+	removed := &types.VmRemovedEvent{}
+	if err := r.Receive(ctx, newEvent(t, removed, cloudevents.ApplicationXML)); err != nil {
+		t.Errorf("Receive() = %v", err)
+	}
+	var gotDefault types.BaseEvent
+	r.Default(func(ctx context.Context, be types.BaseEvent) error {
+		gotDefault = be
+		return nil
+	})
+	if err := r.Receive(ctx, newEvent(t, removed, cloudevents.ApplicationXML)); err != nil {
+		t.Errorf("Receive() = %v", err)
+	}
+	if _, ok := gotDefault.(*types.VmRemovedEvent); !ok {
+		t.Errorf("Default got %T, wanted *types.VmRemovedEvent", gotDefault)
+	}
+
+	// Events from other sources are dropped, even with a default.
+	gotDefault = nil
+	foreign := cloudevents.NewEvent()
+	foreign.SetType("dev.knative.apiserver.resource.add")
+	if err := r.Receive(ctx, foreign); err != nil {
+		t.Errorf("Receive() = %v", err)
+	}
+	if gotDefault != nil {
+		t.Errorf("Default got %T, wanted nothing", gotDefault)
+	}
+}
+
+func TestFetchCatalog(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		catalog, err := FetchCatalog(ctx, c)
+		if err != nil {
+			t.Fatalf("FetchCatalog() = %v", err)
+		}
+		for _, entry := range catalog {
+			if entry.Key == "VmCreatedEvent" {
+				if !IsTypeName(entry.Key) {
+					t.Errorf("IsTypeName(%q) = false, wanted true", entry.Key)
+				}
+				return
+			}
+		}
+		t.Errorf("FetchCatalog() = %v, wanted VmCreatedEvent", catalog)
+	})
+}
+
+func TestLeafTypeNames(t *testing.T) {
+	leaves := make(map[string]bool)
+	for _, name := range LeafTypeNames() {
+		if !IsTypeName(name) {
+			t.Errorf("IsTypeName(%q) = false, wanted true", name)
+		}
+		leaves[name] = true
+	}
+	for name, want := range map[string]bool{
+		"VmCreatedEvent":     true,
+		"DrsVmMigratedEvent": true,
+		"EventEx":            true,
+		"VmEvent":            false,
+		"HostEvent":          false,
+		"VmMigratedEvent":    false,
+	} {
+		if got := leaves[name]; got != want {
+			t.Errorf("leaf %q = %v, wanted %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The gen command generates the list of the vSphere event types known to
+// govmomi, and the typed On* methods of events.Router for each of them.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var output = flag.String("o", "", "The file to write, or stdout when empty.")
+
+const typesPkg = "github.com/vmware/govmomi/vim25/types"
+
+func main() {
+	flag.Parse()
+
+	// Resolve the package relative to the working directory, so that
+	// the vendored copy is used.
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("Unable to determine working directory: %v", err)
+	}
+	pkg, err := build.Import(typesPkg, wd, build.FindOnly)
+	if err != nil {
+		log.Fatalf("Unable to find %s: %v", typesPkg, err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(pkg.Dir, "types.go"), nil, 0)
+	if err != nil {
+		log.Fatalf("Unable to parse types.go: %v", err)
+	}
+
+	// Record the types that each struct embeds.
+	embeds := make(map[string][]string)
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if id, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 {
+					embeds[ts.Name.Name] = append(embeds[ts.Name.Name], id.Name)
+				}
+			}
+		}
+	}
+
+	// Events are the types that (transitively) embed Event.
+	var isEvent func(string) bool
+	isEvent = func(name string) bool {
+		if name == "Event" {
+			return true
+		}
+		for _, e := range embeds[name] {
+			if isEvent(e) {
+				return true
+			}
+		}
+		return false
+	}
+	var names []string
+	for name := range embeds {
+		if name != "Event" && isEvent(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Leaves are the events that no other event embeds.  The others are
+	// mostly abstract base types, like VmEvent.
+	embedded := make(map[string]bool)
+	for _, name := range names {
+		for _, e := range embeds[name] {
+			embedded[e] = true
+		}
+	}
+	var leaves []string
+	for _, name := range names {
+		if !embedded[name] {
+			leaves = append(leaves, name)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("\n// typeNames lists the names of the vSphere event types.\nvar typeNames = []string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t%q,\n", name)
+	}
+	buf.WriteString("}\n")
+	buf.WriteString("\n// leafTypeNames lists the names of the vSphere event types that no other\n// event type embeds.\nvar leafTypeNames = []string{\n")
+	for _, name := range leaves {
+		fmt.Fprintf(&buf, "\t%q,\n", name)
+	}
+	buf.WriteString("}\n")
+	methods := make(map[string]string, len(names))
+	for _, name := range names {
+		method := "On" + strings.TrimSuffix(name, "Event")
+		if other, ok := methods[method]; ok {
+			log.Fatalf("%s and %s both map to %s", name, other, method)
+		}
+		methods[method] = name
+		fmt.Fprintf(&buf, `
+// %s registers the handler for %s events.
+func (r *Router) %s(fn func(context.Context, *types.%s) error) {
+	r.On(%q, func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.%s))
+	})
+}
+`, method, name, method, name, name, name)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("Unable to format output: %v", err)
+	}
+	if *output == "" {
+		fmt.Print(string(src))
+		return
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatalf("Unable to write %s: %v", *output, err)
+	}
+}
+
+const header = `/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by gen. DO NOT EDIT.
+
+package events
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+`
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Handler is invoked with the decoded vSphere event.
+type Handler func(context.Context, types.BaseEvent) error
+
+// Router dispatches the CloudEvents emitted by the source to handlers
+// registered for the type of vSphere event that they carry.  Its Receive
+// method may be passed to cloudevents.Client.StartReceiver, e.g.
+//
+//	r := events.NewRouter()
+//	r.OnVmCreated(func(ctx context.Context, e *types.VmCreatedEvent) error {
+//		...
+//	})
+//	ceclient.StartReceiver(ctx, r.Receive)
+type Router struct {
+	handlers map[string]Handler
+	fallback Handler
+}
+
+// NewRouter creates an empty Router.
+func NewRouter() *Router {
+	return &Router{
+		handlers: make(map[string]Handler),
+	}
+}
+
+// On registers the handler for vSphere events of the named type, e.g.
+// VmCreatedEvent.  The typed On* methods should generally be preferred.
+func (r *Router) On(typeName string, h Handler) {
+	r.handlers[typeName] = h
+}
+
+// Default registers the handler for vSphere events without a handler of
+// their own.  Without it, such events are acknowledged and dropped.
+func (r *Router) Default(h Handler) {
+	r.fallback = h
+}
+
+// Receive decodes the CloudEvent and invokes the matching handler, returning
+// an error when the event can't be decoded.  CloudEvents that aren't vSphere
+// events are acknowledged and dropped, since no handler is for them.
+func (r *Router) Receive(ctx context.Context, event cloudevents.Event) error {
+	name, ok := TypeName(event)
+	if !ok {
+		return nil
+	}
+	h, ok := r.handlers[name]
+	if !ok {
+		h = r.fallback
+	}
+	if h == nil {
+		return nil
+	}
+	be, err := Decode(event)
+	if err != nil {
+		return err
+	}
+	return h(ctx, be)
+}
@@ -0,0 +1,3970 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by gen. DO NOT EDIT.
+
+package events
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// typeNames lists the names of the vSphere event types.
+var typeNames = []string{
+	"AccountCreatedEvent",
+	"AccountRemovedEvent",
+	"AccountUpdatedEvent",
+	"AdminPasswordNotChangedEvent",
+	"AlarmAcknowledgedEvent",
+	"AlarmActionTriggeredEvent",
+	"AlarmClearedEvent",
+	"AlarmCreatedEvent",
+	"AlarmEmailCompletedEvent",
+	"AlarmEmailFailedEvent",
+	"AlarmEvent",
+	"AlarmReconfiguredEvent",
+	"AlarmRemovedEvent",
+	"AlarmScriptCompleteEvent",
+	"AlarmScriptFailedEvent",
+	"AlarmSnmpCompletedEvent",
+	"AlarmSnmpFailedEvent",
+	"AlarmStatusChangedEvent",
+	"AllVirtualMachinesLicensedEvent",
+	"AlreadyAuthenticatedSessionEvent",
+	"AuthorizationEvent",
+	"BadUsernameSessionEvent",
+	"CanceledHostOperationEvent",
+	"ClusterComplianceCheckedEvent",
+	"ClusterCreatedEvent",
+	"ClusterDestroyedEvent",
+	"ClusterEvent",
+	"ClusterOvercommittedEvent",
+	"ClusterReconfiguredEvent",
+	"ClusterStatusChangedEvent",
+	"CustomFieldDefAddedEvent",
+	"CustomFieldDefEvent",
+	"CustomFieldDefRemovedEvent",
+	"CustomFieldDefRenamedEvent",
+	"CustomFieldEvent",
+	"CustomFieldValueChangedEvent",
+	"CustomizationEvent",
+	"CustomizationFailed",
+	"CustomizationLinuxIdentityFailed",
+	"CustomizationNetworkSetupFailed",
+	"CustomizationStartedEvent",
+	"CustomizationSucceeded",
+	"CustomizationSysprepFailed",
+	"CustomizationUnknownFailure",
+	"DVPortgroupCreatedEvent",
+	"DVPortgroupDestroyedEvent",
+	"DVPortgroupEvent",
+	"DVPortgroupReconfiguredEvent",
+	"DVPortgroupRenamedEvent",
+	"DasAdmissionControlDisabledEvent",
+	"DasAdmissionControlEnabledEvent",
+	"DasAgentFoundEvent",
+	"DasAgentUnavailableEvent",
+	"DasClusterIsolatedEvent",
+	"DasDisabledEvent",
+	"DasEnabledEvent",
+	"DasHostFailedEvent",
+	"DasHostIsolatedEvent",
+	"DatacenterCreatedEvent",
+	"DatacenterEvent",
+	"DatacenterRenamedEvent",
+	"DatastoreCapacityIncreasedEvent",
+	"DatastoreDestroyedEvent",
+	"DatastoreDiscoveredEvent",
+	"DatastoreDuplicatedEvent",
+	"DatastoreEvent",
+	"DatastoreFileCopiedEvent",
+	"DatastoreFileDeletedEvent",
+	"DatastoreFileEvent",
+	"DatastoreFileMovedEvent",
+	"DatastoreIORMReconfiguredEvent",
+	"DatastorePrincipalConfigured",
+	"DatastoreRemovedOnHostEvent",
+	"DatastoreRenamedEvent",
+	"DatastoreRenamedOnHostEvent",
+	"DrsDisabledEvent",
+	"DrsEnabledEvent",
+	"DrsEnteredStandbyModeEvent",
+	"DrsEnteringStandbyModeEvent",
+	"DrsExitStandbyModeFailedEvent",
+	"DrsExitedStandbyModeEvent",
+	"DrsExitingStandbyModeEvent",
+	"DrsInvocationFailedEvent",
+	"DrsRecoveredFromFailureEvent",
+	"DrsResourceConfigureFailedEvent",
+	"DrsResourceConfigureSyncedEvent",
+	"DrsRuleComplianceEvent",
+	"DrsRuleViolationEvent",
+	"DrsSoftRuleViolationEvent",
+	"DrsVmMigratedEvent",
+	"DrsVmPoweredOnEvent",
+	"DuplicateIpDetectedEvent",
+	"DvpgImportEvent",
+	"DvpgRestoreEvent",
+	"DvsCreatedEvent",
+	"DvsDestroyedEvent",
+	"DvsEvent",
+	"DvsHealthStatusChangeEvent",
+	"DvsHostBackInSyncEvent",
+	"DvsHostJoinedEvent",
+	"DvsHostLeftEvent",
+	"DvsHostStatusUpdated",
+	"DvsHostWentOutOfSyncEvent",
+	"DvsImportEvent",
+	"DvsMergedEvent",
+	"DvsPortBlockedEvent",
+	"DvsPortConnectedEvent",
+	"DvsPortCreatedEvent",
+	"DvsPortDeletedEvent",
+	"DvsPortDisconnectedEvent",
+	"DvsPortEnteredPassthruEvent",
+	"DvsPortExitedPassthruEvent",
+	"DvsPortJoinPortgroupEvent",
+	"DvsPortLeavePortgroupEvent",
+	"DvsPortLinkDownEvent",
+	"DvsPortLinkUpEvent",
+	"DvsPortReconfiguredEvent",
+	"DvsPortRuntimeChangeEvent",
+	"DvsPortUnblockedEvent",
+	"DvsPortVendorSpecificStateChangeEvent",
+	"DvsReconfiguredEvent",
+	"DvsRenamedEvent",
+	"DvsRestoreEvent",
+	"DvsUpgradeAvailableEvent",
+	"DvsUpgradeInProgressEvent",
+	"DvsUpgradeRejectedEvent",
+	"DvsUpgradedEvent",
+	"EnteredMaintenanceModeEvent",
+	"EnteredStandbyModeEvent",
+	"EnteringMaintenanceModeEvent",
+	"EnteringStandbyModeEvent",
+	"ErrorUpgradeEvent",
+	"EventEx",
+	"ExitMaintenanceModeEvent",
+	"ExitStandbyModeFailedEvent",
+	"ExitedStandbyModeEvent",
+	"ExitingStandbyModeEvent",
+	"ExtendedEvent",
+	"FailoverLevelRestored",
+	"GeneralEvent",
+	"GeneralHostErrorEvent",
+	"GeneralHostInfoEvent",
+	"GeneralHostWarningEvent",
+	"GeneralUserEvent",
+	"GeneralVmErrorEvent",
+	"GeneralVmInfoEvent",
+	"GeneralVmWarningEvent",
+	"GhostDvsProxySwitchDetectedEvent",
+	"GhostDvsProxySwitchRemovedEvent",
+	"GlobalMessageChangedEvent",
+	"HealthStatusChangedEvent",
+	"HostAddFailedEvent",
+	"HostAddedEvent",
+	"HostAdminDisableEvent",
+	"HostAdminEnableEvent",
+	"HostCnxFailedAccountFailedEvent",
+	"HostCnxFailedAlreadyManagedEvent",
+	"HostCnxFailedBadCcagentEvent",
+	"HostCnxFailedBadUsernameEvent",
+	"HostCnxFailedBadVersionEvent",
+	"HostCnxFailedCcagentUpgradeEvent",
+	"HostCnxFailedEvent",
+	"HostCnxFailedNetworkErrorEvent",
+	"HostCnxFailedNoAccessEvent",
+	"HostCnxFailedNoConnectionEvent",
+	"HostCnxFailedNoLicenseEvent",
+	"HostCnxFailedNotFoundEvent",
+	"HostCnxFailedTimeoutEvent",
+	"HostComplianceCheckedEvent",
+	"HostCompliantEvent",
+	"HostConfigAppliedEvent",
+	"HostConnectedEvent",
+	"HostConnectionLostEvent",
+	"HostDasDisabledEvent",
+	"HostDasDisablingEvent",
+	"HostDasEnabledEvent",
+	"HostDasEnablingEvent",
+	"HostDasErrorEvent",
+	"HostDasEvent",
+	"HostDasOkEvent",
+	"HostDisconnectedEvent",
+	"HostEnableAdminFailedEvent",
+	"HostEvent",
+	"HostExtraNetworksEvent",
+	"HostGetShortNameFailedEvent",
+	"HostInAuditModeEvent",
+	"HostInventoryFullEvent",
+	"HostInventoryUnreadableEvent",
+	"HostIpChangedEvent",
+	"HostIpInconsistentEvent",
+	"HostIpToShortNameFailedEvent",
+	"HostIsolationIpPingFailedEvent",
+	"HostLicenseExpiredEvent",
+	"HostLocalPortCreatedEvent",
+	"HostMissingNetworksEvent",
+	"HostMonitoringStateChangedEvent",
+	"HostNoAvailableNetworksEvent",
+	"HostNoHAEnabledPortGroupsEvent",
+	"HostNoRedundantManagementNetworkEvent",
+	"HostNonCompliantEvent",
+	"HostNotInClusterEvent",
+	"HostOvercommittedEvent",
+	"HostPrimaryAgentNotShortNameEvent",
+	"HostProfileAppliedEvent",
+	"HostReconnectionFailedEvent",
+	"HostRemovedEvent",
+	"HostShortNameInconsistentEvent",
+	"HostShortNameToIpFailedEvent",
+	"HostShutdownEvent",
+	"HostSpecificationChangedEvent",
+	"HostSpecificationRequireEvent",
+	"HostSpecificationUpdateEvent",
+	"HostStatusChangedEvent",
+	"HostSubSpecificationDeleteEvent",
+	"HostSubSpecificationUpdateEvent",
+	"HostSyncFailedEvent",
+	"HostUpgradeFailedEvent",
+	"HostUserWorldSwapNotEnabledEvent",
+	"HostVnicConnectedToCustomizedDVPortEvent",
+	"HostWwnChangedEvent",
+	"HostWwnConflictEvent",
+	"IScsiBootFailureEvent",
+	"IncorrectHostInformationEvent",
+	"InfoUpgradeEvent",
+	"InsufficientFailoverResourcesEvent",
+	"InvalidEditionEvent",
+	"LicenseEvent",
+	"LicenseExpiredEvent",
+	"LicenseNonComplianceEvent",
+	"LicenseRestrictedEvent",
+	"LicenseServerAvailableEvent",
+	"LicenseServerUnavailableEvent",
+	"LocalDatastoreCreatedEvent",
+	"LocalTSMEnabledEvent",
+	"LockerMisconfiguredEvent",
+	"LockerReconfiguredEvent",
+	"MigrationErrorEvent",
+	"MigrationEvent",
+	"MigrationHostErrorEvent",
+	"MigrationHostWarningEvent",
+	"MigrationResourceErrorEvent",
+	"MigrationResourceWarningEvent",
+	"MigrationWarningEvent",
+	"MtuMatchEvent",
+	"MtuMismatchEvent",
+	"NASDatastoreCreatedEvent",
+	"NetworkRollbackEvent",
+	"NoAccessUserEvent",
+	"NoDatastoresConfiguredEvent",
+	"NoLicenseEvent",
+	"NoMaintenanceModeDrsRecommendationForVM",
+	"NonVIWorkloadDetectedOnDatastoreEvent",
+	"NotEnoughResourcesToStartVmEvent",
+	"OutOfSyncDvsHost",
+	"PermissionAddedEvent",
+	"PermissionEvent",
+	"PermissionRemovedEvent",
+	"PermissionUpdatedEvent",
+	"ProfileAssociatedEvent",
+	"ProfileChangedEvent",
+	"ProfileCreatedEvent",
+	"ProfileDissociatedEvent",
+	"ProfileEvent",
+	"ProfileReferenceHostChangedEvent",
+	"ProfileRemovedEvent",
+	"RecoveryEvent",
+	"RemoteTSMEnabledEvent",
+	"ResourcePoolCreatedEvent",
+	"ResourcePoolDestroyedEvent",
+	"ResourcePoolEvent",
+	"ResourcePoolMovedEvent",
+	"ResourcePoolReconfiguredEvent",
+	"ResourceViolatedEvent",
+	"RoleAddedEvent",
+	"RoleEvent",
+	"RoleRemovedEvent",
+	"RoleUpdatedEvent",
+	"RollbackEvent",
+	"ScheduledTaskCompletedEvent",
+	"ScheduledTaskCreatedEvent",
+	"ScheduledTaskEmailCompletedEvent",
+	"ScheduledTaskEmailFailedEvent",
+	"ScheduledTaskEvent",
+	"ScheduledTaskFailedEvent",
+	"ScheduledTaskReconfiguredEvent",
+	"ScheduledTaskRemovedEvent",
+	"ScheduledTaskStartedEvent",
+	"ServerLicenseExpiredEvent",
+	"ServerStartedSessionEvent",
+	"SessionEvent",
+	"SessionTerminatedEvent",
+	"TaskEvent",
+	"TaskTimeoutEvent",
+	"TeamingMatchEvent",
+	"TeamingMisMatchEvent",
+	"TemplateBeingUpgradedEvent",
+	"TemplateUpgradeEvent",
+	"TemplateUpgradeFailedEvent",
+	"TemplateUpgradedEvent",
+	"TimedOutHostOperationEvent",
+	"UnlicensedVirtualMachinesEvent",
+	"UnlicensedVirtualMachinesFoundEvent",
+	"UpdatedAgentBeingRestartedEvent",
+	"UpgradeEvent",
+	"UplinkPortMtuNotSupportEvent",
+	"UplinkPortMtuSupportEvent",
+	"UplinkPortVlanTrunkedEvent",
+	"UplinkPortVlanUntrunkedEvent",
+	"UserAssignedToGroup",
+	"UserLoginSessionEvent",
+	"UserLogoutSessionEvent",
+	"UserPasswordChanged",
+	"UserUnassignedFromGroup",
+	"UserUpgradeEvent",
+	"VMFSDatastoreCreatedEvent",
+	"VMFSDatastoreExpandedEvent",
+	"VMFSDatastoreExtendedEvent",
+	"VMotionLicenseExpiredEvent",
+	"VcAgentUninstallFailedEvent",
+	"VcAgentUninstalledEvent",
+	"VcAgentUpgradeFailedEvent",
+	"VcAgentUpgradedEvent",
+	"VimAccountPasswordChangedEvent",
+	"VmAcquiredMksTicketEvent",
+	"VmAcquiredTicketEvent",
+	"VmAutoRenameEvent",
+	"VmBeingClonedEvent",
+	"VmBeingClonedNoFolderEvent",
+	"VmBeingCreatedEvent",
+	"VmBeingDeployedEvent",
+	"VmBeingHotMigratedEvent",
+	"VmBeingMigratedEvent",
+	"VmBeingRelocatedEvent",
+	"VmCloneEvent",
+	"VmCloneFailedEvent",
+	"VmClonedEvent",
+	"VmConfigMissingEvent",
+	"VmConnectedEvent",
+	"VmCreatedEvent",
+	"VmDasBeingResetEvent",
+	"VmDasBeingResetWithScreenshotEvent",
+	"VmDasResetFailedEvent",
+	"VmDasUpdateErrorEvent",
+	"VmDasUpdateOkEvent",
+	"VmDateRolledBackEvent",
+	"VmDeployFailedEvent",
+	"VmDeployedEvent",
+	"VmDisconnectedEvent",
+	"VmDiscoveredEvent",
+	"VmDiskFailedEvent",
+	"VmEmigratingEvent",
+	"VmEndRecordingEvent",
+	"VmEndReplayingEvent",
+	"VmEvent",
+	"VmFailedMigrateEvent",
+	"VmFailedRelayoutEvent",
+	"VmFailedRelayoutOnVmfs2DatastoreEvent",
+	"VmFailedStartingSecondaryEvent",
+	"VmFailedToPowerOffEvent",
+	"VmFailedToPowerOnEvent",
+	"VmFailedToRebootGuestEvent",
+	"VmFailedToResetEvent",
+	"VmFailedToShutdownGuestEvent",
+	"VmFailedToStandbyGuestEvent",
+	"VmFailedToSuspendEvent",
+	"VmFailedUpdatingSecondaryConfig",
+	"VmFailoverFailed",
+	"VmFaultToleranceStateChangedEvent",
+	"VmFaultToleranceTurnedOffEvent",
+	"VmFaultToleranceVmTerminatedEvent",
+	"VmGuestOSCrashedEvent",
+	"VmGuestRebootEvent",
+	"VmGuestShutdownEvent",
+	"VmGuestStandbyEvent",
+	"VmHealthMonitoringStateChangedEvent",
+	"VmInstanceUuidAssignedEvent",
+	"VmInstanceUuidChangedEvent",
+	"VmInstanceUuidConflictEvent",
+	"VmMacAssignedEvent",
+	"VmMacChangedEvent",
+	"VmMacConflictEvent",
+	"VmMaxFTRestartCountReached",
+	"VmMaxRestartCountReached",
+	"VmMessageErrorEvent",
+	"VmMessageEvent",
+	"VmMessageWarningEvent",
+	"VmMigratedEvent",
+	"VmNoCompatibleHostForSecondaryEvent",
+	"VmNoNetworkAccessEvent",
+	"VmOrphanedEvent",
+	"VmPowerOffOnIsolationEvent",
+	"VmPoweredOffEvent",
+	"VmPoweredOnEvent",
+	"VmPoweringOnWithCustomizedDVPortEvent",
+	"VmPrimaryFailoverEvent",
+	"VmReconfiguredEvent",
+	"VmRegisteredEvent",
+	"VmRelayoutSuccessfulEvent",
+	"VmRelayoutUpToDateEvent",
+	"VmReloadFromPathEvent",
+	"VmReloadFromPathFailedEvent",
+	"VmRelocateFailedEvent",
+	"VmRelocateSpecEvent",
+	"VmRelocatedEvent",
+	"VmRemoteConsoleConnectedEvent",
+	"VmRemoteConsoleDisconnectedEvent",
+	"VmRemovedEvent",
+	"VmRenamedEvent",
+	"VmRequirementsExceedCurrentEVCModeEvent",
+	"VmResettingEvent",
+	"VmResourcePoolMovedEvent",
+	"VmResourceReallocatedEvent",
+	"VmRestartedOnAlternateHostEvent",
+	"VmResumingEvent",
+	"VmSecondaryAddedEvent",
+	"VmSecondaryDisabledBySystemEvent",
+	"VmSecondaryDisabledEvent",
+	"VmSecondaryEnabledEvent",
+	"VmSecondaryStartedEvent",
+	"VmShutdownOnIsolationEvent",
+	"VmStartRecordingEvent",
+	"VmStartReplayingEvent",
+	"VmStartingEvent",
+	"VmStartingSecondaryEvent",
+	"VmStaticMacConflictEvent",
+	"VmStoppingEvent",
+	"VmSuspendedEvent",
+	"VmSuspendingEvent",
+	"VmTimedoutStartingSecondaryEvent",
+	"VmUnsupportedStartingEvent",
+	"VmUpgradeCompleteEvent",
+	"VmUpgradeFailedEvent",
+	"VmUpgradingEvent",
+	"VmUuidAssignedEvent",
+	"VmUuidChangedEvent",
+	"VmUuidConflictEvent",
+	"VmVnicPoolReservationViolationClearEvent",
+	"VmVnicPoolReservationViolationRaiseEvent",
+	"VmWwnAssignedEvent",
+	"VmWwnChangedEvent",
+	"VmWwnConflictEvent",
+	"WarningUpgradeEvent",
+}
+
+// leafTypeNames lists the names of the vSphere event types that no other
+// event type embeds.
+var leafTypeNames = []string{
+	"AccountCreatedEvent",
+	"AccountRemovedEvent",
+	"AccountUpdatedEvent",
+	"AdminPasswordNotChangedEvent",
+	"AlarmAcknowledgedEvent",
+	"AlarmActionTriggeredEvent",
+	"AlarmClearedEvent",
+	"AlarmCreatedEvent",
+	"AlarmEmailCompletedEvent",
+	"AlarmEmailFailedEvent",
+	"AlarmReconfiguredEvent",
+	"AlarmRemovedEvent",
+	"AlarmScriptCompleteEvent",
+	"AlarmScriptFailedEvent",
+	"AlarmSnmpCompletedEvent",
+	"AlarmSnmpFailedEvent",
+	"AlarmStatusChangedEvent",
+	"AllVirtualMachinesLicensedEvent",
+	"AlreadyAuthenticatedSessionEvent",
+	"BadUsernameSessionEvent",
+	"CanceledHostOperationEvent",
+	"ClusterComplianceCheckedEvent",
+	"ClusterCreatedEvent",
+	"ClusterDestroyedEvent",
+	"ClusterReconfiguredEvent",
+	"CustomFieldDefAddedEvent",
+	"CustomFieldDefRemovedEvent",
+	"CustomFieldDefRenamedEvent",
+	"CustomFieldValueChangedEvent",
+	"CustomizationLinuxIdentityFailed",
+	"CustomizationNetworkSetupFailed",
+	"CustomizationStartedEvent",
+	"CustomizationSucceeded",
+	"CustomizationSysprepFailed",
+	"CustomizationUnknownFailure",
+	"DVPortgroupCreatedEvent",
+	"DVPortgroupDestroyedEvent",
+	"DVPortgroupReconfiguredEvent",
+	"DVPortgroupRenamedEvent",
+	"DasAdmissionControlDisabledEvent",
+	"DasAdmissionControlEnabledEvent",
+	"DasAgentFoundEvent",
+	"DasAgentUnavailableEvent",
+	"DasClusterIsolatedEvent",
+	"DasDisabledEvent",
+	"DasEnabledEvent",
+	"DasHostFailedEvent",
+	"DasHostIsolatedEvent",
+	"DatacenterCreatedEvent",
+	"DatacenterRenamedEvent",
+	"DatastoreCapacityIncreasedEvent",
+	"DatastoreDestroyedEvent",
+	"DatastoreDiscoveredEvent",
+	"DatastoreDuplicatedEvent",
+	"DatastoreFileCopiedEvent",
+	"DatastoreFileDeletedEvent",
+	"DatastoreFileMovedEvent",
+	"DatastoreIORMReconfiguredEvent",
+	"DatastorePrincipalConfigured",
+	"DatastoreRemovedOnHostEvent",
+	"DatastoreRenamedEvent",
+	"DatastoreRenamedOnHostEvent",
+	"DrsDisabledEvent",
+	"DrsEnabledEvent",
+	"DrsEnteredStandbyModeEvent",
+	"DrsEnteringStandbyModeEvent",
+	"DrsExitStandbyModeFailedEvent",
+	"DrsExitedStandbyModeEvent",
+	"DrsExitingStandbyModeEvent",
+	"DrsInvocationFailedEvent",
+	"DrsRecoveredFromFailureEvent",
+	"DrsResourceConfigureFailedEvent",
+	"DrsResourceConfigureSyncedEvent",
+	"DrsRuleComplianceEvent",
+	"DrsRuleViolationEvent",
+	"DrsSoftRuleViolationEvent",
+	"DrsVmMigratedEvent",
+	"DrsVmPoweredOnEvent",
+	"DuplicateIpDetectedEvent",
+	"DvpgImportEvent",
+	"DvpgRestoreEvent",
+	"DvsCreatedEvent",
+	"DvsDestroyedEvent",
+	"DvsHostBackInSyncEvent",
+	"DvsHostJoinedEvent",
+	"DvsHostLeftEvent",
+	"DvsHostStatusUpdated",
+	"DvsHostWentOutOfSyncEvent",
+	"DvsImportEvent",
+	"DvsMergedEvent",
+	"DvsPortBlockedEvent",
+	"DvsPortConnectedEvent",
+	"DvsPortCreatedEvent",
+	"DvsPortDeletedEvent",
+	"DvsPortDisconnectedEvent",
+	"DvsPortEnteredPassthruEvent",
+	"DvsPortExitedPassthruEvent",
+	"DvsPortJoinPortgroupEvent",
+	"DvsPortLeavePortgroupEvent",
+	"DvsPortLinkDownEvent",
+	"DvsPortLinkUpEvent",
+	"DvsPortReconfiguredEvent",
+	"DvsPortRuntimeChangeEvent",
+	"DvsPortUnblockedEvent",
+	"DvsPortVendorSpecificStateChangeEvent",
+	"DvsReconfiguredEvent",
+	"DvsRenamedEvent",
+	"DvsRestoreEvent",
+	"DvsUpgradeAvailableEvent",
+	"DvsUpgradeInProgressEvent",
+	"DvsUpgradeRejectedEvent",
+	"DvsUpgradedEvent",
+	"EnteredMaintenanceModeEvent",
+	"EnteringMaintenanceModeEvent",
+	"ErrorUpgradeEvent",
+	"EventEx",
+	"ExitMaintenanceModeEvent",
+	"ExtendedEvent",
+	"FailoverLevelRestored",
+	"GeneralHostErrorEvent",
+	"GeneralHostInfoEvent",
+	"GeneralHostWarningEvent",
+	"GeneralUserEvent",
+	"GeneralVmErrorEvent",
+	"GeneralVmInfoEvent",
+	"GeneralVmWarningEvent",
+	"GhostDvsProxySwitchDetectedEvent",
+	"GhostDvsProxySwitchRemovedEvent",
+	"GlobalMessageChangedEvent",
+	"HealthStatusChangedEvent",
+	"HostAddFailedEvent",
+	"HostAddedEvent",
+	"HostAdminDisableEvent",
+	"HostAdminEnableEvent",
+	"HostCnxFailedAccountFailedEvent",
+	"HostCnxFailedAlreadyManagedEvent",
+	"HostCnxFailedBadCcagentEvent",
+	"HostCnxFailedBadUsernameEvent",
+	"HostCnxFailedBadVersionEvent",
+	"HostCnxFailedCcagentUpgradeEvent",
+	"HostCnxFailedEvent",
+	"HostCnxFailedNetworkErrorEvent",
+	"HostCnxFailedNoAccessEvent",
+	"HostCnxFailedNoConnectionEvent",
+	"HostCnxFailedNoLicenseEvent",
+	"HostCnxFailedNotFoundEvent",
+	"HostCnxFailedTimeoutEvent",
+	"HostComplianceCheckedEvent",
+	"HostCompliantEvent",
+	"HostConfigAppliedEvent",
+	"HostConnectedEvent",
+	"HostConnectionLostEvent",
+	"HostDasDisabledEvent",
+	"HostDasDisablingEvent",
+	"HostDasEnabledEvent",
+	"HostDasEnablingEvent",
+	"HostDasErrorEvent",
+	"HostDasOkEvent",
+	"HostDisconnectedEvent",
+	"HostEnableAdminFailedEvent",
+	"HostExtraNetworksEvent",
+	"HostGetShortNameFailedEvent",
+	"HostInAuditModeEvent",
+	"HostInventoryFullEvent",
+	"HostInventoryUnreadableEvent",
+	"HostIpChangedEvent",
+	"HostIpInconsistentEvent",
+	"HostIpToShortNameFailedEvent",
+	"HostIsolationIpPingFailedEvent",
+	"HostLicenseExpiredEvent",
+	"HostLocalPortCreatedEvent",
+	"HostMissingNetworksEvent",
+	"HostMonitoringStateChangedEvent",
+	"HostNoAvailableNetworksEvent",
+	"HostNoHAEnabledPortGroupsEvent",
+	"HostNoRedundantManagementNetworkEvent",
+	"HostNonCompliantEvent",
+	"HostNotInClusterEvent",
+	"HostOvercommittedEvent",
+	"HostPrimaryAgentNotShortNameEvent",
+	"HostProfileAppliedEvent",
+	"HostReconnectionFailedEvent",
+	"HostRemovedEvent",
+	"HostShortNameInconsistentEvent",
+	"HostShortNameToIpFailedEvent",
+	"HostShutdownEvent",
+	"HostSpecificationChangedEvent",
+	"HostSpecificationRequireEvent",
+	"HostSpecificationUpdateEvent",
+	"HostStatusChangedEvent",
+	"HostSubSpecificationDeleteEvent",
+	"HostSubSpecificationUpdateEvent",
+	"HostSyncFailedEvent",
+	"HostUpgradeFailedEvent",
+	"HostUserWorldSwapNotEnabledEvent",
+	"HostVnicConnectedToCustomizedDVPortEvent",
+	"HostWwnChangedEvent",
+	"HostWwnConflictEvent",
+	"IScsiBootFailureEvent",
+	"IncorrectHostInformationEvent",
+	"InfoUpgradeEvent",
+	"InsufficientFailoverResourcesEvent",
+	"InvalidEditionEvent",
+	"LicenseExpiredEvent",
+	"LicenseNonComplianceEvent",
+	"LicenseRestrictedEvent",
+	"LicenseServerAvailableEvent",
+	"LicenseServerUnavailableEvent",
+	"LocalDatastoreCreatedEvent",
+	"LocalTSMEnabledEvent",
+	"LockerMisconfiguredEvent",
+	"LockerReconfiguredEvent",
+	"MigrationErrorEvent",
+	"MigrationHostErrorEvent",
+	"MigrationHostWarningEvent",
+	"MigrationResourceErrorEvent",
+	"MigrationResourceWarningEvent",
+	"MigrationWarningEvent",
+	"MtuMatchEvent",
+	"MtuMismatchEvent",
+	"NASDatastoreCreatedEvent",
+	"NetworkRollbackEvent",
+	"NoAccessUserEvent",
+	"NoDatastoresConfiguredEvent",
+	"NoLicenseEvent",
+	"NoMaintenanceModeDrsRecommendationForVM",
+	"NonVIWorkloadDetectedOnDatastoreEvent",
+	"NotEnoughResourcesToStartVmEvent",
+	"OutOfSyncDvsHost",
+	"PermissionAddedEvent",
+	"PermissionRemovedEvent",
+	"PermissionUpdatedEvent",
+	"ProfileAssociatedEvent",
+	"ProfileChangedEvent",
+	"ProfileCreatedEvent",
+	"ProfileDissociatedEvent",
+	"ProfileReferenceHostChangedEvent",
+	"ProfileRemovedEvent",
+	"RecoveryEvent",
+	"RemoteTSMEnabledEvent",
+	"ResourcePoolCreatedEvent",
+	"ResourcePoolDestroyedEvent",
+	"ResourcePoolMovedEvent",
+	"ResourcePoolReconfiguredEvent",
+	"ResourceViolatedEvent",
+	"RoleAddedEvent",
+	"RoleRemovedEvent",
+	"RoleUpdatedEvent",
+	"RollbackEvent",
+	"ScheduledTaskCompletedEvent",
+	"ScheduledTaskCreatedEvent",
+	"ScheduledTaskEmailCompletedEvent",
+	"ScheduledTaskEmailFailedEvent",
+	"ScheduledTaskFailedEvent",
+	"ScheduledTaskReconfiguredEvent",
+	"ScheduledTaskRemovedEvent",
+	"ScheduledTaskStartedEvent",
+	"ServerLicenseExpiredEvent",
+	"ServerStartedSessionEvent",
+	"SessionTerminatedEvent",
+	"TaskTimeoutEvent",
+	"TeamingMatchEvent",
+	"TeamingMisMatchEvent",
+	"TemplateBeingUpgradedEvent",
+	"TemplateUpgradeFailedEvent",
+	"TemplateUpgradedEvent",
+	"TimedOutHostOperationEvent",
+	"UnlicensedVirtualMachinesEvent",
+	"UnlicensedVirtualMachinesFoundEvent",
+	"UpdatedAgentBeingRestartedEvent",
+	"UplinkPortMtuNotSupportEvent",
+	"UplinkPortMtuSupportEvent",
+	"UplinkPortVlanTrunkedEvent",
+	"UplinkPortVlanUntrunkedEvent",
+	"UserAssignedToGroup",
+	"UserLoginSessionEvent",
+	"UserLogoutSessionEvent",
+	"UserPasswordChanged",
+	"UserUnassignedFromGroup",
+	"UserUpgradeEvent",
+	"VMFSDatastoreCreatedEvent",
+	"VMFSDatastoreExpandedEvent",
+	"VMFSDatastoreExtendedEvent",
+	"VMotionLicenseExpiredEvent",
+	"VcAgentUninstallFailedEvent",
+	"VcAgentUninstalledEvent",
+	"VcAgentUpgradeFailedEvent",
+	"VcAgentUpgradedEvent",
+	"VimAccountPasswordChangedEvent",
+	"VmAcquiredMksTicketEvent",
+	"VmAcquiredTicketEvent",
+	"VmAutoRenameEvent",
+	"VmBeingClonedEvent",
+	"VmBeingClonedNoFolderEvent",
+	"VmBeingCreatedEvent",
+	"VmBeingDeployedEvent",
+	"VmBeingHotMigratedEvent",
+	"VmBeingMigratedEvent",
+	"VmBeingRelocatedEvent",
+	"VmCloneFailedEvent",
+	"VmClonedEvent",
+	"VmConfigMissingEvent",
+	"VmConnectedEvent",
+	"VmCreatedEvent",
+	"VmDasBeingResetWithScreenshotEvent",
+	"VmDasResetFailedEvent",
+	"VmDasUpdateErrorEvent",
+	"VmDasUpdateOkEvent",
+	"VmDateRolledBackEvent",
+	"VmDeployFailedEvent",
+	"VmDeployedEvent",
+	"VmDisconnectedEvent",
+	"VmDiscoveredEvent",
+	"VmDiskFailedEvent",
+	"VmEmigratingEvent",
+	"VmEndRecordingEvent",
+	"VmEndReplayingEvent",
+	"VmFailedMigrateEvent",
+	"VmFailedRelayoutEvent",
+	"VmFailedRelayoutOnVmfs2DatastoreEvent",
+	"VmFailedStartingSecondaryEvent",
+	"VmFailedToPowerOffEvent",
+	"VmFailedToPowerOnEvent",
+	"VmFailedToRebootGuestEvent",
+	"VmFailedToResetEvent",
+	"VmFailedToShutdownGuestEvent",
+	"VmFailedToStandbyGuestEvent",
+	"VmFailedToSuspendEvent",
+	"VmFailedUpdatingSecondaryConfig",
+	"VmFailoverFailed",
+	"VmFaultToleranceStateChangedEvent",
+	"VmFaultToleranceTurnedOffEvent",
+	"VmFaultToleranceVmTerminatedEvent",
+	"VmGuestOSCrashedEvent",
+	"VmGuestRebootEvent",
+	"VmGuestShutdownEvent",
+	"VmGuestStandbyEvent",
+	"VmHealthMonitoringStateChangedEvent",
+	"VmInstanceUuidAssignedEvent",
+	"VmInstanceUuidChangedEvent",
+	"VmInstanceUuidConflictEvent",
+	"VmMacAssignedEvent",
+	"VmMacChangedEvent",
+	"VmMacConflictEvent",
+	"VmMaxFTRestartCountReached",
+	"VmMaxRestartCountReached",
+	"VmMessageErrorEvent",
+	"VmMessageEvent",
+	"VmMessageWarningEvent",
+	"VmNoCompatibleHostForSecondaryEvent",
+	"VmNoNetworkAccessEvent",
+	"VmOrphanedEvent",
+	"VmPowerOffOnIsolationEvent",
+	"VmPoweringOnWithCustomizedDVPortEvent",
+	"VmPrimaryFailoverEvent",
+	"VmReconfiguredEvent",
+	"VmRegisteredEvent",
+	"VmRelayoutSuccessfulEvent",
+	"VmRelayoutUpToDateEvent",
+	"VmReloadFromPathEvent",
+	"VmReloadFromPathFailedEvent",
+	"VmRelocateFailedEvent",
+	"VmRelocatedEvent",
+	"VmRemoteConsoleConnectedEvent",
+	"VmRemoteConsoleDisconnectedEvent",
+	"VmRemovedEvent",
+	"VmRenamedEvent",
+	"VmRequirementsExceedCurrentEVCModeEvent",
+	"VmResettingEvent",
+	"VmResourcePoolMovedEvent",
+	"VmResourceReallocatedEvent",
+	"VmRestartedOnAlternateHostEvent",
+	"VmResumingEvent",
+	"VmSecondaryAddedEvent",
+	"VmSecondaryDisabledBySystemEvent",
+	"VmSecondaryDisabledEvent",
+	"VmSecondaryEnabledEvent",
+	"VmSecondaryStartedEvent",
+	"VmShutdownOnIsolationEvent",
+	"VmStartRecordingEvent",
+	"VmStartReplayingEvent",
+	"VmStartingSecondaryEvent",
+	"VmStaticMacConflictEvent",
+	"VmStoppingEvent",
+	"VmSuspendedEvent",
+	"VmSuspendingEvent",
+	"VmTimedoutStartingSecondaryEvent",
+	"VmUnsupportedStartingEvent",
+	"VmUpgradeCompleteEvent",
+	"VmUpgradeFailedEvent",
+	"VmUpgradingEvent",
+	"VmUuidAssignedEvent",
+	"VmUuidChangedEvent",
+	"VmUuidConflictEvent",
+	"VmVnicPoolReservationViolationClearEvent",
+	"VmVnicPoolReservationViolationRaiseEvent",
+	"VmWwnAssignedEvent",
+	"VmWwnChangedEvent",
+	"VmWwnConflictEvent",
+	"WarningUpgradeEvent",
+}
+
+// OnAccountCreated registers the handler for AccountCreatedEvent events.
+func (r *Router) OnAccountCreated(fn func(context.Context, *types.AccountCreatedEvent) error) {
+	r.On("AccountCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AccountCreatedEvent))
+	})
+}
+
+// OnAccountRemoved registers the handler for AccountRemovedEvent events.
+func (r *Router) OnAccountRemoved(fn func(context.Context, *types.AccountRemovedEvent) error) {
+	r.On("AccountRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AccountRemovedEvent))
+	})
+}
+
+// OnAccountUpdated registers the handler for AccountUpdatedEvent events.
+func (r *Router) OnAccountUpdated(fn func(context.Context, *types.AccountUpdatedEvent) error) {
+	r.On("AccountUpdatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AccountUpdatedEvent))
+	})
+}
+
+// OnAdminPasswordNotChanged registers the handler for AdminPasswordNotChangedEvent events.
+func (r *Router) OnAdminPasswordNotChanged(fn func(context.Context, *types.AdminPasswordNotChangedEvent) error) {
+	r.On("AdminPasswordNotChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AdminPasswordNotChangedEvent))
+	})
+}
+
+// OnAlarmAcknowledged registers the handler for AlarmAcknowledgedEvent events.
+func (r *Router) OnAlarmAcknowledged(fn func(context.Context, *types.AlarmAcknowledgedEvent) error) {
+	r.On("AlarmAcknowledgedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmAcknowledgedEvent))
+	})
+}
+
+// OnAlarmActionTriggered registers the handler for AlarmActionTriggeredEvent events.
+func (r *Router) OnAlarmActionTriggered(fn func(context.Context, *types.AlarmActionTriggeredEvent) error) {
+	r.On("AlarmActionTriggeredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmActionTriggeredEvent))
+	})
+}
+
+// OnAlarmCleared registers the handler for AlarmClearedEvent events.
+func (r *Router) OnAlarmCleared(fn func(context.Context, *types.AlarmClearedEvent) error) {
+	r.On("AlarmClearedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmClearedEvent))
+	})
+}
+
+// OnAlarmCreated registers the handler for AlarmCreatedEvent events.
+func (r *Router) OnAlarmCreated(fn func(context.Context, *types.AlarmCreatedEvent) error) {
+	r.On("AlarmCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmCreatedEvent))
+	})
+}
+
+// OnAlarmEmailCompleted registers the handler for AlarmEmailCompletedEvent events.
+func (r *Router) OnAlarmEmailCompleted(fn func(context.Context, *types.AlarmEmailCompletedEvent) error) {
+	r.On("AlarmEmailCompletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmEmailCompletedEvent))
+	})
+}
+
+// OnAlarmEmailFailed registers the handler for AlarmEmailFailedEvent events.
+func (r *Router) OnAlarmEmailFailed(fn func(context.Context, *types.AlarmEmailFailedEvent) error) {
+	r.On("AlarmEmailFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmEmailFailedEvent))
+	})
+}
+
+// OnAlarm registers the handler for AlarmEvent events.
+func (r *Router) OnAlarm(fn func(context.Context, *types.AlarmEvent) error) {
+	r.On("AlarmEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmEvent))
+	})
+}
+
+// OnAlarmReconfigured registers the handler for AlarmReconfiguredEvent events.
+func (r *Router) OnAlarmReconfigured(fn func(context.Context, *types.AlarmReconfiguredEvent) error) {
+	r.On("AlarmReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmReconfiguredEvent))
+	})
+}
+
+// OnAlarmRemoved registers the handler for AlarmRemovedEvent events.
+func (r *Router) OnAlarmRemoved(fn func(context.Context, *types.AlarmRemovedEvent) error) {
+	r.On("AlarmRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmRemovedEvent))
+	})
+}
+
+// OnAlarmScriptComplete registers the handler for AlarmScriptCompleteEvent events.
+func (r *Router) OnAlarmScriptComplete(fn func(context.Context, *types.AlarmScriptCompleteEvent) error) {
+	r.On("AlarmScriptCompleteEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmScriptCompleteEvent))
+	})
+}
+
+// OnAlarmScriptFailed registers the handler for AlarmScriptFailedEvent events.
+func (r *Router) OnAlarmScriptFailed(fn func(context.Context, *types.AlarmScriptFailedEvent) error) {
+	r.On("AlarmScriptFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmScriptFailedEvent))
+	})
+}
+
+// OnAlarmSnmpCompleted registers the handler for AlarmSnmpCompletedEvent events.
+func (r *Router) OnAlarmSnmpCompleted(fn func(context.Context, *types.AlarmSnmpCompletedEvent) error) {
+	r.On("AlarmSnmpCompletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmSnmpCompletedEvent))
+	})
+}
+
+// OnAlarmSnmpFailed registers the handler for AlarmSnmpFailedEvent events.
+func (r *Router) OnAlarmSnmpFailed(fn func(context.Context, *types.AlarmSnmpFailedEvent) error) {
+	r.On("AlarmSnmpFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmSnmpFailedEvent))
+	})
+}
+
+// OnAlarmStatusChanged registers the handler for AlarmStatusChangedEvent events.
+func (r *Router) OnAlarmStatusChanged(fn func(context.Context, *types.AlarmStatusChangedEvent) error) {
+	r.On("AlarmStatusChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlarmStatusChangedEvent))
+	})
+}
+
+// OnAllVirtualMachinesLicensed registers the handler for AllVirtualMachinesLicensedEvent events.
+func (r *Router) OnAllVirtualMachinesLicensed(fn func(context.Context, *types.AllVirtualMachinesLicensedEvent) error) {
+	r.On("AllVirtualMachinesLicensedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AllVirtualMachinesLicensedEvent))
+	})
+}
+
+// OnAlreadyAuthenticatedSession registers the handler for AlreadyAuthenticatedSessionEvent events.
+func (r *Router) OnAlreadyAuthenticatedSession(fn func(context.Context, *types.AlreadyAuthenticatedSessionEvent) error) {
+	r.On("AlreadyAuthenticatedSessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AlreadyAuthenticatedSessionEvent))
+	})
+}
+
+// OnAuthorization registers the handler for AuthorizationEvent events.
+func (r *Router) OnAuthorization(fn func(context.Context, *types.AuthorizationEvent) error) {
+	r.On("AuthorizationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.AuthorizationEvent))
+	})
+}
+
+// OnBadUsernameSession registers the handler for BadUsernameSessionEvent events.
+func (r *Router) OnBadUsernameSession(fn func(context.Context, *types.BadUsernameSessionEvent) error) {
+	r.On("BadUsernameSessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.BadUsernameSessionEvent))
+	})
+}
+
+// OnCanceledHostOperation registers the handler for CanceledHostOperationEvent events.
+func (r *Router) OnCanceledHostOperation(fn func(context.Context, *types.CanceledHostOperationEvent) error) {
+	r.On("CanceledHostOperationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CanceledHostOperationEvent))
+	})
+}
+
+// OnClusterComplianceChecked registers the handler for ClusterComplianceCheckedEvent events.
+func (r *Router) OnClusterComplianceChecked(fn func(context.Context, *types.ClusterComplianceCheckedEvent) error) {
+	r.On("ClusterComplianceCheckedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterComplianceCheckedEvent))
+	})
+}
+
+// OnClusterCreated registers the handler for ClusterCreatedEvent events.
+func (r *Router) OnClusterCreated(fn func(context.Context, *types.ClusterCreatedEvent) error) {
+	r.On("ClusterCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterCreatedEvent))
+	})
+}
+
+// OnClusterDestroyed registers the handler for ClusterDestroyedEvent events.
+func (r *Router) OnClusterDestroyed(fn func(context.Context, *types.ClusterDestroyedEvent) error) {
+	r.On("ClusterDestroyedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterDestroyedEvent))
+	})
+}
+
+// OnCluster registers the handler for ClusterEvent events.
+func (r *Router) OnCluster(fn func(context.Context, *types.ClusterEvent) error) {
+	r.On("ClusterEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterEvent))
+	})
+}
+
+// OnClusterOvercommitted registers the handler for ClusterOvercommittedEvent events.
+func (r *Router) OnClusterOvercommitted(fn func(context.Context, *types.ClusterOvercommittedEvent) error) {
+	r.On("ClusterOvercommittedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterOvercommittedEvent))
+	})
+}
+
+// OnClusterReconfigured registers the handler for ClusterReconfiguredEvent events.
+func (r *Router) OnClusterReconfigured(fn func(context.Context, *types.ClusterReconfiguredEvent) error) {
+	r.On("ClusterReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterReconfiguredEvent))
+	})
+}
+
+// OnClusterStatusChanged registers the handler for ClusterStatusChangedEvent events.
+func (r *Router) OnClusterStatusChanged(fn func(context.Context, *types.ClusterStatusChangedEvent) error) {
+	r.On("ClusterStatusChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ClusterStatusChangedEvent))
+	})
+}
+
+// OnCustomFieldDefAdded registers the handler for CustomFieldDefAddedEvent events.
+func (r *Router) OnCustomFieldDefAdded(fn func(context.Context, *types.CustomFieldDefAddedEvent) error) {
+	r.On("CustomFieldDefAddedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldDefAddedEvent))
+	})
+}
+
+// OnCustomFieldDef registers the handler for CustomFieldDefEvent events.
+func (r *Router) OnCustomFieldDef(fn func(context.Context, *types.CustomFieldDefEvent) error) {
+	r.On("CustomFieldDefEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldDefEvent))
+	})
+}
+
+// OnCustomFieldDefRemoved registers the handler for CustomFieldDefRemovedEvent events.
+func (r *Router) OnCustomFieldDefRemoved(fn func(context.Context, *types.CustomFieldDefRemovedEvent) error) {
+	r.On("CustomFieldDefRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldDefRemovedEvent))
+	})
+}
+
+// OnCustomFieldDefRenamed registers the handler for CustomFieldDefRenamedEvent events.
+func (r *Router) OnCustomFieldDefRenamed(fn func(context.Context, *types.CustomFieldDefRenamedEvent) error) {
+	r.On("CustomFieldDefRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldDefRenamedEvent))
+	})
+}
+
+// OnCustomField registers the handler for CustomFieldEvent events.
+func (r *Router) OnCustomField(fn func(context.Context, *types.CustomFieldEvent) error) {
+	r.On("CustomFieldEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldEvent))
+	})
+}
+
+// OnCustomFieldValueChanged registers the handler for CustomFieldValueChangedEvent events.
+func (r *Router) OnCustomFieldValueChanged(fn func(context.Context, *types.CustomFieldValueChangedEvent) error) {
+	r.On("CustomFieldValueChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomFieldValueChangedEvent))
+	})
+}
+
+// OnCustomization registers the handler for CustomizationEvent events.
+func (r *Router) OnCustomization(fn func(context.Context, *types.CustomizationEvent) error) {
+	r.On("CustomizationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationEvent))
+	})
+}
+
+// OnCustomizationFailed registers the handler for CustomizationFailed events.
+func (r *Router) OnCustomizationFailed(fn func(context.Context, *types.CustomizationFailed) error) {
+	r.On("CustomizationFailed", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationFailed))
+	})
+}
+
+// OnCustomizationLinuxIdentityFailed registers the handler for CustomizationLinuxIdentityFailed events.
+func (r *Router) OnCustomizationLinuxIdentityFailed(fn func(context.Context, *types.CustomizationLinuxIdentityFailed) error) {
+	r.On("CustomizationLinuxIdentityFailed", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationLinuxIdentityFailed))
+	})
+}
+
+// OnCustomizationNetworkSetupFailed registers the handler for CustomizationNetworkSetupFailed events.
+func (r *Router) OnCustomizationNetworkSetupFailed(fn func(context.Context, *types.CustomizationNetworkSetupFailed) error) {
+	r.On("CustomizationNetworkSetupFailed", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationNetworkSetupFailed))
+	})
+}
+
+// OnCustomizationStarted registers the handler for CustomizationStartedEvent events.
+func (r *Router) OnCustomizationStarted(fn func(context.Context, *types.CustomizationStartedEvent) error) {
+	r.On("CustomizationStartedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationStartedEvent))
+	})
+}
+
+// OnCustomizationSucceeded registers the handler for CustomizationSucceeded events.
+func (r *Router) OnCustomizationSucceeded(fn func(context.Context, *types.CustomizationSucceeded) error) {
+	r.On("CustomizationSucceeded", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationSucceeded))
+	})
+}
+
+// OnCustomizationSysprepFailed registers the handler for CustomizationSysprepFailed events.
+func (r *Router) OnCustomizationSysprepFailed(fn func(context.Context, *types.CustomizationSysprepFailed) error) {
+	r.On("CustomizationSysprepFailed", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationSysprepFailed))
+	})
+}
+
+// OnCustomizationUnknownFailure registers the handler for CustomizationUnknownFailure events.
+func (r *Router) OnCustomizationUnknownFailure(fn func(context.Context, *types.CustomizationUnknownFailure) error) {
+	r.On("CustomizationUnknownFailure", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.CustomizationUnknownFailure))
+	})
+}
+
+// OnDVPortgroupCreated registers the handler for DVPortgroupCreatedEvent events.
+func (r *Router) OnDVPortgroupCreated(fn func(context.Context, *types.DVPortgroupCreatedEvent) error) {
+	r.On("DVPortgroupCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DVPortgroupCreatedEvent))
+	})
+}
+
+// OnDVPortgroupDestroyed registers the handler for DVPortgroupDestroyedEvent events.
+func (r *Router) OnDVPortgroupDestroyed(fn func(context.Context, *types.DVPortgroupDestroyedEvent) error) {
+	r.On("DVPortgroupDestroyedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DVPortgroupDestroyedEvent))
+	})
+}
+
+// OnDVPortgroup registers the handler for DVPortgroupEvent events.
+func (r *Router) OnDVPortgroup(fn func(context.Context, *types.DVPortgroupEvent) error) {
+	r.On("DVPortgroupEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DVPortgroupEvent))
+	})
+}
+
+// OnDVPortgroupReconfigured registers the handler for DVPortgroupReconfiguredEvent events.
+func (r *Router) OnDVPortgroupReconfigured(fn func(context.Context, *types.DVPortgroupReconfiguredEvent) error) {
+	r.On("DVPortgroupReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DVPortgroupReconfiguredEvent))
+	})
+}
+
+// OnDVPortgroupRenamed registers the handler for DVPortgroupRenamedEvent events.
+func (r *Router) OnDVPortgroupRenamed(fn func(context.Context, *types.DVPortgroupRenamedEvent) error) {
+	r.On("DVPortgroupRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DVPortgroupRenamedEvent))
+	})
+}
+
+// OnDasAdmissionControlDisabled registers the handler for DasAdmissionControlDisabledEvent events.
+func (r *Router) OnDasAdmissionControlDisabled(fn func(context.Context, *types.DasAdmissionControlDisabledEvent) error) {
+	r.On("DasAdmissionControlDisabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasAdmissionControlDisabledEvent))
+	})
+}
+
+// OnDasAdmissionControlEnabled registers the handler for DasAdmissionControlEnabledEvent events.
+func (r *Router) OnDasAdmissionControlEnabled(fn func(context.Context, *types.DasAdmissionControlEnabledEvent) error) {
+	r.On("DasAdmissionControlEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasAdmissionControlEnabledEvent))
+	})
+}
+
+// OnDasAgentFound registers the handler for DasAgentFoundEvent events.
+func (r *Router) OnDasAgentFound(fn func(context.Context, *types.DasAgentFoundEvent) error) {
+	r.On("DasAgentFoundEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasAgentFoundEvent))
+	})
+}
+
+// OnDasAgentUnavailable registers the handler for DasAgentUnavailableEvent events.
+func (r *Router) OnDasAgentUnavailable(fn func(context.Context, *types.DasAgentUnavailableEvent) error) {
+	r.On("DasAgentUnavailableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasAgentUnavailableEvent))
+	})
+}
+
+// OnDasClusterIsolated registers the handler for DasClusterIsolatedEvent events.
+func (r *Router) OnDasClusterIsolated(fn func(context.Context, *types.DasClusterIsolatedEvent) error) {
+	r.On("DasClusterIsolatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasClusterIsolatedEvent))
+	})
+}
+
+// OnDasDisabled registers the handler for DasDisabledEvent events.
+func (r *Router) OnDasDisabled(fn func(context.Context, *types.DasDisabledEvent) error) {
+	r.On("DasDisabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasDisabledEvent))
+	})
+}
+
+// OnDasEnabled registers the handler for DasEnabledEvent events.
+func (r *Router) OnDasEnabled(fn func(context.Context, *types.DasEnabledEvent) error) {
+	r.On("DasEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasEnabledEvent))
+	})
+}
+
+// OnDasHostFailed registers the handler for DasHostFailedEvent events.
+func (r *Router) OnDasHostFailed(fn func(context.Context, *types.DasHostFailedEvent) error) {
+	r.On("DasHostFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasHostFailedEvent))
+	})
+}
+
+// OnDasHostIsolated registers the handler for DasHostIsolatedEvent events.
+func (r *Router) OnDasHostIsolated(fn func(context.Context, *types.DasHostIsolatedEvent) error) {
+	r.On("DasHostIsolatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DasHostIsolatedEvent))
+	})
+}
+
+// OnDatacenterCreated registers the handler for DatacenterCreatedEvent events.
+func (r *Router) OnDatacenterCreated(fn func(context.Context, *types.DatacenterCreatedEvent) error) {
+	r.On("DatacenterCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatacenterCreatedEvent))
+	})
+}
+
+// OnDatacenter registers the handler for DatacenterEvent events.
+func (r *Router) OnDatacenter(fn func(context.Context, *types.DatacenterEvent) error) {
+	r.On("DatacenterEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatacenterEvent))
+	})
+}
+
+// OnDatacenterRenamed registers the handler for DatacenterRenamedEvent events.
+func (r *Router) OnDatacenterRenamed(fn func(context.Context, *types.DatacenterRenamedEvent) error) {
+	r.On("DatacenterRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatacenterRenamedEvent))
+	})
+}
+
+// OnDatastoreCapacityIncreased registers the handler for DatastoreCapacityIncreasedEvent events.
+func (r *Router) OnDatastoreCapacityIncreased(fn func(context.Context, *types.DatastoreCapacityIncreasedEvent) error) {
+	r.On("DatastoreCapacityIncreasedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreCapacityIncreasedEvent))
+	})
+}
+
+// OnDatastoreDestroyed registers the handler for DatastoreDestroyedEvent events.
+func (r *Router) OnDatastoreDestroyed(fn func(context.Context, *types.DatastoreDestroyedEvent) error) {
+	r.On("DatastoreDestroyedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreDestroyedEvent))
+	})
+}
+
+// OnDatastoreDiscovered registers the handler for DatastoreDiscoveredEvent events.
+func (r *Router) OnDatastoreDiscovered(fn func(context.Context, *types.DatastoreDiscoveredEvent) error) {
+	r.On("DatastoreDiscoveredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreDiscoveredEvent))
+	})
+}
+
+// OnDatastoreDuplicated registers the handler for DatastoreDuplicatedEvent events.
+func (r *Router) OnDatastoreDuplicated(fn func(context.Context, *types.DatastoreDuplicatedEvent) error) {
+	r.On("DatastoreDuplicatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreDuplicatedEvent))
+	})
+}
+
+// OnDatastore registers the handler for DatastoreEvent events.
+func (r *Router) OnDatastore(fn func(context.Context, *types.DatastoreEvent) error) {
+	r.On("DatastoreEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreEvent))
+	})
+}
+
+// OnDatastoreFileCopied registers the handler for DatastoreFileCopiedEvent events.
+func (r *Router) OnDatastoreFileCopied(fn func(context.Context, *types.DatastoreFileCopiedEvent) error) {
+	r.On("DatastoreFileCopiedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreFileCopiedEvent))
+	})
+}
+
+// OnDatastoreFileDeleted registers the handler for DatastoreFileDeletedEvent events.
+func (r *Router) OnDatastoreFileDeleted(fn func(context.Context, *types.DatastoreFileDeletedEvent) error) {
+	r.On("DatastoreFileDeletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreFileDeletedEvent))
+	})
+}
+
+// OnDatastoreFile registers the handler for DatastoreFileEvent events.
+func (r *Router) OnDatastoreFile(fn func(context.Context, *types.DatastoreFileEvent) error) {
+	r.On("DatastoreFileEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreFileEvent))
+	})
+}
+
+// OnDatastoreFileMoved registers the handler for DatastoreFileMovedEvent events.
+func (r *Router) OnDatastoreFileMoved(fn func(context.Context, *types.DatastoreFileMovedEvent) error) {
+	r.On("DatastoreFileMovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreFileMovedEvent))
+	})
+}
+
+// OnDatastoreIORMReconfigured registers the handler for DatastoreIORMReconfiguredEvent events.
+func (r *Router) OnDatastoreIORMReconfigured(fn func(context.Context, *types.DatastoreIORMReconfiguredEvent) error) {
+	r.On("DatastoreIORMReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreIORMReconfiguredEvent))
+	})
+}
+
+// OnDatastorePrincipalConfigured registers the handler for DatastorePrincipalConfigured events.
+func (r *Router) OnDatastorePrincipalConfigured(fn func(context.Context, *types.DatastorePrincipalConfigured) error) {
+	r.On("DatastorePrincipalConfigured", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastorePrincipalConfigured))
+	})
+}
+
+// OnDatastoreRemovedOnHost registers the handler for DatastoreRemovedOnHostEvent events.
+func (r *Router) OnDatastoreRemovedOnHost(fn func(context.Context, *types.DatastoreRemovedOnHostEvent) error) {
+	r.On("DatastoreRemovedOnHostEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreRemovedOnHostEvent))
+	})
+}
+
+// OnDatastoreRenamed registers the handler for DatastoreRenamedEvent events.
+func (r *Router) OnDatastoreRenamed(fn func(context.Context, *types.DatastoreRenamedEvent) error) {
+	r.On("DatastoreRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreRenamedEvent))
+	})
+}
+
+// OnDatastoreRenamedOnHost registers the handler for DatastoreRenamedOnHostEvent events.
+func (r *Router) OnDatastoreRenamedOnHost(fn func(context.Context, *types.DatastoreRenamedOnHostEvent) error) {
+	r.On("DatastoreRenamedOnHostEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DatastoreRenamedOnHostEvent))
+	})
+}
+
+// OnDrsDisabled registers the handler for DrsDisabledEvent events.
+func (r *Router) OnDrsDisabled(fn func(context.Context, *types.DrsDisabledEvent) error) {
+	r.On("DrsDisabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsDisabledEvent))
+	})
+}
+
+// OnDrsEnabled registers the handler for DrsEnabledEvent events.
+func (r *Router) OnDrsEnabled(fn func(context.Context, *types.DrsEnabledEvent) error) {
+	r.On("DrsEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsEnabledEvent))
+	})
+}
+
+// OnDrsEnteredStandbyMode registers the handler for DrsEnteredStandbyModeEvent events.
+func (r *Router) OnDrsEnteredStandbyMode(fn func(context.Context, *types.DrsEnteredStandbyModeEvent) error) {
+	r.On("DrsEnteredStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsEnteredStandbyModeEvent))
+	})
+}
+
+// OnDrsEnteringStandbyMode registers the handler for DrsEnteringStandbyModeEvent events.
+func (r *Router) OnDrsEnteringStandbyMode(fn func(context.Context, *types.DrsEnteringStandbyModeEvent) error) {
+	r.On("DrsEnteringStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsEnteringStandbyModeEvent))
+	})
+}
+
+// OnDrsExitStandbyModeFailed registers the handler for DrsExitStandbyModeFailedEvent events.
+func (r *Router) OnDrsExitStandbyModeFailed(fn func(context.Context, *types.DrsExitStandbyModeFailedEvent) error) {
+	r.On("DrsExitStandbyModeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsExitStandbyModeFailedEvent))
+	})
+}
+
+// OnDrsExitedStandbyMode registers the handler for DrsExitedStandbyModeEvent events.
+func (r *Router) OnDrsExitedStandbyMode(fn func(context.Context, *types.DrsExitedStandbyModeEvent) error) {
+	r.On("DrsExitedStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsExitedStandbyModeEvent))
+	})
+}
+
+// OnDrsExitingStandbyMode registers the handler for DrsExitingStandbyModeEvent events.
+func (r *Router) OnDrsExitingStandbyMode(fn func(context.Context, *types.DrsExitingStandbyModeEvent) error) {
+	r.On("DrsExitingStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsExitingStandbyModeEvent))
+	})
+}
+
+// OnDrsInvocationFailed registers the handler for DrsInvocationFailedEvent events.
+func (r *Router) OnDrsInvocationFailed(fn func(context.Context, *types.DrsInvocationFailedEvent) error) {
+	r.On("DrsInvocationFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsInvocationFailedEvent))
+	})
+}
+
+// OnDrsRecoveredFromFailure registers the handler for DrsRecoveredFromFailureEvent events.
+func (r *Router) OnDrsRecoveredFromFailure(fn func(context.Context, *types.DrsRecoveredFromFailureEvent) error) {
+	r.On("DrsRecoveredFromFailureEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsRecoveredFromFailureEvent))
+	})
+}
+
+// OnDrsResourceConfigureFailed registers the handler for DrsResourceConfigureFailedEvent events.
+func (r *Router) OnDrsResourceConfigureFailed(fn func(context.Context, *types.DrsResourceConfigureFailedEvent) error) {
+	r.On("DrsResourceConfigureFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsResourceConfigureFailedEvent))
+	})
+}
+
+// OnDrsResourceConfigureSynced registers the handler for DrsResourceConfigureSyncedEvent events.
+func (r *Router) OnDrsResourceConfigureSynced(fn func(context.Context, *types.DrsResourceConfigureSyncedEvent) error) {
+	r.On("DrsResourceConfigureSyncedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsResourceConfigureSyncedEvent))
+	})
+}
+
+// OnDrsRuleCompliance registers the handler for DrsRuleComplianceEvent events.
+func (r *Router) OnDrsRuleCompliance(fn func(context.Context, *types.DrsRuleComplianceEvent) error) {
+	r.On("DrsRuleComplianceEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsRuleComplianceEvent))
+	})
+}
+
+// OnDrsRuleViolation registers the handler for DrsRuleViolationEvent events.
+func (r *Router) OnDrsRuleViolation(fn func(context.Context, *types.DrsRuleViolationEvent) error) {
+	r.On("DrsRuleViolationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsRuleViolationEvent))
+	})
+}
+
+// OnDrsSoftRuleViolation registers the handler for DrsSoftRuleViolationEvent events.
+func (r *Router) OnDrsSoftRuleViolation(fn func(context.Context, *types.DrsSoftRuleViolationEvent) error) {
+	r.On("DrsSoftRuleViolationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsSoftRuleViolationEvent))
+	})
+}
+
+// OnDrsVmMigrated registers the handler for DrsVmMigratedEvent events.
+func (r *Router) OnDrsVmMigrated(fn func(context.Context, *types.DrsVmMigratedEvent) error) {
+	r.On("DrsVmMigratedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsVmMigratedEvent))
+	})
+}
+
+// OnDrsVmPoweredOn registers the handler for DrsVmPoweredOnEvent events.
+func (r *Router) OnDrsVmPoweredOn(fn func(context.Context, *types.DrsVmPoweredOnEvent) error) {
+	r.On("DrsVmPoweredOnEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DrsVmPoweredOnEvent))
+	})
+}
+
+// OnDuplicateIpDetected registers the handler for DuplicateIpDetectedEvent events.
+func (r *Router) OnDuplicateIpDetected(fn func(context.Context, *types.DuplicateIpDetectedEvent) error) {
+	r.On("DuplicateIpDetectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DuplicateIpDetectedEvent))
+	})
+}
+
+// OnDvpgImport registers the handler for DvpgImportEvent events.
+func (r *Router) OnDvpgImport(fn func(context.Context, *types.DvpgImportEvent) error) {
+	r.On("DvpgImportEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvpgImportEvent))
+	})
+}
+
+// OnDvpgRestore registers the handler for DvpgRestoreEvent events.
+func (r *Router) OnDvpgRestore(fn func(context.Context, *types.DvpgRestoreEvent) error) {
+	r.On("DvpgRestoreEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvpgRestoreEvent))
+	})
+}
+
+// OnDvsCreated registers the handler for DvsCreatedEvent events.
+func (r *Router) OnDvsCreated(fn func(context.Context, *types.DvsCreatedEvent) error) {
+	r.On("DvsCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsCreatedEvent))
+	})
+}
+
+// OnDvsDestroyed registers the handler for DvsDestroyedEvent events.
+func (r *Router) OnDvsDestroyed(fn func(context.Context, *types.DvsDestroyedEvent) error) {
+	r.On("DvsDestroyedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsDestroyedEvent))
+	})
+}
+
+// OnDvs registers the handler for DvsEvent events.
+func (r *Router) OnDvs(fn func(context.Context, *types.DvsEvent) error) {
+	r.On("DvsEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsEvent))
+	})
+}
+
+// OnDvsHealthStatusChange registers the handler for DvsHealthStatusChangeEvent events.
+func (r *Router) OnDvsHealthStatusChange(fn func(context.Context, *types.DvsHealthStatusChangeEvent) error) {
+	r.On("DvsHealthStatusChangeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHealthStatusChangeEvent))
+	})
+}
+
+// OnDvsHostBackInSync registers the handler for DvsHostBackInSyncEvent events.
+func (r *Router) OnDvsHostBackInSync(fn func(context.Context, *types.DvsHostBackInSyncEvent) error) {
+	r.On("DvsHostBackInSyncEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHostBackInSyncEvent))
+	})
+}
+
+// OnDvsHostJoined registers the handler for DvsHostJoinedEvent events.
+func (r *Router) OnDvsHostJoined(fn func(context.Context, *types.DvsHostJoinedEvent) error) {
+	r.On("DvsHostJoinedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHostJoinedEvent))
+	})
+}
+
+// OnDvsHostLeft registers the handler for DvsHostLeftEvent events.
+func (r *Router) OnDvsHostLeft(fn func(context.Context, *types.DvsHostLeftEvent) error) {
+	r.On("DvsHostLeftEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHostLeftEvent))
+	})
+}
+
+// OnDvsHostStatusUpdated registers the handler for DvsHostStatusUpdated events.
+func (r *Router) OnDvsHostStatusUpdated(fn func(context.Context, *types.DvsHostStatusUpdated) error) {
+	r.On("DvsHostStatusUpdated", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHostStatusUpdated))
+	})
+}
+
+// OnDvsHostWentOutOfSync registers the handler for DvsHostWentOutOfSyncEvent events.
+func (r *Router) OnDvsHostWentOutOfSync(fn func(context.Context, *types.DvsHostWentOutOfSyncEvent) error) {
+	r.On("DvsHostWentOutOfSyncEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsHostWentOutOfSyncEvent))
+	})
+}
+
+// OnDvsImport registers the handler for DvsImportEvent events.
+func (r *Router) OnDvsImport(fn func(context.Context, *types.DvsImportEvent) error) {
+	r.On("DvsImportEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsImportEvent))
+	})
+}
+
+// OnDvsMerged registers the handler for DvsMergedEvent events.
+func (r *Router) OnDvsMerged(fn func(context.Context, *types.DvsMergedEvent) error) {
+	r.On("DvsMergedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsMergedEvent))
+	})
+}
+
+// OnDvsPortBlocked registers the handler for DvsPortBlockedEvent events.
+func (r *Router) OnDvsPortBlocked(fn func(context.Context, *types.DvsPortBlockedEvent) error) {
+	r.On("DvsPortBlockedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortBlockedEvent))
+	})
+}
+
+// OnDvsPortConnected registers the handler for DvsPortConnectedEvent events.
+func (r *Router) OnDvsPortConnected(fn func(context.Context, *types.DvsPortConnectedEvent) error) {
+	r.On("DvsPortConnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortConnectedEvent))
+	})
+}
+
+// OnDvsPortCreated registers the handler for DvsPortCreatedEvent events.
+func (r *Router) OnDvsPortCreated(fn func(context.Context, *types.DvsPortCreatedEvent) error) {
+	r.On("DvsPortCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortCreatedEvent))
+	})
+}
+
+// OnDvsPortDeleted registers the handler for DvsPortDeletedEvent events.
+func (r *Router) OnDvsPortDeleted(fn func(context.Context, *types.DvsPortDeletedEvent) error) {
+	r.On("DvsPortDeletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortDeletedEvent))
+	})
+}
+
+// OnDvsPortDisconnected registers the handler for DvsPortDisconnectedEvent events.
+func (r *Router) OnDvsPortDisconnected(fn func(context.Context, *types.DvsPortDisconnectedEvent) error) {
+	r.On("DvsPortDisconnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortDisconnectedEvent))
+	})
+}
+
+// OnDvsPortEnteredPassthru registers the handler for DvsPortEnteredPassthruEvent events.
+func (r *Router) OnDvsPortEnteredPassthru(fn func(context.Context, *types.DvsPortEnteredPassthruEvent) error) {
+	r.On("DvsPortEnteredPassthruEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortEnteredPassthruEvent))
+	})
+}
+
+// OnDvsPortExitedPassthru registers the handler for DvsPortExitedPassthruEvent events.
+func (r *Router) OnDvsPortExitedPassthru(fn func(context.Context, *types.DvsPortExitedPassthruEvent) error) {
+	r.On("DvsPortExitedPassthruEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortExitedPassthruEvent))
+	})
+}
+
+// OnDvsPortJoinPortgroup registers the handler for DvsPortJoinPortgroupEvent events.
+func (r *Router) OnDvsPortJoinPortgroup(fn func(context.Context, *types.DvsPortJoinPortgroupEvent) error) {
+	r.On("DvsPortJoinPortgroupEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortJoinPortgroupEvent))
+	})
+}
+
+// OnDvsPortLeavePortgroup registers the handler for DvsPortLeavePortgroupEvent events.
+func (r *Router) OnDvsPortLeavePortgroup(fn func(context.Context, *types.DvsPortLeavePortgroupEvent) error) {
+	r.On("DvsPortLeavePortgroupEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortLeavePortgroupEvent))
+	})
+}
+
+// OnDvsPortLinkDown registers the handler for DvsPortLinkDownEvent events.
+func (r *Router) OnDvsPortLinkDown(fn func(context.Context, *types.DvsPortLinkDownEvent) error) {
+	r.On("DvsPortLinkDownEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortLinkDownEvent))
+	})
+}
+
+// OnDvsPortLinkUp registers the handler for DvsPortLinkUpEvent events.
+func (r *Router) OnDvsPortLinkUp(fn func(context.Context, *types.DvsPortLinkUpEvent) error) {
+	r.On("DvsPortLinkUpEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortLinkUpEvent))
+	})
+}
+
+// OnDvsPortReconfigured registers the handler for DvsPortReconfiguredEvent events.
+func (r *Router) OnDvsPortReconfigured(fn func(context.Context, *types.DvsPortReconfiguredEvent) error) {
+	r.On("DvsPortReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortReconfiguredEvent))
+	})
+}
+
+// OnDvsPortRuntimeChange registers the handler for DvsPortRuntimeChangeEvent events.
+func (r *Router) OnDvsPortRuntimeChange(fn func(context.Context, *types.DvsPortRuntimeChangeEvent) error) {
+	r.On("DvsPortRuntimeChangeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortRuntimeChangeEvent))
+	})
+}
+
+// OnDvsPortUnblocked registers the handler for DvsPortUnblockedEvent events.
+func (r *Router) OnDvsPortUnblocked(fn func(context.Context, *types.DvsPortUnblockedEvent) error) {
+	r.On("DvsPortUnblockedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortUnblockedEvent))
+	})
+}
+
+// OnDvsPortVendorSpecificStateChange registers the handler for DvsPortVendorSpecificStateChangeEvent events.
+func (r *Router) OnDvsPortVendorSpecificStateChange(fn func(context.Context, *types.DvsPortVendorSpecificStateChangeEvent) error) {
+	r.On("DvsPortVendorSpecificStateChangeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsPortVendorSpecificStateChangeEvent))
+	})
+}
+
+// OnDvsReconfigured registers the handler for DvsReconfiguredEvent events.
+func (r *Router) OnDvsReconfigured(fn func(context.Context, *types.DvsReconfiguredEvent) error) {
+	r.On("DvsReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsReconfiguredEvent))
+	})
+}
+
+// OnDvsRenamed registers the handler for DvsRenamedEvent events.
+func (r *Router) OnDvsRenamed(fn func(context.Context, *types.DvsRenamedEvent) error) {
+	r.On("DvsRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsRenamedEvent))
+	})
+}
+
+// OnDvsRestore registers the handler for DvsRestoreEvent events.
+func (r *Router) OnDvsRestore(fn func(context.Context, *types.DvsRestoreEvent) error) {
+	r.On("DvsRestoreEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsRestoreEvent))
+	})
+}
+
+// OnDvsUpgradeAvailable registers the handler for DvsUpgradeAvailableEvent events.
+func (r *Router) OnDvsUpgradeAvailable(fn func(context.Context, *types.DvsUpgradeAvailableEvent) error) {
+	r.On("DvsUpgradeAvailableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsUpgradeAvailableEvent))
+	})
+}
+
+// OnDvsUpgradeInProgress registers the handler for DvsUpgradeInProgressEvent events.
+func (r *Router) OnDvsUpgradeInProgress(fn func(context.Context, *types.DvsUpgradeInProgressEvent) error) {
+	r.On("DvsUpgradeInProgressEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsUpgradeInProgressEvent))
+	})
+}
+
+// OnDvsUpgradeRejected registers the handler for DvsUpgradeRejectedEvent events.
+func (r *Router) OnDvsUpgradeRejected(fn func(context.Context, *types.DvsUpgradeRejectedEvent) error) {
+	r.On("DvsUpgradeRejectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsUpgradeRejectedEvent))
+	})
+}
+
+// OnDvsUpgraded registers the handler for DvsUpgradedEvent events.
+func (r *Router) OnDvsUpgraded(fn func(context.Context, *types.DvsUpgradedEvent) error) {
+	r.On("DvsUpgradedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.DvsUpgradedEvent))
+	})
+}
+
+// OnEnteredMaintenanceMode registers the handler for EnteredMaintenanceModeEvent events.
+func (r *Router) OnEnteredMaintenanceMode(fn func(context.Context, *types.EnteredMaintenanceModeEvent) error) {
+	r.On("EnteredMaintenanceModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EnteredMaintenanceModeEvent))
+	})
+}
+
+// OnEnteredStandbyMode registers the handler for EnteredStandbyModeEvent events.
+func (r *Router) OnEnteredStandbyMode(fn func(context.Context, *types.EnteredStandbyModeEvent) error) {
+	r.On("EnteredStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EnteredStandbyModeEvent))
+	})
+}
+
+// OnEnteringMaintenanceMode registers the handler for EnteringMaintenanceModeEvent events.
+func (r *Router) OnEnteringMaintenanceMode(fn func(context.Context, *types.EnteringMaintenanceModeEvent) error) {
+	r.On("EnteringMaintenanceModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EnteringMaintenanceModeEvent))
+	})
+}
+
+// OnEnteringStandbyMode registers the handler for EnteringStandbyModeEvent events.
+func (r *Router) OnEnteringStandbyMode(fn func(context.Context, *types.EnteringStandbyModeEvent) error) {
+	r.On("EnteringStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EnteringStandbyModeEvent))
+	})
+}
+
+// OnErrorUpgrade registers the handler for ErrorUpgradeEvent events.
+func (r *Router) OnErrorUpgrade(fn func(context.Context, *types.ErrorUpgradeEvent) error) {
+	r.On("ErrorUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ErrorUpgradeEvent))
+	})
+}
+
+// OnEventEx registers the handler for EventEx events.
+func (r *Router) OnEventEx(fn func(context.Context, *types.EventEx) error) {
+	r.On("EventEx", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.EventEx))
+	})
+}
+
+// OnExitMaintenanceMode registers the handler for ExitMaintenanceModeEvent events.
+func (r *Router) OnExitMaintenanceMode(fn func(context.Context, *types.ExitMaintenanceModeEvent) error) {
+	r.On("ExitMaintenanceModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExitMaintenanceModeEvent))
+	})
+}
+
+// OnExitStandbyModeFailed registers the handler for ExitStandbyModeFailedEvent events.
+func (r *Router) OnExitStandbyModeFailed(fn func(context.Context, *types.ExitStandbyModeFailedEvent) error) {
+	r.On("ExitStandbyModeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExitStandbyModeFailedEvent))
+	})
+}
+
+// OnExitedStandbyMode registers the handler for ExitedStandbyModeEvent events.
+func (r *Router) OnExitedStandbyMode(fn func(context.Context, *types.ExitedStandbyModeEvent) error) {
+	r.On("ExitedStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExitedStandbyModeEvent))
+	})
+}
+
+// OnExitingStandbyMode registers the handler for ExitingStandbyModeEvent events.
+func (r *Router) OnExitingStandbyMode(fn func(context.Context, *types.ExitingStandbyModeEvent) error) {
+	r.On("ExitingStandbyModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExitingStandbyModeEvent))
+	})
+}
+
+// OnExtended registers the handler for ExtendedEvent events.
+func (r *Router) OnExtended(fn func(context.Context, *types.ExtendedEvent) error) {
+	r.On("ExtendedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ExtendedEvent))
+	})
+}
+
+// OnFailoverLevelRestored registers the handler for FailoverLevelRestored events.
+func (r *Router) OnFailoverLevelRestored(fn func(context.Context, *types.FailoverLevelRestored) error) {
+	r.On("FailoverLevelRestored", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.FailoverLevelRestored))
+	})
+}
+
+// OnGeneral registers the handler for GeneralEvent events.
+func (r *Router) OnGeneral(fn func(context.Context, *types.GeneralEvent) error) {
+	r.On("GeneralEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralEvent))
+	})
+}
+
+// OnGeneralHostError registers the handler for GeneralHostErrorEvent events.
+func (r *Router) OnGeneralHostError(fn func(context.Context, *types.GeneralHostErrorEvent) error) {
+	r.On("GeneralHostErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralHostErrorEvent))
+	})
+}
+
+// OnGeneralHostInfo registers the handler for GeneralHostInfoEvent events.
+func (r *Router) OnGeneralHostInfo(fn func(context.Context, *types.GeneralHostInfoEvent) error) {
+	r.On("GeneralHostInfoEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralHostInfoEvent))
+	})
+}
+
+// OnGeneralHostWarning registers the handler for GeneralHostWarningEvent events.
+func (r *Router) OnGeneralHostWarning(fn func(context.Context, *types.GeneralHostWarningEvent) error) {
+	r.On("GeneralHostWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralHostWarningEvent))
+	})
+}
+
+// OnGeneralUser registers the handler for GeneralUserEvent events.
+func (r *Router) OnGeneralUser(fn func(context.Context, *types.GeneralUserEvent) error) {
+	r.On("GeneralUserEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralUserEvent))
+	})
+}
+
+// OnGeneralVmError registers the handler for GeneralVmErrorEvent events.
+func (r *Router) OnGeneralVmError(fn func(context.Context, *types.GeneralVmErrorEvent) error) {
+	r.On("GeneralVmErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralVmErrorEvent))
+	})
+}
+
+// OnGeneralVmInfo registers the handler for GeneralVmInfoEvent events.
+func (r *Router) OnGeneralVmInfo(fn func(context.Context, *types.GeneralVmInfoEvent) error) {
+	r.On("GeneralVmInfoEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralVmInfoEvent))
+	})
+}
+
+// OnGeneralVmWarning registers the handler for GeneralVmWarningEvent events.
+func (r *Router) OnGeneralVmWarning(fn func(context.Context, *types.GeneralVmWarningEvent) error) {
+	r.On("GeneralVmWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GeneralVmWarningEvent))
+	})
+}
+
+// OnGhostDvsProxySwitchDetected registers the handler for GhostDvsProxySwitchDetectedEvent events.
+func (r *Router) OnGhostDvsProxySwitchDetected(fn func(context.Context, *types.GhostDvsProxySwitchDetectedEvent) error) {
+	r.On("GhostDvsProxySwitchDetectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GhostDvsProxySwitchDetectedEvent))
+	})
+}
+
+// OnGhostDvsProxySwitchRemoved registers the handler for GhostDvsProxySwitchRemovedEvent events.
+func (r *Router) OnGhostDvsProxySwitchRemoved(fn func(context.Context, *types.GhostDvsProxySwitchRemovedEvent) error) {
+	r.On("GhostDvsProxySwitchRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GhostDvsProxySwitchRemovedEvent))
+	})
+}
+
+// OnGlobalMessageChanged registers the handler for GlobalMessageChangedEvent events.
+func (r *Router) OnGlobalMessageChanged(fn func(context.Context, *types.GlobalMessageChangedEvent) error) {
+	r.On("GlobalMessageChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.GlobalMessageChangedEvent))
+	})
+}
+
+// OnHealthStatusChanged registers the handler for HealthStatusChangedEvent events.
+func (r *Router) OnHealthStatusChanged(fn func(context.Context, *types.HealthStatusChangedEvent) error) {
+	r.On("HealthStatusChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HealthStatusChangedEvent))
+	})
+}
+
+// OnHostAddFailed registers the handler for HostAddFailedEvent events.
+func (r *Router) OnHostAddFailed(fn func(context.Context, *types.HostAddFailedEvent) error) {
+	r.On("HostAddFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostAddFailedEvent))
+	})
+}
+
+// OnHostAdded registers the handler for HostAddedEvent events.
+func (r *Router) OnHostAdded(fn func(context.Context, *types.HostAddedEvent) error) {
+	r.On("HostAddedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostAddedEvent))
+	})
+}
+
+// OnHostAdminDisable registers the handler for HostAdminDisableEvent events.
+func (r *Router) OnHostAdminDisable(fn func(context.Context, *types.HostAdminDisableEvent) error) {
+	r.On("HostAdminDisableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostAdminDisableEvent))
+	})
+}
+
+// OnHostAdminEnable registers the handler for HostAdminEnableEvent events.
+func (r *Router) OnHostAdminEnable(fn func(context.Context, *types.HostAdminEnableEvent) error) {
+	r.On("HostAdminEnableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostAdminEnableEvent))
+	})
+}
+
+// OnHostCnxFailedAccountFailed registers the handler for HostCnxFailedAccountFailedEvent events.
+func (r *Router) OnHostCnxFailedAccountFailed(fn func(context.Context, *types.HostCnxFailedAccountFailedEvent) error) {
+	r.On("HostCnxFailedAccountFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedAccountFailedEvent))
+	})
+}
+
+// OnHostCnxFailedAlreadyManaged registers the handler for HostCnxFailedAlreadyManagedEvent events.
+func (r *Router) OnHostCnxFailedAlreadyManaged(fn func(context.Context, *types.HostCnxFailedAlreadyManagedEvent) error) {
+	r.On("HostCnxFailedAlreadyManagedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedAlreadyManagedEvent))
+	})
+}
+
+// OnHostCnxFailedBadCcagent registers the handler for HostCnxFailedBadCcagentEvent events.
+func (r *Router) OnHostCnxFailedBadCcagent(fn func(context.Context, *types.HostCnxFailedBadCcagentEvent) error) {
+	r.On("HostCnxFailedBadCcagentEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedBadCcagentEvent))
+	})
+}
+
+// OnHostCnxFailedBadUsername registers the handler for HostCnxFailedBadUsernameEvent events.
+func (r *Router) OnHostCnxFailedBadUsername(fn func(context.Context, *types.HostCnxFailedBadUsernameEvent) error) {
+	r.On("HostCnxFailedBadUsernameEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedBadUsernameEvent))
+	})
+}
+
+// OnHostCnxFailedBadVersion registers the handler for HostCnxFailedBadVersionEvent events.
+func (r *Router) OnHostCnxFailedBadVersion(fn func(context.Context, *types.HostCnxFailedBadVersionEvent) error) {
+	r.On("HostCnxFailedBadVersionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedBadVersionEvent))
+	})
+}
+
+// OnHostCnxFailedCcagentUpgrade registers the handler for HostCnxFailedCcagentUpgradeEvent events.
+func (r *Router) OnHostCnxFailedCcagentUpgrade(fn func(context.Context, *types.HostCnxFailedCcagentUpgradeEvent) error) {
+	r.On("HostCnxFailedCcagentUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedCcagentUpgradeEvent))
+	})
+}
+
+// OnHostCnxFailed registers the handler for HostCnxFailedEvent events.
+func (r *Router) OnHostCnxFailed(fn func(context.Context, *types.HostCnxFailedEvent) error) {
+	r.On("HostCnxFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedEvent))
+	})
+}
+
+// OnHostCnxFailedNetworkError registers the handler for HostCnxFailedNetworkErrorEvent events.
+func (r *Router) OnHostCnxFailedNetworkError(fn func(context.Context, *types.HostCnxFailedNetworkErrorEvent) error) {
+	r.On("HostCnxFailedNetworkErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedNetworkErrorEvent))
+	})
+}
+
+// OnHostCnxFailedNoAccess registers the handler for HostCnxFailedNoAccessEvent events.
+func (r *Router) OnHostCnxFailedNoAccess(fn func(context.Context, *types.HostCnxFailedNoAccessEvent) error) {
+	r.On("HostCnxFailedNoAccessEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedNoAccessEvent))
+	})
+}
+
+// OnHostCnxFailedNoConnection registers the handler for HostCnxFailedNoConnectionEvent events.
+func (r *Router) OnHostCnxFailedNoConnection(fn func(context.Context, *types.HostCnxFailedNoConnectionEvent) error) {
+	r.On("HostCnxFailedNoConnectionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedNoConnectionEvent))
+	})
+}
+
+// OnHostCnxFailedNoLicense registers the handler for HostCnxFailedNoLicenseEvent events.
+func (r *Router) OnHostCnxFailedNoLicense(fn func(context.Context, *types.HostCnxFailedNoLicenseEvent) error) {
+	r.On("HostCnxFailedNoLicenseEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedNoLicenseEvent))
+	})
+}
+
+// OnHostCnxFailedNotFound registers the handler for HostCnxFailedNotFoundEvent events.
+func (r *Router) OnHostCnxFailedNotFound(fn func(context.Context, *types.HostCnxFailedNotFoundEvent) error) {
+	r.On("HostCnxFailedNotFoundEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedNotFoundEvent))
+	})
+}
+
+// OnHostCnxFailedTimeout registers the handler for HostCnxFailedTimeoutEvent events.
+func (r *Router) OnHostCnxFailedTimeout(fn func(context.Context, *types.HostCnxFailedTimeoutEvent) error) {
+	r.On("HostCnxFailedTimeoutEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCnxFailedTimeoutEvent))
+	})
+}
+
+// OnHostComplianceChecked registers the handler for HostComplianceCheckedEvent events.
+func (r *Router) OnHostComplianceChecked(fn func(context.Context, *types.HostComplianceCheckedEvent) error) {
+	r.On("HostComplianceCheckedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostComplianceCheckedEvent))
+	})
+}
+
+// OnHostCompliant registers the handler for HostCompliantEvent events.
+func (r *Router) OnHostCompliant(fn func(context.Context, *types.HostCompliantEvent) error) {
+	r.On("HostCompliantEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostCompliantEvent))
+	})
+}
+
+// OnHostConfigApplied registers the handler for HostConfigAppliedEvent events.
+func (r *Router) OnHostConfigApplied(fn func(context.Context, *types.HostConfigAppliedEvent) error) {
+	r.On("HostConfigAppliedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostConfigAppliedEvent))
+	})
+}
+
+// OnHostConnected registers the handler for HostConnectedEvent events.
+func (r *Router) OnHostConnected(fn func(context.Context, *types.HostConnectedEvent) error) {
+	r.On("HostConnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostConnectedEvent))
+	})
+}
+
+// OnHostConnectionLost registers the handler for HostConnectionLostEvent events.
+func (r *Router) OnHostConnectionLost(fn func(context.Context, *types.HostConnectionLostEvent) error) {
+	r.On("HostConnectionLostEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostConnectionLostEvent))
+	})
+}
+
+// OnHostDasDisabled registers the handler for HostDasDisabledEvent events.
+func (r *Router) OnHostDasDisabled(fn func(context.Context, *types.HostDasDisabledEvent) error) {
+	r.On("HostDasDisabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasDisabledEvent))
+	})
+}
+
+// OnHostDasDisabling registers the handler for HostDasDisablingEvent events.
+func (r *Router) OnHostDasDisabling(fn func(context.Context, *types.HostDasDisablingEvent) error) {
+	r.On("HostDasDisablingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasDisablingEvent))
+	})
+}
+
+// OnHostDasEnabled registers the handler for HostDasEnabledEvent events.
+func (r *Router) OnHostDasEnabled(fn func(context.Context, *types.HostDasEnabledEvent) error) {
+	r.On("HostDasEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasEnabledEvent))
+	})
+}
+
+// OnHostDasEnabling registers the handler for HostDasEnablingEvent events.
+func (r *Router) OnHostDasEnabling(fn func(context.Context, *types.HostDasEnablingEvent) error) {
+	r.On("HostDasEnablingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasEnablingEvent))
+	})
+}
+
+// OnHostDasError registers the handler for HostDasErrorEvent events.
+func (r *Router) OnHostDasError(fn func(context.Context, *types.HostDasErrorEvent) error) {
+	r.On("HostDasErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasErrorEvent))
+	})
+}
+
+// OnHostDas registers the handler for HostDasEvent events.
+func (r *Router) OnHostDas(fn func(context.Context, *types.HostDasEvent) error) {
+	r.On("HostDasEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasEvent))
+	})
+}
+
+// OnHostDasOk registers the handler for HostDasOkEvent events.
+func (r *Router) OnHostDasOk(fn func(context.Context, *types.HostDasOkEvent) error) {
+	r.On("HostDasOkEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDasOkEvent))
+	})
+}
+
+// OnHostDisconnected registers the handler for HostDisconnectedEvent events.
+func (r *Router) OnHostDisconnected(fn func(context.Context, *types.HostDisconnectedEvent) error) {
+	r.On("HostDisconnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostDisconnectedEvent))
+	})
+}
+
+// OnHostEnableAdminFailed registers the handler for HostEnableAdminFailedEvent events.
+func (r *Router) OnHostEnableAdminFailed(fn func(context.Context, *types.HostEnableAdminFailedEvent) error) {
+	r.On("HostEnableAdminFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostEnableAdminFailedEvent))
+	})
+}
+
+// OnHost registers the handler for HostEvent events.
+func (r *Router) OnHost(fn func(context.Context, *types.HostEvent) error) {
+	r.On("HostEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostEvent))
+	})
+}
+
+// OnHostExtraNetworks registers the handler for HostExtraNetworksEvent events.
+func (r *Router) OnHostExtraNetworks(fn func(context.Context, *types.HostExtraNetworksEvent) error) {
+	r.On("HostExtraNetworksEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostExtraNetworksEvent))
+	})
+}
+
+// OnHostGetShortNameFailed registers the handler for HostGetShortNameFailedEvent events.
+func (r *Router) OnHostGetShortNameFailed(fn func(context.Context, *types.HostGetShortNameFailedEvent) error) {
+	r.On("HostGetShortNameFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostGetShortNameFailedEvent))
+	})
+}
+
+// OnHostInAuditMode registers the handler for HostInAuditModeEvent events.
+func (r *Router) OnHostInAuditMode(fn func(context.Context, *types.HostInAuditModeEvent) error) {
+	r.On("HostInAuditModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostInAuditModeEvent))
+	})
+}
+
+// OnHostInventoryFull registers the handler for HostInventoryFullEvent events.
+func (r *Router) OnHostInventoryFull(fn func(context.Context, *types.HostInventoryFullEvent) error) {
+	r.On("HostInventoryFullEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostInventoryFullEvent))
+	})
+}
+
+// OnHostInventoryUnreadable registers the handler for HostInventoryUnreadableEvent events.
+func (r *Router) OnHostInventoryUnreadable(fn func(context.Context, *types.HostInventoryUnreadableEvent) error) {
+	r.On("HostInventoryUnreadableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostInventoryUnreadableEvent))
+	})
+}
+
+// OnHostIpChanged registers the handler for HostIpChangedEvent events.
+func (r *Router) OnHostIpChanged(fn func(context.Context, *types.HostIpChangedEvent) error) {
+	r.On("HostIpChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostIpChangedEvent))
+	})
+}
+
+// OnHostIpInconsistent registers the handler for HostIpInconsistentEvent events.
+func (r *Router) OnHostIpInconsistent(fn func(context.Context, *types.HostIpInconsistentEvent) error) {
+	r.On("HostIpInconsistentEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostIpInconsistentEvent))
+	})
+}
+
+// OnHostIpToShortNameFailed registers the handler for HostIpToShortNameFailedEvent events.
+func (r *Router) OnHostIpToShortNameFailed(fn func(context.Context, *types.HostIpToShortNameFailedEvent) error) {
+	r.On("HostIpToShortNameFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostIpToShortNameFailedEvent))
+	})
+}
+
+// OnHostIsolationIpPingFailed registers the handler for HostIsolationIpPingFailedEvent events.
+func (r *Router) OnHostIsolationIpPingFailed(fn func(context.Context, *types.HostIsolationIpPingFailedEvent) error) {
+	r.On("HostIsolationIpPingFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostIsolationIpPingFailedEvent))
+	})
+}
+
+// OnHostLicenseExpired registers the handler for HostLicenseExpiredEvent events.
+func (r *Router) OnHostLicenseExpired(fn func(context.Context, *types.HostLicenseExpiredEvent) error) {
+	r.On("HostLicenseExpiredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostLicenseExpiredEvent))
+	})
+}
+
+// OnHostLocalPortCreated registers the handler for HostLocalPortCreatedEvent events.
+func (r *Router) OnHostLocalPortCreated(fn func(context.Context, *types.HostLocalPortCreatedEvent) error) {
+	r.On("HostLocalPortCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostLocalPortCreatedEvent))
+	})
+}
+
+// OnHostMissingNetworks registers the handler for HostMissingNetworksEvent events.
+func (r *Router) OnHostMissingNetworks(fn func(context.Context, *types.HostMissingNetworksEvent) error) {
+	r.On("HostMissingNetworksEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostMissingNetworksEvent))
+	})
+}
+
+// OnHostMonitoringStateChanged registers the handler for HostMonitoringStateChangedEvent events.
+func (r *Router) OnHostMonitoringStateChanged(fn func(context.Context, *types.HostMonitoringStateChangedEvent) error) {
+	r.On("HostMonitoringStateChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostMonitoringStateChangedEvent))
+	})
+}
+
+// OnHostNoAvailableNetworks registers the handler for HostNoAvailableNetworksEvent events.
+func (r *Router) OnHostNoAvailableNetworks(fn func(context.Context, *types.HostNoAvailableNetworksEvent) error) {
+	r.On("HostNoAvailableNetworksEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostNoAvailableNetworksEvent))
+	})
+}
+
+// OnHostNoHAEnabledPortGroups registers the handler for HostNoHAEnabledPortGroupsEvent events.
+func (r *Router) OnHostNoHAEnabledPortGroups(fn func(context.Context, *types.HostNoHAEnabledPortGroupsEvent) error) {
+	r.On("HostNoHAEnabledPortGroupsEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostNoHAEnabledPortGroupsEvent))
+	})
+}
+
+// OnHostNoRedundantManagementNetwork registers the handler for HostNoRedundantManagementNetworkEvent events.
+func (r *Router) OnHostNoRedundantManagementNetwork(fn func(context.Context, *types.HostNoRedundantManagementNetworkEvent) error) {
+	r.On("HostNoRedundantManagementNetworkEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostNoRedundantManagementNetworkEvent))
+	})
+}
+
+// OnHostNonCompliant registers the handler for HostNonCompliantEvent events.
+func (r *Router) OnHostNonCompliant(fn func(context.Context, *types.HostNonCompliantEvent) error) {
+	r.On("HostNonCompliantEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostNonCompliantEvent))
+	})
+}
+
+// OnHostNotInCluster registers the handler for HostNotInClusterEvent events.
+func (r *Router) OnHostNotInCluster(fn func(context.Context, *types.HostNotInClusterEvent) error) {
+	r.On("HostNotInClusterEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostNotInClusterEvent))
+	})
+}
+
+// OnHostOvercommitted registers the handler for HostOvercommittedEvent events.
+func (r *Router) OnHostOvercommitted(fn func(context.Context, *types.HostOvercommittedEvent) error) {
+	r.On("HostOvercommittedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostOvercommittedEvent))
+	})
+}
+
+// OnHostPrimaryAgentNotShortName registers the handler for HostPrimaryAgentNotShortNameEvent events.
+func (r *Router) OnHostPrimaryAgentNotShortName(fn func(context.Context, *types.HostPrimaryAgentNotShortNameEvent) error) {
+	r.On("HostPrimaryAgentNotShortNameEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostPrimaryAgentNotShortNameEvent))
+	})
+}
+
+// OnHostProfileApplied registers the handler for HostProfileAppliedEvent events.
+func (r *Router) OnHostProfileApplied(fn func(context.Context, *types.HostProfileAppliedEvent) error) {
+	r.On("HostProfileAppliedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostProfileAppliedEvent))
+	})
+}
+
+// OnHostReconnectionFailed registers the handler for HostReconnectionFailedEvent events.
+func (r *Router) OnHostReconnectionFailed(fn func(context.Context, *types.HostReconnectionFailedEvent) error) {
+	r.On("HostReconnectionFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostReconnectionFailedEvent))
+	})
+}
+
+// OnHostRemoved registers the handler for HostRemovedEvent events.
+func (r *Router) OnHostRemoved(fn func(context.Context, *types.HostRemovedEvent) error) {
+	r.On("HostRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostRemovedEvent))
+	})
+}
+
+// OnHostShortNameInconsistent registers the handler for HostShortNameInconsistentEvent events.
+func (r *Router) OnHostShortNameInconsistent(fn func(context.Context, *types.HostShortNameInconsistentEvent) error) {
+	r.On("HostShortNameInconsistentEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostShortNameInconsistentEvent))
+	})
+}
+
+// OnHostShortNameToIpFailed registers the handler for HostShortNameToIpFailedEvent events.
+func (r *Router) OnHostShortNameToIpFailed(fn func(context.Context, *types.HostShortNameToIpFailedEvent) error) {
+	r.On("HostShortNameToIpFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostShortNameToIpFailedEvent))
+	})
+}
+
+// OnHostShutdown registers the handler for HostShutdownEvent events.
+func (r *Router) OnHostShutdown(fn func(context.Context, *types.HostShutdownEvent) error) {
+	r.On("HostShutdownEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostShutdownEvent))
+	})
+}
+
+// OnHostSpecificationChanged registers the handler for HostSpecificationChangedEvent events.
+func (r *Router) OnHostSpecificationChanged(fn func(context.Context, *types.HostSpecificationChangedEvent) error) {
+	r.On("HostSpecificationChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSpecificationChangedEvent))
+	})
+}
+
+// OnHostSpecificationRequire registers the handler for HostSpecificationRequireEvent events.
+func (r *Router) OnHostSpecificationRequire(fn func(context.Context, *types.HostSpecificationRequireEvent) error) {
+	r.On("HostSpecificationRequireEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSpecificationRequireEvent))
+	})
+}
+
+// OnHostSpecificationUpdate registers the handler for HostSpecificationUpdateEvent events.
+func (r *Router) OnHostSpecificationUpdate(fn func(context.Context, *types.HostSpecificationUpdateEvent) error) {
+	r.On("HostSpecificationUpdateEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSpecificationUpdateEvent))
+	})
+}
+
+// OnHostStatusChanged registers the handler for HostStatusChangedEvent events.
+func (r *Router) OnHostStatusChanged(fn func(context.Context, *types.HostStatusChangedEvent) error) {
+	r.On("HostStatusChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostStatusChangedEvent))
+	})
+}
+
+// OnHostSubSpecificationDelete registers the handler for HostSubSpecificationDeleteEvent events.
+func (r *Router) OnHostSubSpecificationDelete(fn func(context.Context, *types.HostSubSpecificationDeleteEvent) error) {
+	r.On("HostSubSpecificationDeleteEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSubSpecificationDeleteEvent))
+	})
+}
+
+// OnHostSubSpecificationUpdate registers the handler for HostSubSpecificationUpdateEvent events.
+func (r *Router) OnHostSubSpecificationUpdate(fn func(context.Context, *types.HostSubSpecificationUpdateEvent) error) {
+	r.On("HostSubSpecificationUpdateEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSubSpecificationUpdateEvent))
+	})
+}
+
+// OnHostSyncFailed registers the handler for HostSyncFailedEvent events.
+func (r *Router) OnHostSyncFailed(fn func(context.Context, *types.HostSyncFailedEvent) error) {
+	r.On("HostSyncFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostSyncFailedEvent))
+	})
+}
+
+// OnHostUpgradeFailed registers the handler for HostUpgradeFailedEvent events.
+func (r *Router) OnHostUpgradeFailed(fn func(context.Context, *types.HostUpgradeFailedEvent) error) {
+	r.On("HostUpgradeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostUpgradeFailedEvent))
+	})
+}
+
+// OnHostUserWorldSwapNotEnabled registers the handler for HostUserWorldSwapNotEnabledEvent events.
+func (r *Router) OnHostUserWorldSwapNotEnabled(fn func(context.Context, *types.HostUserWorldSwapNotEnabledEvent) error) {
+	r.On("HostUserWorldSwapNotEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostUserWorldSwapNotEnabledEvent))
+	})
+}
+
+// OnHostVnicConnectedToCustomizedDVPort registers the handler for HostVnicConnectedToCustomizedDVPortEvent events.
+func (r *Router) OnHostVnicConnectedToCustomizedDVPort(fn func(context.Context, *types.HostVnicConnectedToCustomizedDVPortEvent) error) {
+	r.On("HostVnicConnectedToCustomizedDVPortEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostVnicConnectedToCustomizedDVPortEvent))
+	})
+}
+
+// OnHostWwnChanged registers the handler for HostWwnChangedEvent events.
+func (r *Router) OnHostWwnChanged(fn func(context.Context, *types.HostWwnChangedEvent) error) {
+	r.On("HostWwnChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostWwnChangedEvent))
+	})
+}
+
+// OnHostWwnConflict registers the handler for HostWwnConflictEvent events.
+func (r *Router) OnHostWwnConflict(fn func(context.Context, *types.HostWwnConflictEvent) error) {
+	r.On("HostWwnConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.HostWwnConflictEvent))
+	})
+}
+
+// OnIScsiBootFailure registers the handler for IScsiBootFailureEvent events.
+func (r *Router) OnIScsiBootFailure(fn func(context.Context, *types.IScsiBootFailureEvent) error) {
+	r.On("IScsiBootFailureEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.IScsiBootFailureEvent))
+	})
+}
+
+// OnIncorrectHostInformation registers the handler for IncorrectHostInformationEvent events.
+func (r *Router) OnIncorrectHostInformation(fn func(context.Context, *types.IncorrectHostInformationEvent) error) {
+	r.On("IncorrectHostInformationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.IncorrectHostInformationEvent))
+	})
+}
+
+// OnInfoUpgrade registers the handler for InfoUpgradeEvent events.
+func (r *Router) OnInfoUpgrade(fn func(context.Context, *types.InfoUpgradeEvent) error) {
+	r.On("InfoUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.InfoUpgradeEvent))
+	})
+}
+
+// OnInsufficientFailoverResources registers the handler for InsufficientFailoverResourcesEvent events.
+func (r *Router) OnInsufficientFailoverResources(fn func(context.Context, *types.InsufficientFailoverResourcesEvent) error) {
+	r.On("InsufficientFailoverResourcesEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.InsufficientFailoverResourcesEvent))
+	})
+}
+
+// OnInvalidEdition registers the handler for InvalidEditionEvent events.
+func (r *Router) OnInvalidEdition(fn func(context.Context, *types.InvalidEditionEvent) error) {
+	r.On("InvalidEditionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.InvalidEditionEvent))
+	})
+}
+
+// OnLicense registers the handler for LicenseEvent events.
+func (r *Router) OnLicense(fn func(context.Context, *types.LicenseEvent) error) {
+	r.On("LicenseEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseEvent))
+	})
+}
+
+// OnLicenseExpired registers the handler for LicenseExpiredEvent events.
+func (r *Router) OnLicenseExpired(fn func(context.Context, *types.LicenseExpiredEvent) error) {
+	r.On("LicenseExpiredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseExpiredEvent))
+	})
+}
+
+// OnLicenseNonCompliance registers the handler for LicenseNonComplianceEvent events.
+func (r *Router) OnLicenseNonCompliance(fn func(context.Context, *types.LicenseNonComplianceEvent) error) {
+	r.On("LicenseNonComplianceEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseNonComplianceEvent))
+	})
+}
+
+// OnLicenseRestricted registers the handler for LicenseRestrictedEvent events.
+func (r *Router) OnLicenseRestricted(fn func(context.Context, *types.LicenseRestrictedEvent) error) {
+	r.On("LicenseRestrictedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseRestrictedEvent))
+	})
+}
+
+// OnLicenseServerAvailable registers the handler for LicenseServerAvailableEvent events.
+func (r *Router) OnLicenseServerAvailable(fn func(context.Context, *types.LicenseServerAvailableEvent) error) {
+	r.On("LicenseServerAvailableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseServerAvailableEvent))
+	})
+}
+
+// OnLicenseServerUnavailable registers the handler for LicenseServerUnavailableEvent events.
+func (r *Router) OnLicenseServerUnavailable(fn func(context.Context, *types.LicenseServerUnavailableEvent) error) {
+	r.On("LicenseServerUnavailableEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LicenseServerUnavailableEvent))
+	})
+}
+
+// OnLocalDatastoreCreated registers the handler for LocalDatastoreCreatedEvent events.
+func (r *Router) OnLocalDatastoreCreated(fn func(context.Context, *types.LocalDatastoreCreatedEvent) error) {
+	r.On("LocalDatastoreCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LocalDatastoreCreatedEvent))
+	})
+}
+
+// OnLocalTSMEnabled registers the handler for LocalTSMEnabledEvent events.
+func (r *Router) OnLocalTSMEnabled(fn func(context.Context, *types.LocalTSMEnabledEvent) error) {
+	r.On("LocalTSMEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LocalTSMEnabledEvent))
+	})
+}
+
+// OnLockerMisconfigured registers the handler for LockerMisconfiguredEvent events.
+func (r *Router) OnLockerMisconfigured(fn func(context.Context, *types.LockerMisconfiguredEvent) error) {
+	r.On("LockerMisconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LockerMisconfiguredEvent))
+	})
+}
+
+// OnLockerReconfigured registers the handler for LockerReconfiguredEvent events.
+func (r *Router) OnLockerReconfigured(fn func(context.Context, *types.LockerReconfiguredEvent) error) {
+	r.On("LockerReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.LockerReconfiguredEvent))
+	})
+}
+
+// OnMigrationError registers the handler for MigrationErrorEvent events.
+func (r *Router) OnMigrationError(fn func(context.Context, *types.MigrationErrorEvent) error) {
+	r.On("MigrationErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationErrorEvent))
+	})
+}
+
+// OnMigration registers the handler for MigrationEvent events.
+func (r *Router) OnMigration(fn func(context.Context, *types.MigrationEvent) error) {
+	r.On("MigrationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationEvent))
+	})
+}
+
+// OnMigrationHostError registers the handler for MigrationHostErrorEvent events.
+func (r *Router) OnMigrationHostError(fn func(context.Context, *types.MigrationHostErrorEvent) error) {
+	r.On("MigrationHostErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationHostErrorEvent))
+	})
+}
+
+// OnMigrationHostWarning registers the handler for MigrationHostWarningEvent events.
+func (r *Router) OnMigrationHostWarning(fn func(context.Context, *types.MigrationHostWarningEvent) error) {
+	r.On("MigrationHostWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationHostWarningEvent))
+	})
+}
+
+// OnMigrationResourceError registers the handler for MigrationResourceErrorEvent events.
+func (r *Router) OnMigrationResourceError(fn func(context.Context, *types.MigrationResourceErrorEvent) error) {
+	r.On("MigrationResourceErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationResourceErrorEvent))
+	})
+}
+
+// OnMigrationResourceWarning registers the handler for MigrationResourceWarningEvent events.
+func (r *Router) OnMigrationResourceWarning(fn func(context.Context, *types.MigrationResourceWarningEvent) error) {
+	r.On("MigrationResourceWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationResourceWarningEvent))
+	})
+}
+
+// OnMigrationWarning registers the handler for MigrationWarningEvent events.
+func (r *Router) OnMigrationWarning(fn func(context.Context, *types.MigrationWarningEvent) error) {
+	r.On("MigrationWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MigrationWarningEvent))
+	})
+}
+
+// OnMtuMatch registers the handler for MtuMatchEvent events.
+func (r *Router) OnMtuMatch(fn func(context.Context, *types.MtuMatchEvent) error) {
+	r.On("MtuMatchEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MtuMatchEvent))
+	})
+}
+
+// OnMtuMismatch registers the handler for MtuMismatchEvent events.
+func (r *Router) OnMtuMismatch(fn func(context.Context, *types.MtuMismatchEvent) error) {
+	r.On("MtuMismatchEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.MtuMismatchEvent))
+	})
+}
+
+// OnNASDatastoreCreated registers the handler for NASDatastoreCreatedEvent events.
+func (r *Router) OnNASDatastoreCreated(fn func(context.Context, *types.NASDatastoreCreatedEvent) error) {
+	r.On("NASDatastoreCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NASDatastoreCreatedEvent))
+	})
+}
+
+// OnNetworkRollback registers the handler for NetworkRollbackEvent events.
+func (r *Router) OnNetworkRollback(fn func(context.Context, *types.NetworkRollbackEvent) error) {
+	r.On("NetworkRollbackEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NetworkRollbackEvent))
+	})
+}
+
+// OnNoAccessUser registers the handler for NoAccessUserEvent events.
+func (r *Router) OnNoAccessUser(fn func(context.Context, *types.NoAccessUserEvent) error) {
+	r.On("NoAccessUserEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NoAccessUserEvent))
+	})
+}
+
+// OnNoDatastoresConfigured registers the handler for NoDatastoresConfiguredEvent events.
+func (r *Router) OnNoDatastoresConfigured(fn func(context.Context, *types.NoDatastoresConfiguredEvent) error) {
+	r.On("NoDatastoresConfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NoDatastoresConfiguredEvent))
+	})
+}
+
+// OnNoLicense registers the handler for NoLicenseEvent events.
+func (r *Router) OnNoLicense(fn func(context.Context, *types.NoLicenseEvent) error) {
+	r.On("NoLicenseEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NoLicenseEvent))
+	})
+}
+
+// OnNoMaintenanceModeDrsRecommendationForVM registers the handler for NoMaintenanceModeDrsRecommendationForVM events.
+func (r *Router) OnNoMaintenanceModeDrsRecommendationForVM(fn func(context.Context, *types.NoMaintenanceModeDrsRecommendationForVM) error) {
+	r.On("NoMaintenanceModeDrsRecommendationForVM", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NoMaintenanceModeDrsRecommendationForVM))
+	})
+}
+
+// OnNonVIWorkloadDetectedOnDatastore registers the handler for NonVIWorkloadDetectedOnDatastoreEvent events.
+func (r *Router) OnNonVIWorkloadDetectedOnDatastore(fn func(context.Context, *types.NonVIWorkloadDetectedOnDatastoreEvent) error) {
+	r.On("NonVIWorkloadDetectedOnDatastoreEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NonVIWorkloadDetectedOnDatastoreEvent))
+	})
+}
+
+// OnNotEnoughResourcesToStartVm registers the handler for NotEnoughResourcesToStartVmEvent events.
+func (r *Router) OnNotEnoughResourcesToStartVm(fn func(context.Context, *types.NotEnoughResourcesToStartVmEvent) error) {
+	r.On("NotEnoughResourcesToStartVmEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.NotEnoughResourcesToStartVmEvent))
+	})
+}
+
+// OnOutOfSyncDvsHost registers the handler for OutOfSyncDvsHost events.
+func (r *Router) OnOutOfSyncDvsHost(fn func(context.Context, *types.OutOfSyncDvsHost) error) {
+	r.On("OutOfSyncDvsHost", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.OutOfSyncDvsHost))
+	})
+}
+
+// OnPermissionAdded registers the handler for PermissionAddedEvent events.
+func (r *Router) OnPermissionAdded(fn func(context.Context, *types.PermissionAddedEvent) error) {
+	r.On("PermissionAddedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.PermissionAddedEvent))
+	})
+}
+
+// OnPermission registers the handler for PermissionEvent events.
+func (r *Router) OnPermission(fn func(context.Context, *types.PermissionEvent) error) {
+	r.On("PermissionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.PermissionEvent))
+	})
+}
+
+// OnPermissionRemoved registers the handler for PermissionRemovedEvent events.
+func (r *Router) OnPermissionRemoved(fn func(context.Context, *types.PermissionRemovedEvent) error) {
+	r.On("PermissionRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.PermissionRemovedEvent))
+	})
+}
+
+// OnPermissionUpdated registers the handler for PermissionUpdatedEvent events.
+func (r *Router) OnPermissionUpdated(fn func(context.Context, *types.PermissionUpdatedEvent) error) {
+	r.On("PermissionUpdatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.PermissionUpdatedEvent))
+	})
+}
+
+// OnProfileAssociated registers the handler for ProfileAssociatedEvent events.
+func (r *Router) OnProfileAssociated(fn func(context.Context, *types.ProfileAssociatedEvent) error) {
+	r.On("ProfileAssociatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileAssociatedEvent))
+	})
+}
+
+// OnProfileChanged registers the handler for ProfileChangedEvent events.
+func (r *Router) OnProfileChanged(fn func(context.Context, *types.ProfileChangedEvent) error) {
+	r.On("ProfileChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileChangedEvent))
+	})
+}
+
+// OnProfileCreated registers the handler for ProfileCreatedEvent events.
+func (r *Router) OnProfileCreated(fn func(context.Context, *types.ProfileCreatedEvent) error) {
+	r.On("ProfileCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileCreatedEvent))
+	})
+}
+
+// OnProfileDissociated registers the handler for ProfileDissociatedEvent events.
+func (r *Router) OnProfileDissociated(fn func(context.Context, *types.ProfileDissociatedEvent) error) {
+	r.On("ProfileDissociatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileDissociatedEvent))
+	})
+}
+
+// OnProfile registers the handler for ProfileEvent events.
+func (r *Router) OnProfile(fn func(context.Context, *types.ProfileEvent) error) {
+	r.On("ProfileEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileEvent))
+	})
+}
+
+// OnProfileReferenceHostChanged registers the handler for ProfileReferenceHostChangedEvent events.
+func (r *Router) OnProfileReferenceHostChanged(fn func(context.Context, *types.ProfileReferenceHostChangedEvent) error) {
+	r.On("ProfileReferenceHostChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileReferenceHostChangedEvent))
+	})
+}
+
+// OnProfileRemoved registers the handler for ProfileRemovedEvent events.
+func (r *Router) OnProfileRemoved(fn func(context.Context, *types.ProfileRemovedEvent) error) {
+	r.On("ProfileRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ProfileRemovedEvent))
+	})
+}
+
+// OnRecovery registers the handler for RecoveryEvent events.
+func (r *Router) OnRecovery(fn func(context.Context, *types.RecoveryEvent) error) {
+	r.On("RecoveryEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RecoveryEvent))
+	})
+}
+
+// OnRemoteTSMEnabled registers the handler for RemoteTSMEnabledEvent events.
+func (r *Router) OnRemoteTSMEnabled(fn func(context.Context, *types.RemoteTSMEnabledEvent) error) {
+	r.On("RemoteTSMEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RemoteTSMEnabledEvent))
+	})
+}
+
+// OnResourcePoolCreated registers the handler for ResourcePoolCreatedEvent events.
+func (r *Router) OnResourcePoolCreated(fn func(context.Context, *types.ResourcePoolCreatedEvent) error) {
+	r.On("ResourcePoolCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourcePoolCreatedEvent))
+	})
+}
+
+// OnResourcePoolDestroyed registers the handler for ResourcePoolDestroyedEvent events.
+func (r *Router) OnResourcePoolDestroyed(fn func(context.Context, *types.ResourcePoolDestroyedEvent) error) {
+	r.On("ResourcePoolDestroyedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourcePoolDestroyedEvent))
+	})
+}
+
+// OnResourcePool registers the handler for ResourcePoolEvent events.
+func (r *Router) OnResourcePool(fn func(context.Context, *types.ResourcePoolEvent) error) {
+	r.On("ResourcePoolEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourcePoolEvent))
+	})
+}
+
+// OnResourcePoolMoved registers the handler for ResourcePoolMovedEvent events.
+func (r *Router) OnResourcePoolMoved(fn func(context.Context, *types.ResourcePoolMovedEvent) error) {
+	r.On("ResourcePoolMovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourcePoolMovedEvent))
+	})
+}
+
+// OnResourcePoolReconfigured registers the handler for ResourcePoolReconfiguredEvent events.
+func (r *Router) OnResourcePoolReconfigured(fn func(context.Context, *types.ResourcePoolReconfiguredEvent) error) {
+	r.On("ResourcePoolReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourcePoolReconfiguredEvent))
+	})
+}
+
+// OnResourceViolated registers the handler for ResourceViolatedEvent events.
+func (r *Router) OnResourceViolated(fn func(context.Context, *types.ResourceViolatedEvent) error) {
+	r.On("ResourceViolatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ResourceViolatedEvent))
+	})
+}
+
+// OnRoleAdded registers the handler for RoleAddedEvent events.
+func (r *Router) OnRoleAdded(fn func(context.Context, *types.RoleAddedEvent) error) {
+	r.On("RoleAddedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RoleAddedEvent))
+	})
+}
+
+// OnRole registers the handler for RoleEvent events.
+func (r *Router) OnRole(fn func(context.Context, *types.RoleEvent) error) {
+	r.On("RoleEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RoleEvent))
+	})
+}
+
+// OnRoleRemoved registers the handler for RoleRemovedEvent events.
+func (r *Router) OnRoleRemoved(fn func(context.Context, *types.RoleRemovedEvent) error) {
+	r.On("RoleRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RoleRemovedEvent))
+	})
+}
+
+// OnRoleUpdated registers the handler for RoleUpdatedEvent events.
+func (r *Router) OnRoleUpdated(fn func(context.Context, *types.RoleUpdatedEvent) error) {
+	r.On("RoleUpdatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RoleUpdatedEvent))
+	})
+}
+
+// OnRollback registers the handler for RollbackEvent events.
+func (r *Router) OnRollback(fn func(context.Context, *types.RollbackEvent) error) {
+	r.On("RollbackEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.RollbackEvent))
+	})
+}
+
+// OnScheduledTaskCompleted registers the handler for ScheduledTaskCompletedEvent events.
+func (r *Router) OnScheduledTaskCompleted(fn func(context.Context, *types.ScheduledTaskCompletedEvent) error) {
+	r.On("ScheduledTaskCompletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskCompletedEvent))
+	})
+}
+
+// OnScheduledTaskCreated registers the handler for ScheduledTaskCreatedEvent events.
+func (r *Router) OnScheduledTaskCreated(fn func(context.Context, *types.ScheduledTaskCreatedEvent) error) {
+	r.On("ScheduledTaskCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskCreatedEvent))
+	})
+}
+
+// OnScheduledTaskEmailCompleted registers the handler for ScheduledTaskEmailCompletedEvent events.
+func (r *Router) OnScheduledTaskEmailCompleted(fn func(context.Context, *types.ScheduledTaskEmailCompletedEvent) error) {
+	r.On("ScheduledTaskEmailCompletedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskEmailCompletedEvent))
+	})
+}
+
+// OnScheduledTaskEmailFailed registers the handler for ScheduledTaskEmailFailedEvent events.
+func (r *Router) OnScheduledTaskEmailFailed(fn func(context.Context, *types.ScheduledTaskEmailFailedEvent) error) {
+	r.On("ScheduledTaskEmailFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskEmailFailedEvent))
+	})
+}
+
+// OnScheduledTask registers the handler for ScheduledTaskEvent events.
+func (r *Router) OnScheduledTask(fn func(context.Context, *types.ScheduledTaskEvent) error) {
+	r.On("ScheduledTaskEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskEvent))
+	})
+}
+
+// OnScheduledTaskFailed registers the handler for ScheduledTaskFailedEvent events.
+func (r *Router) OnScheduledTaskFailed(fn func(context.Context, *types.ScheduledTaskFailedEvent) error) {
+	r.On("ScheduledTaskFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskFailedEvent))
+	})
+}
+
+// OnScheduledTaskReconfigured registers the handler for ScheduledTaskReconfiguredEvent events.
+func (r *Router) OnScheduledTaskReconfigured(fn func(context.Context, *types.ScheduledTaskReconfiguredEvent) error) {
+	r.On("ScheduledTaskReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskReconfiguredEvent))
+	})
+}
+
+// OnScheduledTaskRemoved registers the handler for ScheduledTaskRemovedEvent events.
+func (r *Router) OnScheduledTaskRemoved(fn func(context.Context, *types.ScheduledTaskRemovedEvent) error) {
+	r.On("ScheduledTaskRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskRemovedEvent))
+	})
+}
+
+// OnScheduledTaskStarted registers the handler for ScheduledTaskStartedEvent events.
+func (r *Router) OnScheduledTaskStarted(fn func(context.Context, *types.ScheduledTaskStartedEvent) error) {
+	r.On("ScheduledTaskStartedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ScheduledTaskStartedEvent))
+	})
+}
+
+// OnServerLicenseExpired registers the handler for ServerLicenseExpiredEvent events.
+func (r *Router) OnServerLicenseExpired(fn func(context.Context, *types.ServerLicenseExpiredEvent) error) {
+	r.On("ServerLicenseExpiredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ServerLicenseExpiredEvent))
+	})
+}
+
+// OnServerStartedSession registers the handler for ServerStartedSessionEvent events.
+func (r *Router) OnServerStartedSession(fn func(context.Context, *types.ServerStartedSessionEvent) error) {
+	r.On("ServerStartedSessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.ServerStartedSessionEvent))
+	})
+}
+
+// OnSession registers the handler for SessionEvent events.
+func (r *Router) OnSession(fn func(context.Context, *types.SessionEvent) error) {
+	r.On("SessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.SessionEvent))
+	})
+}
+
+// OnSessionTerminated registers the handler for SessionTerminatedEvent events.
+func (r *Router) OnSessionTerminated(fn func(context.Context, *types.SessionTerminatedEvent) error) {
+	r.On("SessionTerminatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.SessionTerminatedEvent))
+	})
+}
+
+// OnTask registers the handler for TaskEvent events.
+func (r *Router) OnTask(fn func(context.Context, *types.TaskEvent) error) {
+	r.On("TaskEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TaskEvent))
+	})
+}
+
+// OnTaskTimeout registers the handler for TaskTimeoutEvent events.
+func (r *Router) OnTaskTimeout(fn func(context.Context, *types.TaskTimeoutEvent) error) {
+	r.On("TaskTimeoutEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TaskTimeoutEvent))
+	})
+}
+
+// OnTeamingMatch registers the handler for TeamingMatchEvent events.
+func (r *Router) OnTeamingMatch(fn func(context.Context, *types.TeamingMatchEvent) error) {
+	r.On("TeamingMatchEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TeamingMatchEvent))
+	})
+}
+
+// OnTeamingMisMatch registers the handler for TeamingMisMatchEvent events.
+func (r *Router) OnTeamingMisMatch(fn func(context.Context, *types.TeamingMisMatchEvent) error) {
+	r.On("TeamingMisMatchEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TeamingMisMatchEvent))
+	})
+}
+
+// OnTemplateBeingUpgraded registers the handler for TemplateBeingUpgradedEvent events.
+func (r *Router) OnTemplateBeingUpgraded(fn func(context.Context, *types.TemplateBeingUpgradedEvent) error) {
+	r.On("TemplateBeingUpgradedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TemplateBeingUpgradedEvent))
+	})
+}
+
+// OnTemplateUpgrade registers the handler for TemplateUpgradeEvent events.
+func (r *Router) OnTemplateUpgrade(fn func(context.Context, *types.TemplateUpgradeEvent) error) {
+	r.On("TemplateUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TemplateUpgradeEvent))
+	})
+}
+
+// OnTemplateUpgradeFailed registers the handler for TemplateUpgradeFailedEvent events.
+func (r *Router) OnTemplateUpgradeFailed(fn func(context.Context, *types.TemplateUpgradeFailedEvent) error) {
+	r.On("TemplateUpgradeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TemplateUpgradeFailedEvent))
+	})
+}
+
+// OnTemplateUpgraded registers the handler for TemplateUpgradedEvent events.
+func (r *Router) OnTemplateUpgraded(fn func(context.Context, *types.TemplateUpgradedEvent) error) {
+	r.On("TemplateUpgradedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TemplateUpgradedEvent))
+	})
+}
+
+// OnTimedOutHostOperation registers the handler for TimedOutHostOperationEvent events.
+func (r *Router) OnTimedOutHostOperation(fn func(context.Context, *types.TimedOutHostOperationEvent) error) {
+	r.On("TimedOutHostOperationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.TimedOutHostOperationEvent))
+	})
+}
+
+// OnUnlicensedVirtualMachines registers the handler for UnlicensedVirtualMachinesEvent events.
+func (r *Router) OnUnlicensedVirtualMachines(fn func(context.Context, *types.UnlicensedVirtualMachinesEvent) error) {
+	r.On("UnlicensedVirtualMachinesEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UnlicensedVirtualMachinesEvent))
+	})
+}
+
+// OnUnlicensedVirtualMachinesFound registers the handler for UnlicensedVirtualMachinesFoundEvent events.
+func (r *Router) OnUnlicensedVirtualMachinesFound(fn func(context.Context, *types.UnlicensedVirtualMachinesFoundEvent) error) {
+	r.On("UnlicensedVirtualMachinesFoundEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UnlicensedVirtualMachinesFoundEvent))
+	})
+}
+
+// OnUpdatedAgentBeingRestarted registers the handler for UpdatedAgentBeingRestartedEvent events.
+func (r *Router) OnUpdatedAgentBeingRestarted(fn func(context.Context, *types.UpdatedAgentBeingRestartedEvent) error) {
+	r.On("UpdatedAgentBeingRestartedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UpdatedAgentBeingRestartedEvent))
+	})
+}
+
+// OnUpgrade registers the handler for UpgradeEvent events.
+func (r *Router) OnUpgrade(fn func(context.Context, *types.UpgradeEvent) error) {
+	r.On("UpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UpgradeEvent))
+	})
+}
+
+// OnUplinkPortMtuNotSupport registers the handler for UplinkPortMtuNotSupportEvent events.
+func (r *Router) OnUplinkPortMtuNotSupport(fn func(context.Context, *types.UplinkPortMtuNotSupportEvent) error) {
+	r.On("UplinkPortMtuNotSupportEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UplinkPortMtuNotSupportEvent))
+	})
+}
+
+// OnUplinkPortMtuSupport registers the handler for UplinkPortMtuSupportEvent events.
+func (r *Router) OnUplinkPortMtuSupport(fn func(context.Context, *types.UplinkPortMtuSupportEvent) error) {
+	r.On("UplinkPortMtuSupportEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UplinkPortMtuSupportEvent))
+	})
+}
+
+// OnUplinkPortVlanTrunked registers the handler for UplinkPortVlanTrunkedEvent events.
+func (r *Router) OnUplinkPortVlanTrunked(fn func(context.Context, *types.UplinkPortVlanTrunkedEvent) error) {
+	r.On("UplinkPortVlanTrunkedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UplinkPortVlanTrunkedEvent))
+	})
+}
+
+// OnUplinkPortVlanUntrunked registers the handler for UplinkPortVlanUntrunkedEvent events.
+func (r *Router) OnUplinkPortVlanUntrunked(fn func(context.Context, *types.UplinkPortVlanUntrunkedEvent) error) {
+	r.On("UplinkPortVlanUntrunkedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UplinkPortVlanUntrunkedEvent))
+	})
+}
+
+// OnUserAssignedToGroup registers the handler for UserAssignedToGroup events.
+func (r *Router) OnUserAssignedToGroup(fn func(context.Context, *types.UserAssignedToGroup) error) {
+	r.On("UserAssignedToGroup", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserAssignedToGroup))
+	})
+}
+
+// OnUserLoginSession registers the handler for UserLoginSessionEvent events.
+func (r *Router) OnUserLoginSession(fn func(context.Context, *types.UserLoginSessionEvent) error) {
+	r.On("UserLoginSessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserLoginSessionEvent))
+	})
+}
+
+// OnUserLogoutSession registers the handler for UserLogoutSessionEvent events.
+func (r *Router) OnUserLogoutSession(fn func(context.Context, *types.UserLogoutSessionEvent) error) {
+	r.On("UserLogoutSessionEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserLogoutSessionEvent))
+	})
+}
+
+// OnUserPasswordChanged registers the handler for UserPasswordChanged events.
+func (r *Router) OnUserPasswordChanged(fn func(context.Context, *types.UserPasswordChanged) error) {
+	r.On("UserPasswordChanged", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserPasswordChanged))
+	})
+}
+
+// OnUserUnassignedFromGroup registers the handler for UserUnassignedFromGroup events.
+func (r *Router) OnUserUnassignedFromGroup(fn func(context.Context, *types.UserUnassignedFromGroup) error) {
+	r.On("UserUnassignedFromGroup", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserUnassignedFromGroup))
+	})
+}
+
+// OnUserUpgrade registers the handler for UserUpgradeEvent events.
+func (r *Router) OnUserUpgrade(fn func(context.Context, *types.UserUpgradeEvent) error) {
+	r.On("UserUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.UserUpgradeEvent))
+	})
+}
+
+// OnVMFSDatastoreCreated registers the handler for VMFSDatastoreCreatedEvent events.
+func (r *Router) OnVMFSDatastoreCreated(fn func(context.Context, *types.VMFSDatastoreCreatedEvent) error) {
+	r.On("VMFSDatastoreCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VMFSDatastoreCreatedEvent))
+	})
+}
+
+// OnVMFSDatastoreExpanded registers the handler for VMFSDatastoreExpandedEvent events.
+func (r *Router) OnVMFSDatastoreExpanded(fn func(context.Context, *types.VMFSDatastoreExpandedEvent) error) {
+	r.On("VMFSDatastoreExpandedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VMFSDatastoreExpandedEvent))
+	})
+}
+
+// OnVMFSDatastoreExtended registers the handler for VMFSDatastoreExtendedEvent events.
+func (r *Router) OnVMFSDatastoreExtended(fn func(context.Context, *types.VMFSDatastoreExtendedEvent) error) {
+	r.On("VMFSDatastoreExtendedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VMFSDatastoreExtendedEvent))
+	})
+}
+
+// OnVMotionLicenseExpired registers the handler for VMotionLicenseExpiredEvent events.
+func (r *Router) OnVMotionLicenseExpired(fn func(context.Context, *types.VMotionLicenseExpiredEvent) error) {
+	r.On("VMotionLicenseExpiredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VMotionLicenseExpiredEvent))
+	})
+}
+
+// OnVcAgentUninstallFailed registers the handler for VcAgentUninstallFailedEvent events.
+func (r *Router) OnVcAgentUninstallFailed(fn func(context.Context, *types.VcAgentUninstallFailedEvent) error) {
+	r.On("VcAgentUninstallFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VcAgentUninstallFailedEvent))
+	})
+}
+
+// OnVcAgentUninstalled registers the handler for VcAgentUninstalledEvent events.
+func (r *Router) OnVcAgentUninstalled(fn func(context.Context, *types.VcAgentUninstalledEvent) error) {
+	r.On("VcAgentUninstalledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VcAgentUninstalledEvent))
+	})
+}
+
+// OnVcAgentUpgradeFailed registers the handler for VcAgentUpgradeFailedEvent events.
+func (r *Router) OnVcAgentUpgradeFailed(fn func(context.Context, *types.VcAgentUpgradeFailedEvent) error) {
+	r.On("VcAgentUpgradeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VcAgentUpgradeFailedEvent))
+	})
+}
+
+// OnVcAgentUpgraded registers the handler for VcAgentUpgradedEvent events.
+func (r *Router) OnVcAgentUpgraded(fn func(context.Context, *types.VcAgentUpgradedEvent) error) {
+	r.On("VcAgentUpgradedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VcAgentUpgradedEvent))
+	})
+}
+
+// OnVimAccountPasswordChanged registers the handler for VimAccountPasswordChangedEvent events.
+func (r *Router) OnVimAccountPasswordChanged(fn func(context.Context, *types.VimAccountPasswordChangedEvent) error) {
+	r.On("VimAccountPasswordChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VimAccountPasswordChangedEvent))
+	})
+}
+
+// OnVmAcquiredMksTicket registers the handler for VmAcquiredMksTicketEvent events.
+func (r *Router) OnVmAcquiredMksTicket(fn func(context.Context, *types.VmAcquiredMksTicketEvent) error) {
+	r.On("VmAcquiredMksTicketEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmAcquiredMksTicketEvent))
+	})
+}
+
+// OnVmAcquiredTicket registers the handler for VmAcquiredTicketEvent events.
+func (r *Router) OnVmAcquiredTicket(fn func(context.Context, *types.VmAcquiredTicketEvent) error) {
+	r.On("VmAcquiredTicketEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmAcquiredTicketEvent))
+	})
+}
+
+// OnVmAutoRename registers the handler for VmAutoRenameEvent events.
+func (r *Router) OnVmAutoRename(fn func(context.Context, *types.VmAutoRenameEvent) error) {
+	r.On("VmAutoRenameEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmAutoRenameEvent))
+	})
+}
+
+// OnVmBeingCloned registers the handler for VmBeingClonedEvent events.
+func (r *Router) OnVmBeingCloned(fn func(context.Context, *types.VmBeingClonedEvent) error) {
+	r.On("VmBeingClonedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingClonedEvent))
+	})
+}
+
+// OnVmBeingClonedNoFolder registers the handler for VmBeingClonedNoFolderEvent events.
+func (r *Router) OnVmBeingClonedNoFolder(fn func(context.Context, *types.VmBeingClonedNoFolderEvent) error) {
+	r.On("VmBeingClonedNoFolderEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingClonedNoFolderEvent))
+	})
+}
+
+// OnVmBeingCreated registers the handler for VmBeingCreatedEvent events.
+func (r *Router) OnVmBeingCreated(fn func(context.Context, *types.VmBeingCreatedEvent) error) {
+	r.On("VmBeingCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingCreatedEvent))
+	})
+}
+
+// OnVmBeingDeployed registers the handler for VmBeingDeployedEvent events.
+func (r *Router) OnVmBeingDeployed(fn func(context.Context, *types.VmBeingDeployedEvent) error) {
+	r.On("VmBeingDeployedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingDeployedEvent))
+	})
+}
+
+// OnVmBeingHotMigrated registers the handler for VmBeingHotMigratedEvent events.
+func (r *Router) OnVmBeingHotMigrated(fn func(context.Context, *types.VmBeingHotMigratedEvent) error) {
+	r.On("VmBeingHotMigratedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingHotMigratedEvent))
+	})
+}
+
+// OnVmBeingMigrated registers the handler for VmBeingMigratedEvent events.
+func (r *Router) OnVmBeingMigrated(fn func(context.Context, *types.VmBeingMigratedEvent) error) {
+	r.On("VmBeingMigratedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingMigratedEvent))
+	})
+}
+
+// OnVmBeingRelocated registers the handler for VmBeingRelocatedEvent events.
+func (r *Router) OnVmBeingRelocated(fn func(context.Context, *types.VmBeingRelocatedEvent) error) {
+	r.On("VmBeingRelocatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmBeingRelocatedEvent))
+	})
+}
+
+// OnVmClone registers the handler for VmCloneEvent events.
+func (r *Router) OnVmClone(fn func(context.Context, *types.VmCloneEvent) error) {
+	r.On("VmCloneEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmCloneEvent))
+	})
+}
+
+// OnVmCloneFailed registers the handler for VmCloneFailedEvent events.
+func (r *Router) OnVmCloneFailed(fn func(context.Context, *types.VmCloneFailedEvent) error) {
+	r.On("VmCloneFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmCloneFailedEvent))
+	})
+}
+
+// OnVmCloned registers the handler for VmClonedEvent events.
+func (r *Router) OnVmCloned(fn func(context.Context, *types.VmClonedEvent) error) {
+	r.On("VmClonedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmClonedEvent))
+	})
+}
+
+// OnVmConfigMissing registers the handler for VmConfigMissingEvent events.
+func (r *Router) OnVmConfigMissing(fn func(context.Context, *types.VmConfigMissingEvent) error) {
+	r.On("VmConfigMissingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmConfigMissingEvent))
+	})
+}
+
+// OnVmConnected registers the handler for VmConnectedEvent events.
+func (r *Router) OnVmConnected(fn func(context.Context, *types.VmConnectedEvent) error) {
+	r.On("VmConnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmConnectedEvent))
+	})
+}
+
+// OnVmCreated registers the handler for VmCreatedEvent events.
+func (r *Router) OnVmCreated(fn func(context.Context, *types.VmCreatedEvent) error) {
+	r.On("VmCreatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmCreatedEvent))
+	})
+}
+
+// OnVmDasBeingReset registers the handler for VmDasBeingResetEvent events.
+func (r *Router) OnVmDasBeingReset(fn func(context.Context, *types.VmDasBeingResetEvent) error) {
+	r.On("VmDasBeingResetEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDasBeingResetEvent))
+	})
+}
+
+// OnVmDasBeingResetWithScreenshot registers the handler for VmDasBeingResetWithScreenshotEvent events.
+func (r *Router) OnVmDasBeingResetWithScreenshot(fn func(context.Context, *types.VmDasBeingResetWithScreenshotEvent) error) {
+	r.On("VmDasBeingResetWithScreenshotEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDasBeingResetWithScreenshotEvent))
+	})
+}
+
+// OnVmDasResetFailed registers the handler for VmDasResetFailedEvent events.
+func (r *Router) OnVmDasResetFailed(fn func(context.Context, *types.VmDasResetFailedEvent) error) {
+	r.On("VmDasResetFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDasResetFailedEvent))
+	})
+}
+
+// OnVmDasUpdateError registers the handler for VmDasUpdateErrorEvent events.
+func (r *Router) OnVmDasUpdateError(fn func(context.Context, *types.VmDasUpdateErrorEvent) error) {
+	r.On("VmDasUpdateErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDasUpdateErrorEvent))
+	})
+}
+
+// OnVmDasUpdateOk registers the handler for VmDasUpdateOkEvent events.
+func (r *Router) OnVmDasUpdateOk(fn func(context.Context, *types.VmDasUpdateOkEvent) error) {
+	r.On("VmDasUpdateOkEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDasUpdateOkEvent))
+	})
+}
+
+// OnVmDateRolledBack registers the handler for VmDateRolledBackEvent events.
+func (r *Router) OnVmDateRolledBack(fn func(context.Context, *types.VmDateRolledBackEvent) error) {
+	r.On("VmDateRolledBackEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDateRolledBackEvent))
+	})
+}
+
+// OnVmDeployFailed registers the handler for VmDeployFailedEvent events.
+func (r *Router) OnVmDeployFailed(fn func(context.Context, *types.VmDeployFailedEvent) error) {
+	r.On("VmDeployFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDeployFailedEvent))
+	})
+}
+
+// OnVmDeployed registers the handler for VmDeployedEvent events.
+func (r *Router) OnVmDeployed(fn func(context.Context, *types.VmDeployedEvent) error) {
+	r.On("VmDeployedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDeployedEvent))
+	})
+}
+
+// OnVmDisconnected registers the handler for VmDisconnectedEvent events.
+func (r *Router) OnVmDisconnected(fn func(context.Context, *types.VmDisconnectedEvent) error) {
+	r.On("VmDisconnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDisconnectedEvent))
+	})
+}
+
+// OnVmDiscovered registers the handler for VmDiscoveredEvent events.
+func (r *Router) OnVmDiscovered(fn func(context.Context, *types.VmDiscoveredEvent) error) {
+	r.On("VmDiscoveredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDiscoveredEvent))
+	})
+}
+
+// OnVmDiskFailed registers the handler for VmDiskFailedEvent events.
+func (r *Router) OnVmDiskFailed(fn func(context.Context, *types.VmDiskFailedEvent) error) {
+	r.On("VmDiskFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmDiskFailedEvent))
+	})
+}
+
+// OnVmEmigrating registers the handler for VmEmigratingEvent events.
+func (r *Router) OnVmEmigrating(fn func(context.Context, *types.VmEmigratingEvent) error) {
+	r.On("VmEmigratingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmEmigratingEvent))
+	})
+}
+
+// OnVmEndRecording registers the handler for VmEndRecordingEvent events.
+func (r *Router) OnVmEndRecording(fn func(context.Context, *types.VmEndRecordingEvent) error) {
+	r.On("VmEndRecordingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmEndRecordingEvent))
+	})
+}
+
+// OnVmEndReplaying registers the handler for VmEndReplayingEvent events.
+func (r *Router) OnVmEndReplaying(fn func(context.Context, *types.VmEndReplayingEvent) error) {
+	r.On("VmEndReplayingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmEndReplayingEvent))
+	})
+}
+
+// OnVm registers the handler for VmEvent events.
+func (r *Router) OnVm(fn func(context.Context, *types.VmEvent) error) {
+	r.On("VmEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmEvent))
+	})
+}
+
+// OnVmFailedMigrate registers the handler for VmFailedMigrateEvent events.
+func (r *Router) OnVmFailedMigrate(fn func(context.Context, *types.VmFailedMigrateEvent) error) {
+	r.On("VmFailedMigrateEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedMigrateEvent))
+	})
+}
+
+// OnVmFailedRelayout registers the handler for VmFailedRelayoutEvent events.
+func (r *Router) OnVmFailedRelayout(fn func(context.Context, *types.VmFailedRelayoutEvent) error) {
+	r.On("VmFailedRelayoutEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedRelayoutEvent))
+	})
+}
+
+// OnVmFailedRelayoutOnVmfs2Datastore registers the handler for VmFailedRelayoutOnVmfs2DatastoreEvent events.
+func (r *Router) OnVmFailedRelayoutOnVmfs2Datastore(fn func(context.Context, *types.VmFailedRelayoutOnVmfs2DatastoreEvent) error) {
+	r.On("VmFailedRelayoutOnVmfs2DatastoreEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedRelayoutOnVmfs2DatastoreEvent))
+	})
+}
+
+// OnVmFailedStartingSecondary registers the handler for VmFailedStartingSecondaryEvent events.
+func (r *Router) OnVmFailedStartingSecondary(fn func(context.Context, *types.VmFailedStartingSecondaryEvent) error) {
+	r.On("VmFailedStartingSecondaryEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedStartingSecondaryEvent))
+	})
+}
+
+// OnVmFailedToPowerOff registers the handler for VmFailedToPowerOffEvent events.
+func (r *Router) OnVmFailedToPowerOff(fn func(context.Context, *types.VmFailedToPowerOffEvent) error) {
+	r.On("VmFailedToPowerOffEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToPowerOffEvent))
+	})
+}
+
+// OnVmFailedToPowerOn registers the handler for VmFailedToPowerOnEvent events.
+func (r *Router) OnVmFailedToPowerOn(fn func(context.Context, *types.VmFailedToPowerOnEvent) error) {
+	r.On("VmFailedToPowerOnEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToPowerOnEvent))
+	})
+}
+
+// OnVmFailedToRebootGuest registers the handler for VmFailedToRebootGuestEvent events.
+func (r *Router) OnVmFailedToRebootGuest(fn func(context.Context, *types.VmFailedToRebootGuestEvent) error) {
+	r.On("VmFailedToRebootGuestEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToRebootGuestEvent))
+	})
+}
+
+// OnVmFailedToReset registers the handler for VmFailedToResetEvent events.
+func (r *Router) OnVmFailedToReset(fn func(context.Context, *types.VmFailedToResetEvent) error) {
+	r.On("VmFailedToResetEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToResetEvent))
+	})
+}
+
+// OnVmFailedToShutdownGuest registers the handler for VmFailedToShutdownGuestEvent events.
+func (r *Router) OnVmFailedToShutdownGuest(fn func(context.Context, *types.VmFailedToShutdownGuestEvent) error) {
+	r.On("VmFailedToShutdownGuestEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToShutdownGuestEvent))
+	})
+}
+
+// OnVmFailedToStandbyGuest registers the handler for VmFailedToStandbyGuestEvent events.
+func (r *Router) OnVmFailedToStandbyGuest(fn func(context.Context, *types.VmFailedToStandbyGuestEvent) error) {
+	r.On("VmFailedToStandbyGuestEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToStandbyGuestEvent))
+	})
+}
+
+// OnVmFailedToSuspend registers the handler for VmFailedToSuspendEvent events.
+func (r *Router) OnVmFailedToSuspend(fn func(context.Context, *types.VmFailedToSuspendEvent) error) {
+	r.On("VmFailedToSuspendEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedToSuspendEvent))
+	})
+}
+
+// OnVmFailedUpdatingSecondaryConfig registers the handler for VmFailedUpdatingSecondaryConfig events.
+func (r *Router) OnVmFailedUpdatingSecondaryConfig(fn func(context.Context, *types.VmFailedUpdatingSecondaryConfig) error) {
+	r.On("VmFailedUpdatingSecondaryConfig", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailedUpdatingSecondaryConfig))
+	})
+}
+
+// OnVmFailoverFailed registers the handler for VmFailoverFailed events.
+func (r *Router) OnVmFailoverFailed(fn func(context.Context, *types.VmFailoverFailed) error) {
+	r.On("VmFailoverFailed", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFailoverFailed))
+	})
+}
+
+// OnVmFaultToleranceStateChanged registers the handler for VmFaultToleranceStateChangedEvent events.
+func (r *Router) OnVmFaultToleranceStateChanged(fn func(context.Context, *types.VmFaultToleranceStateChangedEvent) error) {
+	r.On("VmFaultToleranceStateChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFaultToleranceStateChangedEvent))
+	})
+}
+
+// OnVmFaultToleranceTurnedOff registers the handler for VmFaultToleranceTurnedOffEvent events.
+func (r *Router) OnVmFaultToleranceTurnedOff(fn func(context.Context, *types.VmFaultToleranceTurnedOffEvent) error) {
+	r.On("VmFaultToleranceTurnedOffEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFaultToleranceTurnedOffEvent))
+	})
+}
+
+// OnVmFaultToleranceVmTerminated registers the handler for VmFaultToleranceVmTerminatedEvent events.
+func (r *Router) OnVmFaultToleranceVmTerminated(fn func(context.Context, *types.VmFaultToleranceVmTerminatedEvent) error) {
+	r.On("VmFaultToleranceVmTerminatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmFaultToleranceVmTerminatedEvent))
+	})
+}
+
+// OnVmGuestOSCrashed registers the handler for VmGuestOSCrashedEvent events.
+func (r *Router) OnVmGuestOSCrashed(fn func(context.Context, *types.VmGuestOSCrashedEvent) error) {
+	r.On("VmGuestOSCrashedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmGuestOSCrashedEvent))
+	})
+}
+
+// OnVmGuestReboot registers the handler for VmGuestRebootEvent events.
+func (r *Router) OnVmGuestReboot(fn func(context.Context, *types.VmGuestRebootEvent) error) {
+	r.On("VmGuestRebootEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmGuestRebootEvent))
+	})
+}
+
+// OnVmGuestShutdown registers the handler for VmGuestShutdownEvent events.
+func (r *Router) OnVmGuestShutdown(fn func(context.Context, *types.VmGuestShutdownEvent) error) {
+	r.On("VmGuestShutdownEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmGuestShutdownEvent))
+	})
+}
+
+// OnVmGuestStandby registers the handler for VmGuestStandbyEvent events.
+func (r *Router) OnVmGuestStandby(fn func(context.Context, *types.VmGuestStandbyEvent) error) {
+	r.On("VmGuestStandbyEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmGuestStandbyEvent))
+	})
+}
+
+// OnVmHealthMonitoringStateChanged registers the handler for VmHealthMonitoringStateChangedEvent events.
+func (r *Router) OnVmHealthMonitoringStateChanged(fn func(context.Context, *types.VmHealthMonitoringStateChangedEvent) error) {
+	r.On("VmHealthMonitoringStateChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmHealthMonitoringStateChangedEvent))
+	})
+}
+
+// OnVmInstanceUuidAssigned registers the handler for VmInstanceUuidAssignedEvent events.
+func (r *Router) OnVmInstanceUuidAssigned(fn func(context.Context, *types.VmInstanceUuidAssignedEvent) error) {
+	r.On("VmInstanceUuidAssignedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmInstanceUuidAssignedEvent))
+	})
+}
+
+// OnVmInstanceUuidChanged registers the handler for VmInstanceUuidChangedEvent events.
+func (r *Router) OnVmInstanceUuidChanged(fn func(context.Context, *types.VmInstanceUuidChangedEvent) error) {
+	r.On("VmInstanceUuidChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmInstanceUuidChangedEvent))
+	})
+}
+
+// OnVmInstanceUuidConflict registers the handler for VmInstanceUuidConflictEvent events.
+func (r *Router) OnVmInstanceUuidConflict(fn func(context.Context, *types.VmInstanceUuidConflictEvent) error) {
+	r.On("VmInstanceUuidConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmInstanceUuidConflictEvent))
+	})
+}
+
+// OnVmMacAssigned registers the handler for VmMacAssignedEvent events.
+func (r *Router) OnVmMacAssigned(fn func(context.Context, *types.VmMacAssignedEvent) error) {
+	r.On("VmMacAssignedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMacAssignedEvent))
+	})
+}
+
+// OnVmMacChanged registers the handler for VmMacChangedEvent events.
+func (r *Router) OnVmMacChanged(fn func(context.Context, *types.VmMacChangedEvent) error) {
+	r.On("VmMacChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMacChangedEvent))
+	})
+}
+
+// OnVmMacConflict registers the handler for VmMacConflictEvent events.
+func (r *Router) OnVmMacConflict(fn func(context.Context, *types.VmMacConflictEvent) error) {
+	r.On("VmMacConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMacConflictEvent))
+	})
+}
+
+// OnVmMaxFTRestartCountReached registers the handler for VmMaxFTRestartCountReached events.
+func (r *Router) OnVmMaxFTRestartCountReached(fn func(context.Context, *types.VmMaxFTRestartCountReached) error) {
+	r.On("VmMaxFTRestartCountReached", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMaxFTRestartCountReached))
+	})
+}
+
+// OnVmMaxRestartCountReached registers the handler for VmMaxRestartCountReached events.
+func (r *Router) OnVmMaxRestartCountReached(fn func(context.Context, *types.VmMaxRestartCountReached) error) {
+	r.On("VmMaxRestartCountReached", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMaxRestartCountReached))
+	})
+}
+
+// OnVmMessageError registers the handler for VmMessageErrorEvent events.
+func (r *Router) OnVmMessageError(fn func(context.Context, *types.VmMessageErrorEvent) error) {
+	r.On("VmMessageErrorEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMessageErrorEvent))
+	})
+}
+
+// OnVmMessage registers the handler for VmMessageEvent events.
+func (r *Router) OnVmMessage(fn func(context.Context, *types.VmMessageEvent) error) {
+	r.On("VmMessageEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMessageEvent))
+	})
+}
+
+// OnVmMessageWarning registers the handler for VmMessageWarningEvent events.
+func (r *Router) OnVmMessageWarning(fn func(context.Context, *types.VmMessageWarningEvent) error) {
+	r.On("VmMessageWarningEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMessageWarningEvent))
+	})
+}
+
+// OnVmMigrated registers the handler for VmMigratedEvent events.
+func (r *Router) OnVmMigrated(fn func(context.Context, *types.VmMigratedEvent) error) {
+	r.On("VmMigratedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmMigratedEvent))
+	})
+}
+
+// OnVmNoCompatibleHostForSecondary registers the handler for VmNoCompatibleHostForSecondaryEvent events.
+func (r *Router) OnVmNoCompatibleHostForSecondary(fn func(context.Context, *types.VmNoCompatibleHostForSecondaryEvent) error) {
+	r.On("VmNoCompatibleHostForSecondaryEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmNoCompatibleHostForSecondaryEvent))
+	})
+}
+
+// OnVmNoNetworkAccess registers the handler for VmNoNetworkAccessEvent events.
+func (r *Router) OnVmNoNetworkAccess(fn func(context.Context, *types.VmNoNetworkAccessEvent) error) {
+	r.On("VmNoNetworkAccessEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmNoNetworkAccessEvent))
+	})
+}
+
+// OnVmOrphaned registers the handler for VmOrphanedEvent events.
+func (r *Router) OnVmOrphaned(fn func(context.Context, *types.VmOrphanedEvent) error) {
+	r.On("VmOrphanedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmOrphanedEvent))
+	})
+}
+
+// OnVmPowerOffOnIsolation registers the handler for VmPowerOffOnIsolationEvent events.
+func (r *Router) OnVmPowerOffOnIsolation(fn func(context.Context, *types.VmPowerOffOnIsolationEvent) error) {
+	r.On("VmPowerOffOnIsolationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmPowerOffOnIsolationEvent))
+	})
+}
+
+// OnVmPoweredOff registers the handler for VmPoweredOffEvent events.
+func (r *Router) OnVmPoweredOff(fn func(context.Context, *types.VmPoweredOffEvent) error) {
+	r.On("VmPoweredOffEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmPoweredOffEvent))
+	})
+}
+
+// OnVmPoweredOn registers the handler for VmPoweredOnEvent events.
+func (r *Router) OnVmPoweredOn(fn func(context.Context, *types.VmPoweredOnEvent) error) {
+	r.On("VmPoweredOnEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmPoweredOnEvent))
+	})
+}
+
+// OnVmPoweringOnWithCustomizedDVPort registers the handler for VmPoweringOnWithCustomizedDVPortEvent events.
+func (r *Router) OnVmPoweringOnWithCustomizedDVPort(fn func(context.Context, *types.VmPoweringOnWithCustomizedDVPortEvent) error) {
+	r.On("VmPoweringOnWithCustomizedDVPortEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmPoweringOnWithCustomizedDVPortEvent))
+	})
+}
+
+// OnVmPrimaryFailover registers the handler for VmPrimaryFailoverEvent events.
+func (r *Router) OnVmPrimaryFailover(fn func(context.Context, *types.VmPrimaryFailoverEvent) error) {
+	r.On("VmPrimaryFailoverEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmPrimaryFailoverEvent))
+	})
+}
+
+// OnVmReconfigured registers the handler for VmReconfiguredEvent events.
+func (r *Router) OnVmReconfigured(fn func(context.Context, *types.VmReconfiguredEvent) error) {
+	r.On("VmReconfiguredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmReconfiguredEvent))
+	})
+}
+
+// OnVmRegistered registers the handler for VmRegisteredEvent events.
+func (r *Router) OnVmRegistered(fn func(context.Context, *types.VmRegisteredEvent) error) {
+	r.On("VmRegisteredEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRegisteredEvent))
+	})
+}
+
+// OnVmRelayoutSuccessful registers the handler for VmRelayoutSuccessfulEvent events.
+func (r *Router) OnVmRelayoutSuccessful(fn func(context.Context, *types.VmRelayoutSuccessfulEvent) error) {
+	r.On("VmRelayoutSuccessfulEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRelayoutSuccessfulEvent))
+	})
+}
+
+// OnVmRelayoutUpToDate registers the handler for VmRelayoutUpToDateEvent events.
+func (r *Router) OnVmRelayoutUpToDate(fn func(context.Context, *types.VmRelayoutUpToDateEvent) error) {
+	r.On("VmRelayoutUpToDateEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRelayoutUpToDateEvent))
+	})
+}
+
+// OnVmReloadFromPath registers the handler for VmReloadFromPathEvent events.
+func (r *Router) OnVmReloadFromPath(fn func(context.Context, *types.VmReloadFromPathEvent) error) {
+	r.On("VmReloadFromPathEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmReloadFromPathEvent))
+	})
+}
+
+// OnVmReloadFromPathFailed registers the handler for VmReloadFromPathFailedEvent events.
+func (r *Router) OnVmReloadFromPathFailed(fn func(context.Context, *types.VmReloadFromPathFailedEvent) error) {
+	r.On("VmReloadFromPathFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmReloadFromPathFailedEvent))
+	})
+}
+
+// OnVmRelocateFailed registers the handler for VmRelocateFailedEvent events.
+func (r *Router) OnVmRelocateFailed(fn func(context.Context, *types.VmRelocateFailedEvent) error) {
+	r.On("VmRelocateFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRelocateFailedEvent))
+	})
+}
+
+// OnVmRelocateSpec registers the handler for VmRelocateSpecEvent events.
+func (r *Router) OnVmRelocateSpec(fn func(context.Context, *types.VmRelocateSpecEvent) error) {
+	r.On("VmRelocateSpecEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRelocateSpecEvent))
+	})
+}
+
+// OnVmRelocated registers the handler for VmRelocatedEvent events.
+func (r *Router) OnVmRelocated(fn func(context.Context, *types.VmRelocatedEvent) error) {
+	r.On("VmRelocatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRelocatedEvent))
+	})
+}
+
+// OnVmRemoteConsoleConnected registers the handler for VmRemoteConsoleConnectedEvent events.
+func (r *Router) OnVmRemoteConsoleConnected(fn func(context.Context, *types.VmRemoteConsoleConnectedEvent) error) {
+	r.On("VmRemoteConsoleConnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRemoteConsoleConnectedEvent))
+	})
+}
+
+// OnVmRemoteConsoleDisconnected registers the handler for VmRemoteConsoleDisconnectedEvent events.
+func (r *Router) OnVmRemoteConsoleDisconnected(fn func(context.Context, *types.VmRemoteConsoleDisconnectedEvent) error) {
+	r.On("VmRemoteConsoleDisconnectedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRemoteConsoleDisconnectedEvent))
+	})
+}
+
+// OnVmRemoved registers the handler for VmRemovedEvent events.
+func (r *Router) OnVmRemoved(fn func(context.Context, *types.VmRemovedEvent) error) {
+	r.On("VmRemovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRemovedEvent))
+	})
+}
+
+// OnVmRenamed registers the handler for VmRenamedEvent events.
+func (r *Router) OnVmRenamed(fn func(context.Context, *types.VmRenamedEvent) error) {
+	r.On("VmRenamedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRenamedEvent))
+	})
+}
+
+// OnVmRequirementsExceedCurrentEVCMode registers the handler for VmRequirementsExceedCurrentEVCModeEvent events.
+func (r *Router) OnVmRequirementsExceedCurrentEVCMode(fn func(context.Context, *types.VmRequirementsExceedCurrentEVCModeEvent) error) {
+	r.On("VmRequirementsExceedCurrentEVCModeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRequirementsExceedCurrentEVCModeEvent))
+	})
+}
+
+// OnVmResetting registers the handler for VmResettingEvent events.
+func (r *Router) OnVmResetting(fn func(context.Context, *types.VmResettingEvent) error) {
+	r.On("VmResettingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmResettingEvent))
+	})
+}
+
+// OnVmResourcePoolMoved registers the handler for VmResourcePoolMovedEvent events.
+func (r *Router) OnVmResourcePoolMoved(fn func(context.Context, *types.VmResourcePoolMovedEvent) error) {
+	r.On("VmResourcePoolMovedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmResourcePoolMovedEvent))
+	})
+}
+
+// OnVmResourceReallocated registers the handler for VmResourceReallocatedEvent events.
+func (r *Router) OnVmResourceReallocated(fn func(context.Context, *types.VmResourceReallocatedEvent) error) {
+	r.On("VmResourceReallocatedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmResourceReallocatedEvent))
+	})
+}
+
+// OnVmRestartedOnAlternateHost registers the handler for VmRestartedOnAlternateHostEvent events.
+func (r *Router) OnVmRestartedOnAlternateHost(fn func(context.Context, *types.VmRestartedOnAlternateHostEvent) error) {
+	r.On("VmRestartedOnAlternateHostEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmRestartedOnAlternateHostEvent))
+	})
+}
+
+// OnVmResuming registers the handler for VmResumingEvent events.
+func (r *Router) OnVmResuming(fn func(context.Context, *types.VmResumingEvent) error) {
+	r.On("VmResumingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmResumingEvent))
+	})
+}
+
+// OnVmSecondaryAdded registers the handler for VmSecondaryAddedEvent events.
+func (r *Router) OnVmSecondaryAdded(fn func(context.Context, *types.VmSecondaryAddedEvent) error) {
+	r.On("VmSecondaryAddedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSecondaryAddedEvent))
+	})
+}
+
+// OnVmSecondaryDisabledBySystem registers the handler for VmSecondaryDisabledBySystemEvent events.
+func (r *Router) OnVmSecondaryDisabledBySystem(fn func(context.Context, *types.VmSecondaryDisabledBySystemEvent) error) {
+	r.On("VmSecondaryDisabledBySystemEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSecondaryDisabledBySystemEvent))
+	})
+}
+
+// OnVmSecondaryDisabled registers the handler for VmSecondaryDisabledEvent events.
+func (r *Router) OnVmSecondaryDisabled(fn func(context.Context, *types.VmSecondaryDisabledEvent) error) {
+	r.On("VmSecondaryDisabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSecondaryDisabledEvent))
+	})
+}
+
+// OnVmSecondaryEnabled registers the handler for VmSecondaryEnabledEvent events.
+func (r *Router) OnVmSecondaryEnabled(fn func(context.Context, *types.VmSecondaryEnabledEvent) error) {
+	r.On("VmSecondaryEnabledEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSecondaryEnabledEvent))
+	})
+}
+
+// OnVmSecondaryStarted registers the handler for VmSecondaryStartedEvent events.
+func (r *Router) OnVmSecondaryStarted(fn func(context.Context, *types.VmSecondaryStartedEvent) error) {
+	r.On("VmSecondaryStartedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSecondaryStartedEvent))
+	})
+}
+
+// OnVmShutdownOnIsolation registers the handler for VmShutdownOnIsolationEvent events.
+func (r *Router) OnVmShutdownOnIsolation(fn func(context.Context, *types.VmShutdownOnIsolationEvent) error) {
+	r.On("VmShutdownOnIsolationEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmShutdownOnIsolationEvent))
+	})
+}
+
+// OnVmStartRecording registers the handler for VmStartRecordingEvent events.
+func (r *Router) OnVmStartRecording(fn func(context.Context, *types.VmStartRecordingEvent) error) {
+	r.On("VmStartRecordingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStartRecordingEvent))
+	})
+}
+
+// OnVmStartReplaying registers the handler for VmStartReplayingEvent events.
+func (r *Router) OnVmStartReplaying(fn func(context.Context, *types.VmStartReplayingEvent) error) {
+	r.On("VmStartReplayingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStartReplayingEvent))
+	})
+}
+
+// OnVmStarting registers the handler for VmStartingEvent events.
+func (r *Router) OnVmStarting(fn func(context.Context, *types.VmStartingEvent) error) {
+	r.On("VmStartingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStartingEvent))
+	})
+}
+
+// OnVmStartingSecondary registers the handler for VmStartingSecondaryEvent events.
+func (r *Router) OnVmStartingSecondary(fn func(context.Context, *types.VmStartingSecondaryEvent) error) {
+	r.On("VmStartingSecondaryEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStartingSecondaryEvent))
+	})
+}
+
+// OnVmStaticMacConflict registers the handler for VmStaticMacConflictEvent events.
+func (r *Router) OnVmStaticMacConflict(fn func(context.Context, *types.VmStaticMacConflictEvent) error) {
+	r.On("VmStaticMacConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStaticMacConflictEvent))
+	})
+}
+
+// OnVmStopping registers the handler for VmStoppingEvent events.
+func (r *Router) OnVmStopping(fn func(context.Context, *types.VmStoppingEvent) error) {
+	r.On("VmStoppingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmStoppingEvent))
+	})
+}
+
+// OnVmSuspended registers the handler for VmSuspendedEvent events.
+func (r *Router) OnVmSuspended(fn func(context.Context, *types.VmSuspendedEvent) error) {
+	r.On("VmSuspendedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSuspendedEvent))
+	})
+}
+
+// OnVmSuspending registers the handler for VmSuspendingEvent events.
+func (r *Router) OnVmSuspending(fn func(context.Context, *types.VmSuspendingEvent) error) {
+	r.On("VmSuspendingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmSuspendingEvent))
+	})
+}
+
+// OnVmTimedoutStartingSecondary registers the handler for VmTimedoutStartingSecondaryEvent events.
+func (r *Router) OnVmTimedoutStartingSecondary(fn func(context.Context, *types.VmTimedoutStartingSecondaryEvent) error) {
+	r.On("VmTimedoutStartingSecondaryEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmTimedoutStartingSecondaryEvent))
+	})
+}
+
+// OnVmUnsupportedStarting registers the handler for VmUnsupportedStartingEvent events.
+func (r *Router) OnVmUnsupportedStarting(fn func(context.Context, *types.VmUnsupportedStartingEvent) error) {
+	r.On("VmUnsupportedStartingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUnsupportedStartingEvent))
+	})
+}
+
+// OnVmUpgradeComplete registers the handler for VmUpgradeCompleteEvent events.
+func (r *Router) OnVmUpgradeComplete(fn func(context.Context, *types.VmUpgradeCompleteEvent) error) {
+	r.On("VmUpgradeCompleteEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUpgradeCompleteEvent))
+	})
+}
+
+// OnVmUpgradeFailed registers the handler for VmUpgradeFailedEvent events.
+func (r *Router) OnVmUpgradeFailed(fn func(context.Context, *types.VmUpgradeFailedEvent) error) {
+	r.On("VmUpgradeFailedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUpgradeFailedEvent))
+	})
+}
+
+// OnVmUpgrading registers the handler for VmUpgradingEvent events.
+func (r *Router) OnVmUpgrading(fn func(context.Context, *types.VmUpgradingEvent) error) {
+	r.On("VmUpgradingEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUpgradingEvent))
+	})
+}
+
+// OnVmUuidAssigned registers the handler for VmUuidAssignedEvent events.
+func (r *Router) OnVmUuidAssigned(fn func(context.Context, *types.VmUuidAssignedEvent) error) {
+	r.On("VmUuidAssignedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUuidAssignedEvent))
+	})
+}
+
+// OnVmUuidChanged registers the handler for VmUuidChangedEvent events.
+func (r *Router) OnVmUuidChanged(fn func(context.Context, *types.VmUuidChangedEvent) error) {
+	r.On("VmUuidChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUuidChangedEvent))
+	})
+}
+
+// OnVmUuidConflict registers the handler for VmUuidConflictEvent events.
+func (r *Router) OnVmUuidConflict(fn func(context.Context, *types.VmUuidConflictEvent) error) {
+	r.On("VmUuidConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmUuidConflictEvent))
+	})
+}
+
+// OnVmVnicPoolReservationViolationClear registers the handler for VmVnicPoolReservationViolationClearEvent events.
+func (r *Router) OnVmVnicPoolReservationViolationClear(fn func(context.Context, *types.VmVnicPoolReservationViolationClearEvent) error) {
+	r.On("VmVnicPoolReservationViolationClearEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmVnicPoolReservationViolationClearEvent))
+	})
+}
+
+// OnVmVnicPoolReservationViolationRaise registers the handler for VmVnicPoolReservationViolationRaiseEvent events.
+func (r *Router) OnVmVnicPoolReservationViolationRaise(fn func(context.Context, *types.VmVnicPoolReservationViolationRaiseEvent) error) {
+	r.On("VmVnicPoolReservationViolationRaiseEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmVnicPoolReservationViolationRaiseEvent))
+	})
+}
+
+// OnVmWwnAssigned registers the handler for VmWwnAssignedEvent events.
+func (r *Router) OnVmWwnAssigned(fn func(context.Context, *types.VmWwnAssignedEvent) error) {
+	r.On("VmWwnAssignedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmWwnAssignedEvent))
+	})
+}
+
+// OnVmWwnChanged registers the handler for VmWwnChangedEvent events.
+func (r *Router) OnVmWwnChanged(fn func(context.Context, *types.VmWwnChangedEvent) error) {
+	r.On("VmWwnChangedEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmWwnChangedEvent))
+	})
+}
+
+// OnVmWwnConflict registers the handler for VmWwnConflictEvent events.
+func (r *Router) OnVmWwnConflict(fn func(context.Context, *types.VmWwnConflictEvent) error) {
+	r.On("VmWwnConflictEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.VmWwnConflictEvent))
+	})
+}
+
+// OnWarningUpgrade registers the handler for WarningUpgradeEvent events.
+func (r *Router) OnWarningUpgrade(fn func(context.Context, *types.WarningUpgradeEvent) error) {
+	r.On("WarningUpgradeEvent", func(ctx context.Context, be types.BaseEvent) error {
+		return fn(ctx, be.(*types.WarningUpgradeEvent))
+	})
+}
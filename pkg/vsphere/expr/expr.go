@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expr compiles and evaluates the CEL expressions that a
+// VSphereSource may use to filter and transform events before they
+// are delivered.  Expressions are evaluated with the variables:
+//
+//	event:     the vSphere event, decoded from its JSON form.
+//	eventType: the name of the concrete event type, e.g. "VmCreatedEvent".
+package expr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/vmware/govmomi/vim25/types"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+const (
+	// EventVar is the name of the variable holding the decoded event.
+	EventVar = "event"
+
+	// EventTypeVar is the name of the variable holding the event's type name.
+	EventTypeVar = "eventType"
+)
+
+// Input holds the variables against which expressions are evaluated.
+type Input map[string]interface{}
+
+// NewInput creates the Input for evaluating expressions against the event.
+func NewInput(be types.BaseEvent) (Input, error) {
+	b, err := json.Marshal(be)
+	if err != nil {
+		return nil, err
+	}
+	// Decode numbers as json.Number so that integral values surface as
+	// CEL ints rather than doubles.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var event interface{}
+	if err := dec.Decode(&event); err != nil {
+		return nil, err
+	}
+	return Input{
+		EventVar:     normalize(event),
+		EventTypeVar: reflect.TypeOf(be).Elem().Name(),
+	}, nil
+}
+
+// normalize replaces json.Number values with int64 or float64.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+var env cel.Env
+
+func init() {
+	var err error
+	env, err = cel.NewEnv(cel.Declarations(
+		decls.NewIdent(EventVar, decls.Dyn, nil),
+		decls.NewIdent(EventTypeVar, decls.String, nil),
+	))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// compile parses and checks the expression, and ensures that its result
+// has one of the allowed types.
+func compile(src string, allowed ...*exprpb.Type) (cel.Ast, cel.Program, error) {
+	ast, iss := env.Parse(src)
+	if iss != nil && iss.Err() != nil {
+		return nil, nil, iss.Err()
+	}
+	checked, iss := env.Check(ast)
+	if iss != nil && iss.Err() != nil {
+		return nil, nil, iss.Err()
+	}
+	if len(allowed) > 0 {
+		ok := false
+		for _, t := range allowed {
+			if proto.Equal(checked.ResultType(), t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("expression must evaluate to %v, got %v",
+				allowed[0], checked.ResultType())
+		}
+	}
+	prg, err := env.Program(checked)
+	if err != nil {
+		return nil, nil, err
+	}
+	return checked, prg, nil
+}
+
+// Filter is a compiled expression that selects which events are delivered.
+type Filter struct {
+	ast cel.Ast
+	prg cel.Program
+}
+
+// NewFilter compiles the filter expression, which must evaluate to a bool.
+func NewFilter(src string) (*Filter, error) {
+	ast, prg, err := compile(src, decls.Bool, decls.Dyn)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{ast: ast, prg: prg}, nil
+}
+
+// Matches evaluates the filter against the input.
+func (f *Filter) Matches(in Input) (bool, error) {
+	out, _, err := f.prg.Eval(map[string]interface{}(in))
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.(celtypes.Bool)
+	if !ok {
+		return false, fmt.Errorf("filter evaluated to %v, wanted bool", out.Type())
+	}
+	return bool(b), nil
+}
+
+// Transform is a set of compiled expressions that reshape an event.
+type Transform struct {
+	data       cel.Program
+	extensions map[string]cel.Program
+}
+
+// NewTransform compiles the expressions that produce the event's new
+// payload and extensions.  Either may be empty, and the extension
+// expressions must evaluate to strings.
+func NewTransform(data string, extensions map[string]string) (*Transform, error) {
+	t := &Transform{
+		extensions: make(map[string]cel.Program, len(extensions)),
+	}
+	if data != "" {
+		_, prg, err := compile(data)
+		if err != nil {
+			return nil, fmt.Errorf("data: %w", err)
+		}
+		t.data = prg
+	}
+	for name, src := range extensions {
+		_, prg, err := compile(src, decls.String, decls.Dyn)
+		if err != nil {
+			return nil, fmt.Errorf("extensions[%s]: %w", name, err)
+		}
+		t.extensions[name] = prg
+	}
+	return t, nil
+}
+
+// Data evaluates the data expression against the input, and returns the
+// result encoded as JSON.  It returns nil when no data expression is set.
+func (t *Transform) Data(in Input) ([]byte, error) {
+	if t.data == nil {
+		return nil, nil
+	}
+	out, _, err := t.data.Eval(map[string]interface{}(in))
+	if err != nil {
+		return nil, err
+	}
+	return toJSON(out)
+}
+
+// Extensions evaluates the extension expressions against the input.
+func (t *Transform) Extensions(in Input) (map[string]string, error) {
+	exts := make(map[string]string, len(t.extensions))
+	for name, prg := range t.extensions {
+		out, _, err := prg.Eval(map[string]interface{}(in))
+		if err != nil {
+			return nil, fmt.Errorf("extensions[%s]: %w", name, err)
+		}
+		s, ok := out.(celtypes.String)
+		if !ok {
+			return nil, fmt.Errorf("extensions[%s] evaluated to %v, wanted string", name, out.Type())
+		}
+		exts[name] = string(s)
+	}
+	return exts, nil
+}
+
+var jsonValueType = reflect.TypeOf(&structpb.Value{})
+
+// toJSON encodes a CEL value as JSON.
+func toJSON(v ref.Val) ([]byte, error) {
+	native, err := v.ConvertToNative(jsonValueType)
+	if err != nil {
+		return nil, err
+	}
+	pv, ok := native.(*structpb.Value)
+	if !ok {
+		return nil, errors.New("unable to convert result to JSON")
+	}
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, pv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
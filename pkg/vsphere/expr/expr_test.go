@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var reconfigured = &types.VmReconfiguredEvent{
+	VmEvent: types.VmEvent{
+		Event: types.Event{
+			Key:      42,
+			UserName: "administrator",
+			Vm: &types.VmEventArgument{
+				EntityEventArgument: types.EntityEventArgument{
+					Name: "my-vm",
+				},
+			},
+		},
+	},
+	ConfigSpec: types.VirtualMachineConfigSpec{
+		NumCPUs: 4,
+	},
+}
+
+func TestFilter(t *testing.T) {
+	in, err := NewInput(reconfigured)
+	if err != nil {
+		t.Fatalf("NewInput() = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		src  string
+		want bool
+	}{{
+		name: "type matches",
+		src:  `eventType == "VmReconfiguredEvent"`,
+		want: true,
+	}, {
+		name: "type doesn't match",
+		src:  `eventType == "VmCreatedEvent"`,
+		want: false,
+	}, {
+		name: "field comparison",
+		src:  `eventType == "VmReconfiguredEvent" && event.ConfigSpec.NumCPUs > 2`,
+		want: true,
+	}, {
+		name: "field presence",
+		src:  `has(event.ConfigSpec.NumCPUs) && event.Vm.Name == "my-vm"`,
+		want: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewFilter(test.src)
+			if err != nil {
+				t.Fatalf("NewFilter() = %v", err)
+			}
+			got, err := f.Matches(in)
+			if err != nil {
+				t.Fatalf("Matches() = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Matches() = %v, wanted %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, src := range []string{
+		`eventType ==`,
+		`eventType`,
+		`unknown == 1`,
+	} {
+		if _, err := NewFilter(src); err == nil {
+			t.Errorf("NewFilter(%q) = nil, wanted error", src)
+		}
+	}
+
+	// Referencing a field that isn't there fails at evaluation time.
+	f, err := NewFilter(`event.NoSuchField == 1`)
+	if err != nil {
+		t.Fatalf("NewFilter() = %v", err)
+	}
+	in, err := NewInput(reconfigured)
+	if err != nil {
+		t.Fatalf("NewInput() = %v", err)
+	}
+	if _, err := f.Matches(in); err == nil {
+		t.Error("Matches() = nil, wanted error")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	in, err := NewInput(reconfigured)
+	if err != nil {
+		t.Fatalf("NewInput() = %v", err)
+	}
+
+	tr, err := NewTransform(`{"vm": event.Vm.Name, "cpus": event.ConfigSpec.NumCPUs}`, map[string]string{
+		"vmname": `event.Vm.Name`,
+		"user":   `event.UserName`,
+	})
+	if err != nil {
+		t.Fatalf("NewTransform() = %v", err)
+	}
+
+	data, err := tr.Data(in)
+	if err != nil {
+		t.Fatalf("Data() = %v", err)
+	}
+	if got, want := string(data), `{"cpus":4,"vm":"my-vm"}`; got != want {
+		t.Errorf("Data() = %s, wanted %s", got, want)
+	}
+
+	exts, err := tr.Extensions(in)
+	if err != nil {
+		t.Fatalf("Extensions() = %v", err)
+	}
+	want := map[string]string{
+		"vmname": "my-vm",
+		"user":   "administrator",
+	}
+	if !cmp.Equal(exts, want) {
+		t.Errorf("Extensions (-want, +got) = %s", cmp.Diff(want, exts))
+	}
+
+	if _, err := NewTransform("", map[string]string{"bad": `1 + 1`}); err == nil {
+		t.Error("NewTransform() = nil, wanted error for non-string extension")
+	}
+}
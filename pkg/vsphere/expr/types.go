@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expr
+
+import (
+	"sort"
+
+	"github.com/google/cel-go/common/operators"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// EventTypes returns the names of the event types that the filter can
+// possibly match, and whether it restricts them at all.  The analysis is
+// conservative: it only understands conditions of the form
+//
+//	eventType == "VmCreatedEvent"
+//	eventType in ["VmCreatedEvent", "VmRemovedEvent"]
+//
+// combined with && and ||, and considers anything else unrestricted.
+func (f *Filter) EventTypes() ([]string, bool) {
+	set, ok := restriction(f.ast.Expr())
+	if !ok {
+		return nil, false
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// restriction returns the set of event types to which e restricts
+// eventType, and whether it was able to determine one.
+func restriction(e *exprpb.Expr) (map[string]struct{}, bool) {
+	call := e.GetCallExpr()
+	if call == nil || len(call.Args) != 2 {
+		return nil, false
+	}
+	lhs, rhs := call.Args[0], call.Args[1]
+
+	switch call.Function {
+	case operators.LogicalAnd:
+		l, lok := restriction(lhs)
+		r, rok := restriction(rhs)
+		switch {
+		case lok && rok:
+			both := make(map[string]struct{})
+			for name := range l {
+				if _, ok := r[name]; ok {
+					both[name] = struct{}{}
+				}
+			}
+			return both, true
+		case lok:
+			return l, true
+		case rok:
+			return r, true
+		}
+		return nil, false
+
+	case operators.LogicalOr:
+		l, lok := restriction(lhs)
+		r, rok := restriction(rhs)
+		if !lok || !rok {
+			return nil, false
+		}
+		for name := range r {
+			l[name] = struct{}{}
+		}
+		return l, true
+
+	case operators.Equals:
+		if !isEventType(lhs) {
+			lhs, rhs = rhs, lhs
+		}
+		if s, ok := stringConst(rhs); ok && isEventType(lhs) {
+			return map[string]struct{}{s: {}}, true
+		}
+
+	case operators.In, operators.OldIn:
+		list := rhs.GetListExpr()
+		if !isEventType(lhs) || list == nil {
+			return nil, false
+		}
+		set := make(map[string]struct{}, len(list.Elements))
+		for _, elt := range list.Elements {
+			s, ok := stringConst(elt)
+			if !ok {
+				return nil, false
+			}
+			set[s] = struct{}{}
+		}
+		return set, true
+	}
+	return nil, false
+}
+
+func isEventType(e *exprpb.Expr) bool {
+	id := e.GetIdentExpr()
+	return id != nil && id.Name == EventTypeVar
+}
+
+func stringConst(e *exprpb.Expr) (string, bool) {
+	c := e.GetConstExpr()
+	if c == nil {
+		return "", false
+	}
+	s, ok := c.ConstantKind.(*exprpb.Constant_StringValue)
+	if !ok {
+		return "", false
+	}
+	return s.StringValue, true
+}
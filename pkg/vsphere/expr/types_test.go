@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventTypes(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		want           []string
+		wantRestricted bool
+	}{{
+		name:           "equality",
+		src:            `eventType == "VmCreatedEvent"`,
+		want:           []string{"VmCreatedEvent"},
+		wantRestricted: true,
+	}, {
+		name:           "reversed equality",
+		src:            `"VmCreatedEvent" == eventType`,
+		want:           []string{"VmCreatedEvent"},
+		wantRestricted: true,
+	}, {
+		name:           "in list",
+		src:            `eventType in ["VmRemovedEvent", "VmCreatedEvent"]`,
+		want:           []string{"VmCreatedEvent", "VmRemovedEvent"},
+		wantRestricted: true,
+	}, {
+		name:           "or",
+		src:            `eventType == "VmCreatedEvent" || eventType == "VmRemovedEvent"`,
+		want:           []string{"VmCreatedEvent", "VmRemovedEvent"},
+		wantRestricted: true,
+	}, {
+		name:           "and with other conditions",
+		src:            `event.Vm.Name == "foo" && eventType == "VmCreatedEvent"`,
+		want:           []string{"VmCreatedEvent"},
+		wantRestricted: true,
+	}, {
+		name:           "and of restrictions",
+		src:            `eventType in ["VmRemovedEvent", "VmCreatedEvent"] && eventType != "VmRemovedEvent" && eventType == "VmCreatedEvent"`,
+		want:           []string{"VmCreatedEvent"},
+		wantRestricted: true,
+	}, {
+		name: "or with other conditions",
+		src:  `eventType == "VmCreatedEvent" || event.Vm.Name == "foo"`,
+	}, {
+		name: "negation",
+		src:  `eventType != "VmCreatedEvent"`,
+	}, {
+		name: "no type condition",
+		src:  `event.Vm.Name == "foo"`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewFilter(test.src)
+			if err != nil {
+				t.Fatalf("NewFilter() = %v", err)
+			}
+			got, restricted := f.EventTypes()
+			if restricted != test.wantRestricted {
+				t.Errorf("EventTypes() restricted = %v, wanted %v", restricted, test.wantRestricted)
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Errorf("EventTypes (-want, +got) = %s", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// ExpressionStatus is the value recorded under ExpressionStatusKey.
+type ExpressionStatus struct {
+	// Error holds the most recent evaluation failure, and is empty when
+	// the last event was evaluated successfully.
+	Error string `json:"error,omitempty"`
+
+	// Expression identifies the expression that failed, e.g. "filter".
+	Expression string `json:"expression,omitempty"`
+
+	// Time is when Error was last observed.
+	Time time.Time `json:"time,omitempty"`
+}
+
+// eventTransform mirrors v1alpha1.EventTransform, which the adapter
+// receives as JSON.
+type eventTransform struct {
+	Data       string            `json:"data,omitempty"`
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// parseTransform decodes and compiles the JSON encoded EventTransform.
+func parseTransform(raw string) (*expr.Transform, error) {
+	et := eventTransform{}
+	if err := json.Unmarshal([]byte(raw), &et); err != nil {
+		return nil, err
+	}
+	return expr.NewTransform(et.Data, et.Extensions)
+}
+
+// expressionReporter records the outcome of evaluating expressions in the
+// adapter's ConfigMap, only writing when it changes.
+type expressionReporter struct {
+	client corev1client.ConfigMapInterface
+	name   string
+
+	m    sync.Mutex
+	last string
+}
+
+// report records the error from evaluating the named expression, or its
+// success when err is nil.
+func (er *expressionReporter) report(expression string, err error) error {
+	er.m.Lock()
+	defer er.m.Unlock()
+
+	status := ExpressionStatus{}
+	if err != nil {
+		status = ExpressionStatus{
+			Error:      err.Error(),
+			Expression: expression,
+			Time:       time.Now(),
+		}
+	}
+	if status.Error == er.last {
+		return nil
+	}
+	if err := patchConfigMap(er.client, er.name, ExpressionStatusKey, status); err != nil {
+		return err
+	}
+	er.last = status.Error
+	return nil
+}
+
+// applyExpressions evaluates the source's filter, route and transform
+// expressions against the vSphere event, reshaping the CloudEvent in place.
+// It returns the sinks of the routes that the event matches, which are
+// empty when it should go to the default sink, and false when the event
+// should be dropped, either because the filter didn't match or because an
+// expression failed to evaluate.
+func (a *vAdapter) applyExpressions(ctx context.Context, be types.BaseEvent, event *cloudevents.Event) ([]string, bool) {
+	if a.EventFilter == nil && a.Transform == nil && len(a.Routes) == 0 {
+		return nil, true
+	}
+
+	// fail records the evaluation failure and drops the event.
+	fail := func(expression string, err error) ([]string, bool) {
+		a.Logger.Errorw("failed to evaluate expression", zap.String("expression", expression), zap.Error(err))
+		reportExpressionError(ctx, event.Type(), expression)
+		if a.Expressions != nil {
+			if err := a.Expressions.report(expression, err); err != nil {
+				a.Logger.Errorw("failed to record expression status", zap.Error(err))
+			}
+		}
+		return nil, false
+	}
+
+	in, err := expr.NewInput(be)
+	if err != nil {
+		return fail("input", err)
+	}
+
+	if a.EventFilter != nil {
+		ok, err := a.EventFilter.Matches(in)
+		if err != nil {
+			return fail("filter", err)
+		}
+		if !ok {
+			reportFiltered(ctx, event.Type(), filterReasonFilter)
+			return nil, false
+		}
+	}
+
+	// A route failing to evaluate drops the event, rather than sending it
+	// to the default sink, which it may not be meant for.
+	var sinks []string
+	for _, r := range a.Routes {
+		ok, err := r.filter.Matches(in)
+		if err != nil {
+			return fail(fmt.Sprintf("routes[%s].filter", r.name), err)
+		}
+		if ok {
+			sinks = append(sinks, r.sink)
+		}
+	}
+
+	if a.Transform != nil {
+		data, err := a.Transform.Data(in)
+		if err != nil {
+			return fail("data", err)
+		}
+		exts, err := a.Transform.Extensions(in)
+		if err != nil {
+			return fail("extensions", err)
+		}
+		if data != nil {
+			if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+				return fail("data", err)
+			}
+		}
+		for name, value := range exts {
+			event.SetExtension(name, value)
+		}
+	}
+
+	if a.Expressions != nil {
+		if err := a.Expressions.report("", nil); err != nil {
+			a.Logger.Errorw("failed to record expression status", zap.Error(err))
+		}
+	}
+	return sinks, true
+}
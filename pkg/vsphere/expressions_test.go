@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+)
+
+func powerOn(name string) *types.VmPoweredOnEvent {
+	return &types.VmPoweredOnEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Vm: &types.VmEventArgument{
+					EntityEventArgument: types.EntityEventArgument{
+						Name: name,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyExpressions(t *testing.T) {
+	filter, err := expr.NewFilter(`event.Vm.Name.startsWith("prod-")`)
+	if err != nil {
+		t.Fatalf("NewFilter() = %v", err)
+	}
+	transform, err := parseTransform(`{"data":"{\"vm\": event.Vm.Name}","extensions":{"vmname":"event.Vm.Name"}}`)
+	if err != nil {
+		t.Fatalf("parseTransform() = %v", err)
+	}
+	a := &vAdapter{
+		Logger:      zap.NewNop().Sugar(),
+		EventFilter: filter,
+		Transform:   transform,
+	}
+
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetType("com.vmware.vsphere.VmPoweredOnEvent")
+	if _, ok := a.applyExpressions(context.Background(), powerOn("dev-vm"), &event); ok {
+		t.Error("applyExpressions() = true, wanted the event to be filtered")
+	}
+
+	event = cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetType("com.vmware.vsphere.VmPoweredOnEvent")
+	if _, ok := a.applyExpressions(context.Background(), powerOn("prod-vm"), &event); !ok {
+		t.Fatal("applyExpressions() = false, wanted the event to be sent")
+	}
+	if got, want := string(event.Data()), `{"vm":"prod-vm"}`; got != want {
+		t.Errorf("Data() = %s, wanted %s", got, want)
+	}
+	if got, want := event.DataContentType(), cloudevents.ApplicationJSON; got != want {
+		t.Errorf("DataContentType() = %s, wanted %s", got, want)
+	}
+	if got, want := event.Extensions()["vmname"], "prod-vm"; got != want {
+		t.Errorf("Extensions()[vmname] = %v, wanted %s", got, want)
+	}
+
+	// An event on which the filter fails to evaluate is dropped.
+	event = cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetType("com.vmware.vsphere.SessionEvent")
+	if _, ok := a.applyExpressions(context.Background(), &types.SessionEvent{}, &event); ok {
+		t.Error("applyExpressions() = true, wanted the event to be dropped")
+	}
+}
+
+func TestApplyExpressionsRoutes(t *testing.T) {
+	routes, err := parseRoutes(`[{
+		"name": "security",
+		"filter": "eventType == \"UserLoginSessionEvent\"",
+		"sink": "http://security.default.svc.cluster.local"
+	}, {
+		"name": "prod",
+		"filter": "event.Vm.Name.startsWith(\"prod-\")",
+		"sink": "http://prod.default.svc.cluster.local"
+	}, {
+		"name": "power",
+		"filter": "eventType == \"VmPoweredOnEvent\"",
+		"sink": "http://power.default.svc.cluster.local"
+	}]`)
+	if err != nil {
+		t.Fatalf("parseRoutes() = %v", err)
+	}
+	a := &vAdapter{
+		Logger: zap.NewNop().Sugar(),
+		Routes: routes,
+	}
+
+	tests := []struct {
+		name string
+		be   types.BaseEvent
+		want []string
+		drop bool
+	}{{
+		name: "matches one route",
+		be:   powerOn("dev-vm"),
+		want: []string{"http://power.default.svc.cluster.local"},
+	}, {
+		name: "matches every route",
+		be:   powerOn("prod-vm"),
+		want: []string{"http://prod.default.svc.cluster.local", "http://power.default.svc.cluster.local"},
+	}, {
+		name: "default sink",
+		be: &types.VmRemovedEvent{
+			VmEvent: types.VmEvent{
+				Event: types.Event{
+					Vm: &types.VmEventArgument{},
+				},
+			},
+		},
+	}, {
+		name: "route fails to evaluate",
+		be:   &types.UserLoginSessionEvent{},
+		drop: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := cloudevents.NewEvent(cloudevents.VersionV1)
+			event.SetType("com.vmware.vsphere." + test.name)
+			got, ok := a.applyExpressions(context.Background(), test.be, &event)
+			if ok == test.drop {
+				t.Fatalf("applyExpressions() = %v, wanted %v", ok, !test.drop)
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Errorf("applyExpressions (-want, +got) = %s", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
+func TestSendEventSharedRouteSink(t *testing.T) {
+	routes, err := parseRoutes(`[{
+		"name": "power",
+		"filter": "eventType == \"VmPoweredOnEvent\"",
+		"sink": "http://ops.default.svc.cluster.local"
+	}, {
+		"name": "all",
+		"filter": "true",
+		"sink": "http://ops.default.svc.cluster.local"
+	}]`)
+	if err != nil {
+		t.Fatalf("parseRoutes() = %v", err)
+	}
+	client := &fakeClient{}
+	a := &vAdapter{
+		Logger:   zap.NewNop().Sugar(),
+		Source:   "https://vcenter.local/sdk",
+		CEClient: client,
+		Routes:   routes,
+		Progress: newProgressReporter(nil, "", nil),
+	}
+
+	// The event matches both routes, but their sink only gets it once.
+	if err := a.sendEvent(context.Background(), keyed(1)); err != nil {
+		t.Fatalf("sendEvent() = %v", err)
+	}
+	if got, want := client.sent, []string{"1"}; !cmp.Equal(got, want) {
+		t.Errorf("sent = %v, wanted %v", got, want)
+	}
+}
+
+func TestParseRoutesErrors(t *testing.T) {
+	for name, raw := range map[string]string{
+		"malformed":      `[{`,
+		"missing sink":   `[{"name": "foo", "filter": "true"}]`,
+		"invalid filter": `[{"name": "foo", "filter": "eventType ==", "sink": "http://foo"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got, err := parseRoutes(raw); err == nil {
+				t.Errorf("parseRoutes() = %v, wanted error", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"knative.dev/pkg/kvstore"
+
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+)
+
+// The kinds of kvstore that VSPHERE_KVSTORE may select, as URL schemes.
+const (
+	// KVStoreConfigMap keeps the checkpoint in a ConfigMap in the adapter's
+	// namespace, named by the URL's host or VSPHERE_KVSTORE_CONFIGMAP, e.g.
+	// "configmap://my-source".  The adapter also reports back to the
+	// controller through it.  This is the default.
+	KVStoreConfigMap = "configmap"
+
+	// KVStoreFile keeps the checkpoint in a local file, as a JSON object,
+	// e.g. "file:///tmp/state.json".
+	KVStoreFile = "file"
+
+	// KVStoreMemory keeps the checkpoint in memory, so the adapter starts
+	// from the present each time it starts, e.g. "memory://".
+	KVStoreMemory = "memory"
+)
+
+// parseKVStore parses the value of VSPHERE_KVSTORE, where the empty string
+// selects the ConfigMap kvstore.
+func parseKVStore(raw string) (*url.URL, error) {
+	if raw == "" {
+		return &url.URL{Scheme: KVStoreConfigMap}, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case KVStoreConfigMap, KVStoreMemory:
+	case KVStoreFile:
+		if u.Path == "" {
+			return nil, fmt.Errorf("%q names no file", raw)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kvstore %q, wanted one of %s, %s or %s",
+			raw, KVStoreConfigMap, KVStoreFile, KVStoreMemory)
+	}
+	return u, nil
+}
+
+// newKVStore returns the (initialized) kvstore selected by VSPHERE_KVSTORE.
+// When it is a ConfigMap, it also returns the client for the ConfigMaps in
+// the adapter's namespace and the ConfigMap's name, through which the
+// adapter reports back to the controller.
+func newKVStore(ctx context.Context, env *envConfig) (kvstore.Interface, corev1client.ConfigMapInterface, string, error) {
+	u, err := parseKVStore(env.KVStore)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var (
+		store    kvstore.Interface
+		cmClient corev1client.ConfigMapInterface
+		cmName   string
+	)
+	switch u.Scheme {
+	case KVStoreConfigMap:
+		cmName = u.Host
+		if cmName == "" {
+			cmName = env.KVConfigMap
+		}
+		if cmName == "" {
+			return nil, nil, "", errors.New("the ConfigMap kvstore needs VSPHERE_KVSTORE_CONFIGMAP")
+		}
+		core := kubeclient.Get(ctx).CoreV1()
+		cmClient = core.ConfigMaps(env.Namespace)
+		store = kvstore.NewConfigMapKVStore(ctx, cmName, env.Namespace, core)
+	case KVStoreFile:
+		store = newFileKVStore(u.Path)
+	case KVStoreMemory:
+		store = newMemoryKVStore()
+	}
+	if err := store.Init(ctx); err != nil {
+		return nil, nil, "", err
+	}
+	return store, cmClient, cmName, nil
+}
+
+// KVStoreNeedsKubernetes returns whether the kvstore selected by the value
+// of VSPHERE_KVSTORE is kept in the cluster, and so whether the adapter
+// needs a Kubernetes client.  It returns true for values that it can't
+// parse, so that NewAdapter reports them.
+func KVStoreNeedsKubernetes(raw string) bool {
+	u, err := parseKVStore(raw)
+	return err != nil || u.Scheme == KVStoreConfigMap
+}
+
+// memoryKVStore keeps its keys in memory, JSON encoded as the ConfigMap
+// kvstore does.
+type memoryKVStore struct {
+	data map[string]string
+}
+
+var _ kvstore.Interface = (*memoryKVStore)(nil)
+
+// newMemoryKVStore returns an empty memoryKVStore.
+func newMemoryKVStore() *memoryKVStore {
+	return &memoryKVStore{data: map[string]string{}}
+}
+
+// Init implements kvstore.Interface
+func (ms *memoryKVStore) Init(ctx context.Context) error {
+	return nil
+}
+
+// Load implements kvstore.Interface
+func (ms *memoryKVStore) Load(ctx context.Context) error {
+	return nil
+}
+
+// Save implements kvstore.Interface
+func (ms *memoryKVStore) Save(ctx context.Context) error {
+	return nil
+}
+
+// Get implements kvstore.Interface
+func (ms *memoryKVStore) Get(ctx context.Context, key string, value interface{}) error {
+	v, ok := ms.data[key]
+	if !ok {
+		return fmt.Errorf("key %s does not exist", key)
+	}
+	if err := json.Unmarshal([]byte(v), value); err != nil {
+		return fmt.Errorf("failed to unmarshal %q: %w", v, err)
+	}
+	return nil
+}
+
+// Set implements kvstore.Interface
+func (ms *memoryKVStore) Set(ctx context.Context, key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal: %w", err)
+	}
+	ms.data[key] = string(b)
+	return nil
+}
+
+// fileKVStore is a memoryKVStore that is loaded from and saved to a local
+// file, which holds the keys as a JSON object.
+type fileKVStore struct {
+	*memoryKVStore
+	path string
+}
+
+var _ kvstore.Interface = (*fileKVStore)(nil)
+
+// newFileKVStore returns a fileKVStore backed by the file at path.
+func newFileKVStore(path string) *fileKVStore {
+	return &fileKVStore{
+		memoryKVStore: newMemoryKVStore(),
+		path:          path,
+	}
+}
+
+// Init implements kvstore.Interface
+func (fs *fileKVStore) Init(ctx context.Context) error {
+	err := fs.Load(ctx)
+	if os.IsNotExist(err) {
+		// Create the file now, so that we find out that we can't before
+		// we have anything to save.
+		return fs.Save(ctx)
+	}
+	return err
+}
+
+// Load implements kvstore.Interface
+func (fs *fileKVStore) Load(ctx context.Context) error {
+	b, err := ioutil.ReadFile(fs.path)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", fs.path, err)
+	}
+	fs.data = data
+	return nil
+}
+
+// Save implements kvstore.Interface
+func (fs *fileKVStore) Save(ctx context.Context) error {
+	b, err := json.Marshal(fs.data)
+	if err != nil {
+		return err
+	}
+	// Write the whole file aside and rename it into place, so that we
+	// never leave a partial file behind.
+	tmp, err := ioutil.TempFile(filepath.Dir(fs.path), filepath.Base(fs.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fs.path)
+}
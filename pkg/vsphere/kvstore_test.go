@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseKVStore(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantScheme string
+		wantErr    bool
+		needsKube  bool
+	}{{
+		raw:        "",
+		wantScheme: KVStoreConfigMap,
+		needsKube:  true,
+	}, {
+		raw:        "configmap://my-source",
+		wantScheme: KVStoreConfigMap,
+		needsKube:  true,
+	}, {
+		raw:        "file:///tmp/state.json",
+		wantScheme: KVStoreFile,
+	}, {
+		raw:     "file://",
+		wantErr: true,
+		// NewAdapter reports the error.
+		needsKube: true,
+	}, {
+		raw:        "memory://",
+		wantScheme: KVStoreMemory,
+	}, {
+		raw:       "redis://localhost",
+		wantErr:   true,
+		needsKube: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			u, err := parseKVStore(test.raw)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseKVStore() = %v, wantErr %v", err, test.wantErr)
+			}
+			if err == nil && u.Scheme != test.wantScheme {
+				t.Errorf("parseKVStore() scheme = %q, wanted %q", u.Scheme, test.wantScheme)
+			}
+			if got := KVStoreNeedsKubernetes(test.raw); got != test.needsKube {
+				t.Errorf("KVStoreNeedsKubernetes() = %v, wanted %v", got, test.needsKube)
+			}
+		})
+	}
+}
+
+func TestMemoryKVStore(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryKVStore()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if cp := loadCheckpoint(ctx, store); cp != nil {
+		t.Errorf("loadCheckpoint() = %v, wanted nil", cp)
+	}
+
+	want := &Checkpoint{LastEventKey: 42}
+	if err := store.Set(ctx, CheckpointKey, want); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if got := loadCheckpoint(ctx, store); !cmp.Equal(got, want) {
+		t.Errorf("loadCheckpoint() = %v, wanted %v", got, want)
+	}
+}
+
+func TestFileKVStore(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "kvstore")
+	if err != nil {
+		t.Fatalf("TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	store := newFileKVStore(path)
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Init() didn't create the file: %v", err)
+	}
+
+	want := &Checkpoint{
+		LastEventKey:  42,
+		LastEventTime: time.Date(2020, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := store.Set(ctx, CheckpointKey, want); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if err := store.Save(ctx); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+
+	// A new store picks up where the last one left off.
+	store = newFileKVStore(path)
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+	if got := loadCheckpoint(ctx, store); !cmp.Equal(got, want) {
+		t.Errorf("loadCheckpoint() = %v, wanted %v", got, want)
+	}
+
+	// Nothing else is left behind in the directory.
+	if files, err := ioutil.ReadDir(dir); err != nil {
+		t.Fatalf("ReadDir() = %v", err)
+	} else if len(files) != 1 {
+		t.Errorf("ReadDir() = %d files, wanted 1", len(files))
+	}
+}
+
+func TestFileKVStoreCorrupt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kvstore")
+	if err != nil {
+		t.Fatalf("TempDir() = %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+	if err := ioutil.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	if err := newFileKVStore(path).Init(context.Background()); err == nil {
+		t.Error("Init() = nil, wanted error")
+	}
+}
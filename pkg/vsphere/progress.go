@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Progress is what the adapter reports about the flow of events in its
+// heartbeats.
+type Progress struct {
+	// LastEventKey and LastEventTime identify the last event that the
+	// adapter has handled (whether or not it was sent).
+	LastEventKey  int32      `json:"lastEventKey,omitempty"`
+	LastEventTime *time.Time `json:"lastEventTime,omitempty"`
+
+	// EventsSent counts the events sent since the adapter started.
+	EventsSent int64 `json:"eventsSent"`
+
+	// VCenterVersion and InstanceUUID describe the vCenter that the adapter
+	// is connected to.
+	VCenterVersion string `json:"vcenterVersion,omitempty"`
+	InstanceUUID   string `json:"instanceUUID,omitempty"`
+
+	// HeartbeatTime is when the adapter published this, and
+	// HeartbeatInterval is how long until it publishes the next one, so
+	// that the controller knows when to stop waiting for it.
+	HeartbeatTime     time.Time     `json:"heartbeatTime"`
+	HeartbeatInterval time.Duration `json:"heartbeatInterval,omitempty"`
+}
+
+// progressReporter tracks the adapter's progress, and periodically
+// publishes it to the adapter's ConfigMap.
+type progressReporter struct {
+	client corev1client.ConfigMapInterface
+	name   string
+
+	m        sync.Mutex
+	progress Progress
+}
+
+// newProgressReporter returns a progressReporter for the adapter with the
+// given ConfigMap, which picks up from its checkpoint, if any.
+func newProgressReporter(client corev1client.ConfigMapInterface, name string, cp *Checkpoint) *progressReporter {
+	pr := &progressReporter{
+		client: client,
+		name:   name,
+	}
+	if cp != nil {
+		last := cp.LastEventTime
+		pr.progress.LastEventKey = cp.LastEventKey
+		pr.progress.LastEventTime = &last
+	}
+	return pr
+}
+
+// handled records that the adapter is done with the event.
+func (pr *progressReporter) handled(be types.BaseEvent) {
+	if pr == nil {
+		return
+	}
+	pr.m.Lock()
+	defer pr.m.Unlock()
+	created := be.GetEvent().CreatedTime
+	pr.progress.LastEventKey = be.GetEvent().Key
+	pr.progress.LastEventTime = &created
+}
+
+// sent records that the adapter sent an event.
+func (pr *progressReporter) sent() {
+	if pr == nil {
+		return
+	}
+	pr.m.Lock()
+	defer pr.m.Unlock()
+	pr.progress.EventsSent++
+}
+
+// connected records the vCenter that the adapter is connected to.
+func (pr *progressReporter) connected(about types.AboutInfo) {
+	pr.m.Lock()
+	defer pr.m.Unlock()
+	pr.progress.VCenterVersion = about.Version
+	pr.progress.InstanceUUID = about.InstanceUuid
+}
+
+// report publishes the adapter's progress.
+func (pr *progressReporter) report(now time.Time) error {
+	pr.m.Lock()
+	progress := pr.progress
+	pr.m.Unlock()
+
+	progress.HeartbeatTime = now
+	return patchConfigMap(pr.client, pr.name, ProgressKey, progress)
+}
+
+// run publishes the adapter's progress every interval, until the context
+// is cancelled.
+func (pr *progressReporter) run(ctx context.Context, logger *zap.SugaredLogger, interval time.Duration) {
+	pr.m.Lock()
+	pr.progress.HeartbeatInterval = interval
+	pr.m.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := pr.report(time.Now()); err != nil {
+			logger.Errorw("failed to report progress", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vim25/xml"
+)
+
+// RecordedEvent is a line of a recording of a vCenter's event stream, which
+// is stored as JSON lines.
+type RecordedEvent struct {
+	// Source is the CloudEvent source of the event, i.e. the vCenter's
+	// address.
+	Source string `json:"source"`
+
+	// Type is the name of the concrete vSphere event type, e.g.
+	// VmPoweredOnEvent.
+	Type string `json:"type"`
+
+	// Event is the vSphere event, encoded as XML the way that vCenter
+	// encodes it, so that the concrete types of its polymorphic fields are
+	// preserved too.
+	Event string `json:"event"`
+}
+
+// recorder writes the events that the adapter receives to a recording.
+type recorder struct {
+	m   sync.Mutex
+	enc *json.Encoder
+}
+
+// newRecorder returns a recorder writing to w.
+func newRecorder(w io.Writer) *recorder {
+	return &recorder{enc: json.NewEncoder(w)}
+}
+
+// record appends the event to the recording.
+func (r *recorder) record(source string, be types.BaseEvent) error {
+	if r == nil {
+		return nil
+	}
+	b, err := xml.Marshal(be)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %d: %w", be.GetEvent().Key, err)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.enc.Encode(RecordedEvent{
+		Source: source,
+		Type:   reflect.TypeOf(be).Elem().Name(),
+		Event:  string(b),
+	})
+}
+
+// Decode reconstructs the recorded vSphere event.
+func (re *RecordedEvent) Decode() (types.BaseEvent, error) {
+	t, ok := typeFunc(re.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown vSphere event type %q", re.Type)
+	}
+	be, ok := reflect.New(t).Interface().(types.BaseEvent)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a vSphere event type", re.Type)
+	}
+	dec := xml.NewDecoder(strings.NewReader(re.Event))
+	dec.TypeFunc = typeFunc
+	if err := dec.Decode(be); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", re.Type, err)
+	}
+	return be, nil
+}
+
+var typeFunc = types.TypeFunc()
+
+// replayer plays a recording back as the adapter's event stream, instead
+// of a vCenter's.
+type replayer struct {
+	dec *json.Decoder
+
+	// speed is how many times faster than they originally happened the
+	// events are played back, or zero to play them back without pausing.
+	speed float64
+
+	// sleep is time.Sleep, but may be cancelled, and replaced for tests.
+	sleep func(context.Context, time.Duration) error
+}
+
+// newReplayer returns a replayer reading the recording from r.
+func newReplayer(r io.Reader, speed float64) *replayer {
+	return &replayer{
+		dec:   json.NewDecoder(r),
+		speed: speed,
+		sleep: func(ctx context.Context, d time.Duration) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d):
+				return nil
+			}
+		},
+	}
+}
+
+// run passes each of the recorded events to send, with the source they were
+// recorded with, pausing between them as they originally were (adjusted by
+// the replayer's speed).  It returns when it reaches the end of the
+// recording.
+func (rp *replayer) run(ctx context.Context, send func(source string, be types.BaseEvent) error) error {
+	var last time.Time
+	for line := 1; ; line++ {
+		var re RecordedEvent
+		if err := rp.dec.Decode(&re); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read the recording at event %d: %w", line, err)
+		}
+		be, err := re.Decode()
+		if err != nil {
+			return fmt.Errorf("failed to decode the recording at event %d: %w", line, err)
+		}
+
+		created := be.GetEvent().CreatedTime
+		if rp.speed > 0 && !last.IsZero() && created.After(last) {
+			pause := time.Duration(float64(created.Sub(last)) / rp.speed)
+			if err := rp.sleep(ctx, pause); err != nil {
+				return err
+			}
+		}
+		last = created
+
+		if err := send(re.Source, be); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+)
+
+// captureClient is a cloudevents.Client that keeps the events that it
+// sends.
+type captureClient struct {
+	cloudevents.Client
+	events []cloudevents.Event
+}
+
+func (c *captureClient) Send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	c.events = append(c.events, event)
+	return cloudevents.ResultACK
+}
+
+// recordedEvents returns a variety of events, including ones with
+// polymorphic fields.
+func recordedEvents() []types.BaseEvent {
+	created := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	return []types.BaseEvent{
+		&types.VmPoweredOnEvent{
+			VmEvent: types.VmEvent{
+				Event: types.Event{
+					Key:         1,
+					CreatedTime: created,
+					UserName:    "root",
+					Vm: &types.VmEventArgument{
+						EntityEventArgument: types.EntityEventArgument{Name: "web-1"},
+						Vm:                  types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+					},
+					FullFormattedMessage: "web-1 on host-1 is powered on",
+				},
+			},
+		},
+		&types.EventEx{
+			Event: types.Event{
+				Key:         2,
+				CreatedTime: created.Add(2 * time.Second),
+			},
+			EventTypeId: "com.vmware.vc.example",
+			Arguments: []types.KeyAnyValue{{
+				Key:   "count",
+				Value: int32(3),
+			}, {
+				Key:   "name",
+				Value: "thing",
+			}},
+		},
+		&types.UserLoginSessionEvent{
+			SessionEvent: types.SessionEvent{
+				Event: types.Event{
+					Key:         3,
+					CreatedTime: created.Add(6 * time.Second),
+					UserName:    "administrator@vsphere.local",
+				},
+			},
+			IpAddress: "10.0.0.1",
+		},
+	}
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rec := newRecorder(buf)
+	want := recordedEvents()
+	for _, be := range want {
+		if err := rec.record("https://vcenter.local/sdk", be); err != nil {
+			t.Fatalf("record() = %v", err)
+		}
+	}
+	if got := strings.Count(buf.String(), "\n"); got != len(want) {
+		t.Errorf("recorded %d lines, wanted %d", got, len(want))
+	}
+
+	var got []types.BaseEvent
+	rp := newReplayer(buf, 0)
+	err := rp.run(context.Background(), func(source string, be types.BaseEvent) error {
+		if source != "https://vcenter.local/sdk" {
+			t.Errorf("source = %q", source)
+		}
+		got = append(got, be)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("replayed (-want, +got) = %s", cmp.Diff(want, got))
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	tests := []struct {
+		speed float64
+		want  []time.Duration
+	}{{
+		speed: 1,
+		want:  []time.Duration{2 * time.Second, 4 * time.Second},
+	}, {
+		speed: 4,
+		want:  []time.Duration{500 * time.Millisecond, time.Second},
+	}, {
+		speed: 0,
+	}}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		rec := newRecorder(buf)
+		for _, be := range recordedEvents() {
+			if err := rec.record("", be); err != nil {
+				t.Fatalf("record() = %v", err)
+			}
+		}
+
+		var got []time.Duration
+		rp := newReplayer(buf, test.speed)
+		rp.sleep = func(ctx context.Context, d time.Duration) error {
+			got = append(got, d)
+			return nil
+		}
+		if err := rp.run(context.Background(), func(string, types.BaseEvent) error { return nil }); err != nil {
+			t.Fatalf("run() = %v", err)
+		}
+		if !cmp.Equal(test.want, got) {
+			t.Errorf("speed %v: pauses = %v, wanted %v", test.speed, got, test.want)
+		}
+	}
+}
+
+func TestReplayIdenticalCloudEvents(t *testing.T) {
+	ctx := context.Background()
+
+	// Record the events as the adapter sends them.
+	buf := &bytes.Buffer{}
+	live := &captureClient{}
+	a := &vAdapter{
+		Logger:   zap.NewNop().Sugar(),
+		Source:   "https://vcenter.local/sdk",
+		CEClient: live,
+		KVStore:  newMemoryKVStore(),
+		Recorder: newRecorder(buf),
+	}
+	if err := a.sendEvents(ctx)(types.ManagedObjectReference{}, recordedEvents()); err != nil {
+		t.Fatalf("sendEvents() = %v", err)
+	}
+
+	// Replaying them sends the same CloudEvents.
+	replayed := &captureClient{}
+	b := &vAdapter{
+		Logger:   zap.NewNop().Sugar(),
+		CEClient: replayed,
+		KVStore:  newMemoryKVStore(),
+		Replay:   newReplayer(buf, 0),
+	}
+	if err := b.run(ctx); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+
+	if len(live.events) != len(recordedEvents()) {
+		t.Fatalf("sent %d events, wanted %d", len(live.events), len(recordedEvents()))
+	}
+	if got, want := len(replayed.events), len(live.events); got != want {
+		t.Fatalf("replayed %d events, wanted %d", got, want)
+	}
+	for i := range live.events {
+		if got, want := replayed.events[i].String(), live.events[i].String(); got != want {
+			t.Errorf("replayed event %d (-want, +got) = %s", i, cmp.Diff(want, got))
+		}
+	}
+}
+
+func TestReplayCorrupt(t *testing.T) {
+	for _, raw := range []string{
+		"not json",
+		`{"type":"NoSuchEvent","event":"<NoSuchEvent></NoSuchEvent>"}`,
+		`{"type":"VmPoweredOnEvent","event":"<VmPoweredOnEvent><key>one</key></VmPoweredOnEvent>"}`,
+	} {
+		rp := newReplayer(strings.NewReader(raw), 0)
+		if err := rp.run(context.Background(), func(string, types.BaseEvent) error { return nil }); err == nil {
+			t.Errorf("run(%s) = nil, wanted error", raw)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+)
+
+const replySink = "http://reply.example.com"
+
+// replyingClient is a cloudevents.Client whose sink replies to the events
+// that it is sent with reply, and which records where events are sent.
+type replyingClient struct {
+	cloudevents.Client
+	reply func(cloudevents.Event) *cloudevents.Event
+
+	// nackReplies makes the reply sink reject the replies.
+	nackReplies bool
+
+	requests []string
+	replies  []cloudevents.Event
+	attempts int
+}
+
+func (c *replyingClient) Request(ctx context.Context, event cloudevents.Event) (*cloudevents.Event, cloudevents.Result) {
+	c.requests = append(c.requests, event.ID())
+	return c.reply(event), cloudevents.ResultACK
+}
+
+func (c *replyingClient) Send(ctx context.Context, event cloudevents.Event) cloudevents.Result {
+	if target := cloudevents.TargetFromContext(ctx); target == nil || target.String() != replySink {
+		// Only replies are sent without a response.
+		c.requests = append(c.requests, event.ID())
+		return cloudevents.ResultACK
+	}
+	c.attempts++
+	if c.nackReplies {
+		return cloudevents.ResultNACK
+	}
+	c.replies = append(c.replies, event)
+	return cloudevents.ResultACK
+}
+
+// enrich replies to events with a follow-up event.
+func enrich(event cloudevents.Event) *cloudevents.Event {
+	reply := cloudevents.NewEvent()
+	reply.SetID("reply-to-" + event.ID())
+	reply.SetType("com.example.enriched")
+	reply.SetSource("https://enricher.example.com")
+	return &reply
+}
+
+func TestSendReplies(t *testing.T) {
+	tests := []struct {
+		name        string
+		replySink   string
+		reply       func(cloudevents.Event) *cloudevents.Event
+		nackReplies bool
+		wantACK     bool
+		wantReplies []string
+		wantAttempt int
+	}{{
+		name:    "no reply sink",
+		reply:   enrich,
+		wantACK: true,
+	}, {
+		name:        "reply forwarded",
+		replySink:   replySink,
+		reply:       enrich,
+		wantACK:     true,
+		wantReplies: []string{"reply-to-1"},
+		wantAttempt: 1,
+	}, {
+		name:      "no reply",
+		replySink: replySink,
+		reply: func(cloudevents.Event) *cloudevents.Event {
+			return nil
+		},
+		wantACK: true,
+	}, {
+		name:      "invalid reply dropped",
+		replySink: replySink,
+		reply: func(cloudevents.Event) *cloudevents.Event {
+			reply := cloudevents.NewEvent()
+			return &reply
+		},
+		wantACK: true,
+	}, {
+		name:        "reply rejected",
+		replySink:   replySink,
+		reply:       enrich,
+		nackReplies: true,
+		wantACK:     false,
+		wantAttempt: 2,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &replyingClient{
+				reply:       test.reply,
+				nackReplies: test.nackReplies,
+			}
+			a := &vAdapter{
+				Logger:    zap.NewNop().Sugar(),
+				CEClient:  client,
+				ReplySink: test.replySink,
+				Retries:   &retryPolicy{retries: 1, delay: time.Millisecond},
+			}
+			event := cloudevents.NewEvent()
+			event.SetID("1")
+
+			if got := cloudevents.IsACK(a.send(context.Background(), event)); got != test.wantACK {
+				t.Errorf("send() ACK = %v, wanted %v", got, test.wantACK)
+			}
+			if got, want := client.requests, []string{"1"}; !cmp.Equal(got, want) {
+				t.Errorf("sent %v, wanted %v", got, want)
+			}
+			var got []string
+			for _, reply := range client.replies {
+				got = append(got, reply.ID())
+				if id := reply.Extensions()[ReplyToExtension]; id != event.ID() {
+					t.Errorf("%s = %v, wanted %s", ReplyToExtension, id, event.ID())
+				}
+			}
+			if !cmp.Equal(got, test.wantReplies) {
+				t.Errorf("replies = %v, wanted %v", got, test.wantReplies)
+			}
+			if client.attempts != test.wantAttempt {
+				t.Errorf("reply attempts = %d, wanted %d", client.attempts, test.wantAttempt)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+)
+
+// eventRoute is the form in which the adapter receives the source's
+// routes as JSON, with their sinks resolved by the controller.
+type eventRoute struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+	Sink   string `json:"sink"`
+}
+
+// route sends the events matching its filter to its sink.
+type route struct {
+	name   string
+	filter *expr.Filter
+	sink   string
+}
+
+// parseRoutes decodes and compiles the JSON encoded routes.
+func parseRoutes(raw string) ([]route, error) {
+	var ers []eventRoute
+	if err := json.Unmarshal([]byte(raw), &ers); err != nil {
+		return nil, err
+	}
+	routes := make([]route, 0, len(ers))
+	for _, er := range ers {
+		if er.Sink == "" {
+			return nil, fmt.Errorf("route %q has no sink", er.Name)
+		}
+		filter, err := expr.NewFilter(er.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("route %q: %w", er.Name, err)
+		}
+		routes = append(routes, route{
+			name:   er.Name,
+			filter: filter,
+			sink:   er.Sink,
+		})
+	}
+	return routes, nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+// entitySelector mirrors the JSON shape of v1alpha1.EntitySelector, which
+// the reconciler hands us via VSPHERE_SELECTOR.  We cannot use the API type
+// directly because the API package depends on this one.
+type entitySelector struct {
+	Tags []struct {
+		Category string `json:"category"`
+		Name     string `json:"name"`
+	} `json:"tags,omitempty"`
+	CustomAttributes []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"customAttributes,omitempty"`
+}
+
+// entityFilter evaluates an entitySelector against the entity affected by
+// each event.  Tag associations are served from a cache that is refreshed
+// periodically from the vAPI tagging service, whereas custom attributes are
+// read from the entity when the event is evaluated.
+type entityFilter struct {
+	selector entitySelector
+
+	tags      *tags.Manager
+	fields    *object.CustomFieldsManager
+	collector *property.Collector
+
+	m sync.RWMutex
+	// attached holds the set of entities attached to each entry
+	// of selector.Tags (in the same order).
+	attached []map[types.ManagedObjectReference]struct{}
+	// fieldKeys maps custom attribute names to their keys.
+	fieldKeys map[string]int32
+}
+
+// parseSelector decodes the JSON encoded selector.
+func parseSelector(raw string) (*entitySelector, error) {
+	sel := &entitySelector{}
+	if err := json.Unmarshal([]byte(raw), sel); err != nil {
+		return nil, fmt.Errorf("unable to parse selector: %w", err)
+	}
+	return sel, nil
+}
+
+// newEntityFilter creates an entityFilter for the given selector.  The
+// tag manager is only needed when the selector references tags.
+func newEntityFilter(sel *entitySelector, vc *vim25.Client, tm *tags.Manager) (*entityFilter, error) {
+	if len(sel.Tags) > 0 && tm == nil {
+		return nil, errors.New("a tag manager is required to select by tags")
+	}
+	return &entityFilter{
+		selector:  *sel,
+		tags:      tm,
+		fields:    object.NewCustomFieldsManager(vc),
+		collector: property.DefaultCollector(vc),
+	}, nil
+}
+
+// Refresh reloads the tag associations and custom attribute definitions.
+func (f *entityFilter) Refresh(ctx context.Context) error {
+	attached := make([]map[types.ManagedObjectReference]struct{}, 0, len(f.selector.Tags))
+	for _, ts := range f.selector.Tags {
+		tag, err := f.tags.GetTagForCategory(ctx, ts.Name, ts.Category)
+		if err != nil {
+			return fmt.Errorf("unable to find tag %s:%s: %w", ts.Category, ts.Name, err)
+		}
+		objs, err := f.tags.ListAttachedObjects(ctx, tag.ID)
+		if err != nil {
+			return fmt.Errorf("unable to list objects tagged %s:%s: %w", ts.Category, ts.Name, err)
+		}
+		set := make(map[types.ManagedObjectReference]struct{}, len(objs))
+		for _, obj := range objs {
+			set[obj.Reference()] = struct{}{}
+		}
+		attached = append(attached, set)
+	}
+
+	var fieldKeys map[string]int32
+	if len(f.selector.CustomAttributes) > 0 {
+		defs, err := f.fields.Field(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list custom attributes: %w", err)
+		}
+		fieldKeys = make(map[string]int32, len(defs))
+		for _, def := range defs {
+			fieldKeys[def.Name] = def.Key
+		}
+	}
+
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.attached = attached
+	f.fieldKeys = fieldKeys
+	return nil
+}
+
+// Prime refreshes the filter until that succeeds, retrying every interval,
+// up to the given number of attempts, or until the context is cancelled.
+// Until it is primed, the filter would select the events of entities with
+// any tags.  A selector that names a tag or attribute that doesn't exist
+// never succeeds, so we give up rather than wait on it forever.
+func (f *entityFilter) Prime(ctx context.Context, interval time.Duration, attempts int) error {
+	for attempt := 1; ; attempt++ {
+		err := f.Refresh(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("unable to prime selector after %d attempts: %w", attempts, err)
+		}
+		logging.FromContext(ctx).Errorw("failed to refresh selector, retrying", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Run refreshes the filter every period until the context is cancelled.
+func (f *entityFilter) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Refresh(ctx); err != nil {
+				logging.FromContext(ctx).Errorw("failed to refresh selector", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Matches returns whether the entity affected by the event is selected.
+// Entities that no longer exist aren't selected by custom attributes.
+func (f *entityFilter) Matches(ctx context.Context, be types.BaseEvent) (bool, error) {
+	ref := AffectedEntity(be.GetEvent())
+	if ref == nil {
+		// Events that don't name an entity can't be selected.
+		return false, nil
+	}
+
+	f.m.RLock()
+	attached, fieldKeys := f.attached, f.fieldKeys
+	f.m.RUnlock()
+
+	for _, set := range attached {
+		if _, ok := set[*ref]; !ok {
+			return false, nil
+		}
+	}
+
+	if len(f.selector.CustomAttributes) == 0 {
+		return true, nil
+	}
+
+	var me mo.ManagedEntity
+	if err := f.collector.RetrieveOne(ctx, *ref, []string{"customValue"}, &me); err != nil {
+		if isNotFound(err) {
+			// The entity is gone (e.g. the event is a VmRemovedEvent), and
+			// with it its custom attributes.
+			return false, nil
+		}
+		return false, err
+	}
+	values := make(map[int32]string, len(me.CustomValue))
+	for _, bcv := range me.CustomValue {
+		if cv, ok := bcv.(*types.CustomFieldStringValue); ok {
+			values[cv.Key] = cv.Value
+		}
+	}
+	for _, cas := range f.selector.CustomAttributes {
+		key, ok := fieldKeys[cas.Name]
+		if !ok {
+			return false, nil
+		}
+		if v, ok := values[key]; !ok || v != cas.Value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isNotFound returns whether the error is vCenter reporting that an object
+// doesn't exist (any longer).
+func isNotFound(err error) bool {
+	if !soap.IsSoapFault(err) {
+		return false
+	}
+	_, ok := soap.ToSoapFault(err).VimFault().(types.ManagedObjectNotFound)
+	return ok
+}
+
+// AffectedEntity returns the most specific entity named by the event, or
+// nil when it names none.
+func AffectedEntity(e *types.Event) *types.ManagedObjectReference {
+	switch {
+	case e.Vm != nil:
+		return &e.Vm.Vm
+	case e.Host != nil:
+		return &e.Host.Host
+	case e.ComputeResource != nil:
+		return &e.ComputeResource.ComputeResource
+	case e.Ds != nil:
+		return &e.Ds.Datastore
+	case e.Net != nil:
+		return &e.Net.Network
+	case e.Dvs != nil:
+		return &e.Dvs.Dvs
+	case e.Datacenter != nil:
+		return &e.Datacenter.Datacenter
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	_ "github.com/vmware/govmomi/vapi/simulator"
+)
+
+func TestEntityFilter(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		vms := simulator.Map.All("VirtualMachine")
+		if len(vms) < 2 {
+			t.Fatalf("Want at least 2 VMs, got %d", len(vms))
+		}
+		tagged, untagged := vms[0].Reference(), vms[1].Reference()
+
+		rc := rest.NewClient(c)
+		if err := rc.Login(ctx, simulator.DefaultLogin); err != nil {
+			t.Fatalf("Login() = %v", err)
+		}
+		tm := tags.NewManager(rc)
+		catID, err := tm.CreateCategory(ctx, &tags.Category{Name: "env"})
+		if err != nil {
+			t.Fatalf("CreateCategory() = %v", err)
+		}
+		tagID, err := tm.CreateTag(ctx, &tags.Tag{Name: "prod", CategoryID: catID})
+		if err != nil {
+			t.Fatalf("CreateTag() = %v", err)
+		}
+		if err := tm.AttachTag(ctx, tagID, tagged); err != nil {
+			t.Fatalf("AttachTag() = %v", err)
+		}
+
+		cfm := object.NewCustomFieldsManager(c)
+		field, err := cfm.Add(ctx, "owner", "VirtualMachine", nil, nil)
+		if err != nil {
+			t.Fatalf("Add() = %v", err)
+		}
+		for _, ref := range []types.ManagedObjectReference{tagged, untagged} {
+			if err := cfm.Set(ctx, ref, field.Key, "team-x"); err != nil {
+				t.Fatalf("Set() = %v", err)
+			}
+		}
+
+		tests := []struct {
+			name     string
+			selector string
+			event    types.BaseEvent
+			want     bool
+		}{{
+			name:     "tag matches",
+			selector: `{"tags":[{"category":"env","name":"prod"}]}`,
+			event:    vmEvent(tagged),
+			want:     true,
+		}, {
+			name:     "tag doesn't match",
+			selector: `{"tags":[{"category":"env","name":"prod"}]}`,
+			event:    vmEvent(untagged),
+			want:     false,
+		}, {
+			name:     "custom attribute matches",
+			selector: `{"customAttributes":[{"name":"owner","value":"team-x"}]}`,
+			event:    vmEvent(untagged),
+			want:     true,
+		}, {
+			name:     "custom attribute doesn't match",
+			selector: `{"customAttributes":[{"name":"owner","value":"team-y"}]}`,
+			event:    vmEvent(untagged),
+			want:     false,
+		}, {
+			name:     "unknown custom attribute",
+			selector: `{"customAttributes":[{"name":"cost-center","value":"1234"}]}`,
+			event:    vmEvent(untagged),
+			want:     false,
+		}, {
+			name:     "tag and custom attribute",
+			selector: `{"tags":[{"category":"env","name":"prod"}],"customAttributes":[{"name":"owner","value":"team-x"}]}`,
+			event:    vmEvent(tagged),
+			want:     true,
+		}, {
+			name:     "no entity",
+			selector: `{"customAttributes":[{"name":"owner","value":"team-x"}]}`,
+			event:    &types.SessionTerminatedEvent{},
+			want:     false,
+		}}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				sel, err := parseSelector(test.selector)
+				if err != nil {
+					t.Fatalf("parseSelector() = %v", err)
+				}
+				f, err := newEntityFilter(sel, c, tm)
+				if err != nil {
+					t.Fatalf("newEntityFilter() = %v", err)
+				}
+				if err := f.Refresh(ctx); err != nil {
+					t.Fatalf("Refresh() = %v", err)
+				}
+				got, err := f.Matches(ctx, test.event)
+				if err != nil {
+					t.Fatalf("Matches() = %v", err)
+				}
+				if got != test.want {
+					t.Errorf("Matches() = %v, wanted %v", got, test.want)
+				}
+			})
+		}
+	})
+}
+
+func TestEntityFilterRemovedEntity(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		cfm := object.NewCustomFieldsManager(c)
+		field, err := cfm.Add(ctx, "owner", "VirtualMachine", nil, nil)
+		if err != nil {
+			t.Fatalf("Add() = %v", err)
+		}
+		vm := object.NewVirtualMachine(c, simulator.Map.Any("VirtualMachine").Reference())
+		if err := cfm.Set(ctx, vm.Reference(), field.Key, "team-x"); err != nil {
+			t.Fatalf("Set() = %v", err)
+		}
+
+		sel, err := parseSelector(`{"customAttributes":[{"name":"owner","value":"team-x"}]}`)
+		if err != nil {
+			t.Fatalf("parseSelector() = %v", err)
+		}
+		f, err := newEntityFilter(sel, c, nil)
+		if err != nil {
+			t.Fatalf("newEntityFilter() = %v", err)
+		}
+		if err := f.Refresh(ctx); err != nil {
+			t.Fatalf("Refresh() = %v", err)
+		}
+		if got, err := f.Matches(ctx, vmEvent(vm.Reference())); err != nil || !got {
+			t.Fatalf("Matches() = %v, %v, wanted true", got, err)
+		}
+
+		// Remove the VM, as happens before its VmRemovedEvent is evaluated.
+		for _, start := range []func(context.Context) (*object.Task, error){vm.PowerOff, vm.Destroy} {
+			task, err := start(ctx)
+			if err != nil {
+				t.Fatalf("task = %v", err)
+			}
+			if err := task.Wait(ctx); err != nil {
+				t.Fatalf("Wait() = %v", err)
+			}
+		}
+		removed := &types.VmRemovedEvent{
+			VmEvent: types.VmEvent{
+				Event: types.Event{
+					Vm: &types.VmEventArgument{Vm: vm.Reference()},
+				},
+			},
+		}
+		if got, err := f.Matches(ctx, removed); err != nil || got {
+			t.Errorf("Matches() = %v, %v, wanted false", got, err)
+		}
+
+		// The adapter drops the event rather than failing on it.
+		a := &vAdapter{
+			Logger: zap.NewNop().Sugar(),
+			Filter: f,
+		}
+		if err := a.sendEvent(ctx, removed); err != nil {
+			t.Errorf("sendEvent() = %v", err)
+		}
+	})
+}
+
+func TestEntityFilterPrime(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		ctx = logging.WithLogger(ctx, zap.NewNop().Sugar())
+		rc := rest.NewClient(c)
+		if err := rc.Login(ctx, simulator.DefaultLogin); err != nil {
+			t.Fatalf("Login() = %v", err)
+		}
+		tm := tags.NewManager(rc)
+		sel, err := parseSelector(`{"tags":[{"category":"env","name":"prod"}]}`)
+		if err != nil {
+			t.Fatalf("parseSelector() = %v", err)
+		}
+		f, err := newEntityFilter(sel, c, tm)
+		if err != nil {
+			t.Fatalf("newEntityFilter() = %v", err)
+		}
+
+		// The tag doesn't exist yet, so priming keeps retrying until it does.
+		primed := make(chan error)
+		go func() {
+			primed <- f.Prime(ctx, 10*time.Millisecond, 1000)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		select {
+		case err := <-primed:
+			t.Fatalf("Prime() = %v before the tag existed", err)
+		default:
+		}
+		catID, err := tm.CreateCategory(ctx, &tags.Category{Name: "env"})
+		if err != nil {
+			t.Fatalf("CreateCategory() = %v", err)
+		}
+		if _, err := tm.CreateTag(ctx, &tags.Tag{Name: "prod", CategoryID: catID}); err != nil {
+			t.Fatalf("CreateTag() = %v", err)
+		}
+		select {
+		case err := <-primed:
+			if err != nil {
+				t.Errorf("Prime() = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for Prime()")
+		}
+
+		// Priming stops when the context is cancelled.
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		f.selector.Tags[0].Name = "staging"
+		if err := f.Prime(cctx, time.Hour, 1000); err == nil {
+			t.Error("Prime() = nil, wanted an error once cancelled")
+		}
+
+		// Priming gives up on a tag that never shows up.
+		if err := f.Prime(ctx, time.Millisecond, 3); err == nil {
+			t.Error("Prime() = nil, wanted an error after the last attempt")
+		}
+	})
+}
+
+func vmEvent(ref types.ManagedObjectReference) types.BaseEvent {
+	return &types.VmPoweredOnEvent{
+		VmEvent: types.VmEvent{
+			Event: types.Event{
+				Vm: &types.VmEventArgument{Vm: ref},
+			},
+		},
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sink implements the receiver of a VSphereSink, which records the
+// CloudEvents sent to it in vCenter as events on the entities that they
+// concern.
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// EntityExtension is the CloudEvent extension naming the entity that
+	// an event is recorded on, by its inventory path (e.g.
+	// "/dc1/vm/prod/web-1") or managed object reference (e.g.
+	// "VirtualMachine:vm-42").
+	EntityExtension = "vsphereentity"
+
+	// SeverityExtension is the CloudEvent extension holding the severity
+	// ("info", "warning", "error" or "user") of the EventEx events that an
+	// event is posted as.  It defaults to "info".
+	SeverityExtension = "vsphereseverity"
+)
+
+const (
+	// RecordAsUserEvent logs events as user events.
+	RecordAsUserEvent = "UserEvent"
+
+	// RecordAsEventEx posts events as EventEx events.
+	RecordAsEventEx = "EventEx"
+)
+
+// EnvConfig is the configuration of the receiver, which the controller sets
+// from the VSphereSink's spec.
+type EnvConfig struct {
+	// Port is the port on which events are received.
+	Port int `envconfig:"PORT" default:"8080"`
+
+	// Entity is the inventory path of the entity on which the events that
+	// don't name one are recorded, or empty for the root folder.
+	Entity string `envconfig:"VSPHERE_ENTITY"`
+
+	// RecordAs is how events are recorded, RecordAsUserEvent or
+	// RecordAsEventEx.
+	RecordAs string `envconfig:"VSPHERE_RECORD_AS" default:"UserEvent"`
+}
+
+// Recorder records events in vCenter, as event.Manager does.
+type Recorder interface {
+	LogUserEvent(ctx context.Context, entity types.ManagedObjectReference, msg string) error
+	PostEvent(ctx context.Context, eventToPost types.BaseEvent, taskInfo ...types.TaskInfo) error
+}
+
+// Receiver is an http.Handler that records the CloudEvents posted to it.
+type Receiver struct {
+	Logger   *zap.SugaredLogger
+	Client   *vim25.Client
+	Recorder Recorder
+
+	// Entity is the inventory path of the entity on which the events that
+	// don't name one are recorded, or empty for the root folder.
+	Entity string
+
+	// RecordAs is how events are recorded.
+	RecordAs string
+}
+
+var _ http.Handler = (*Receiver)(nil)
+
+// badEventError is returned for events that can't be recorded however
+// often they are sent.
+type badEventError struct {
+	error
+}
+
+func badEvent(format string, args ...interface{}) error {
+	return &badEventError{fmt.Errorf(format, args...)}
+}
+
+// notFoundError is returned for events about entities that don't exist.
+type notFoundError struct {
+	error
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &notFoundError{fmt.Errorf(format, args...)}
+}
+
+// IsNotFound returns whether the error is about an entity that doesn't
+// exist, either as FindEntity reports it or as vCenter does.
+func IsNotFound(err error) bool {
+	var nf *notFoundError
+	if errors.As(err, &nf) {
+		return true
+	}
+	if !soap.IsSoapFault(err) {
+		return false
+	}
+	_, ok := soap.ToSoapFault(err).VimFault().(types.ManagedObjectNotFound)
+	return ok
+}
+
+// ServeHTTP implements http.Handler
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+	event, err := binding.ToEvent(ctx, cehttp.NewMessageFromHttpRequest(req))
+	if err == nil {
+		err = event.Validate()
+	}
+	if err != nil {
+		r.Logger.Infow("Rejecting malformed event", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := r.Record(ctx, *event, req.URL.Path); err != nil {
+		var bad *badEventError
+		if errors.As(err, &bad) {
+			r.Logger.Infow("Rejecting event", zap.String("id", event.ID()), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Resending won't make the entity exist.
+		if IsNotFound(err) {
+			r.Logger.Infow("Rejecting event about a missing entity", zap.String("id", event.ID()), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		// The sender should try again later.
+		r.Logger.Errorw("Failed to record event", zap.String("id", event.ID()), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Record records the event on the entity that it names, either with
+// EntityExtension or with the given path (that of the request that it was
+// sent with), falling back on the receiver's Entity.
+func (r *Receiver) Record(ctx context.Context, event cloudevents.Event, path string) error {
+	ref, err := r.entity(ctx, event, path)
+	if err != nil {
+		return err
+	}
+	msg := Message(event)
+
+	switch r.RecordAs {
+	case RecordAsEventEx:
+		ex, err := r.eventEx(ctx, event, ref, msg)
+		if err != nil {
+			return err
+		}
+		return r.Recorder.PostEvent(ctx, ex)
+	default:
+		return r.Recorder.LogUserEvent(ctx, ref, msg)
+	}
+}
+
+// entity resolves the entity that the event is recorded on.  The path
+// may hold a managed object reference rather than an inventory path, e.g.
+// /VirtualMachine:vm-42.
+func (r *Receiver) entity(ctx context.Context, event cloudevents.Event, path string) (types.ManagedObjectReference, error) {
+	name := r.Entity
+	if path = strings.TrimSuffix(path, "/"); path != "" {
+		name = path
+		if ref := strings.TrimPrefix(path, "/"); ref != path {
+			if _, ok := parseRef(ref); ok {
+				name = ref
+			}
+		}
+	}
+	if ext, ok := event.Extensions()[EntityExtension]; ok {
+		name = fmt.Sprint(ext)
+	}
+	if name == "" {
+		return r.Client.ServiceContent.RootFolder, nil
+	}
+	return FindEntity(ctx, r.Client, name)
+}
+
+// FindEntity returns the entity with the given name, which is either an
+// inventory path or a managed object reference.  It returns an error for
+// which IsNotFound is true when there is no such entity.
+func FindEntity(ctx context.Context, client *vim25.Client, name string) (types.ManagedObjectReference, error) {
+	if !strings.HasPrefix(name, "/") {
+		ref, ok := parseRef(name)
+		if !ok {
+			return ref, badEvent("invalid entity %q: neither an inventory path nor a managed object reference", name)
+		}
+		// Check that the entity exists, so that it isn't reported as a
+		// failure to record (or act on) events about it.
+		if _, err := object.NewCommon(client, ref).ObjectName(ctx); err != nil {
+			if IsNotFound(err) {
+				return ref, notFound("no entity %s", ref)
+			}
+			return ref, err
+		}
+		return ref, nil
+	}
+	found, err := object.NewSearchIndex(client).FindByInventoryPath(ctx, name)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	if found == nil {
+		return types.ManagedObjectReference{}, notFound("no entity found at inventory path %q", name)
+	}
+	return found.Reference(), nil
+}
+
+// parseRef parses a managed object reference, e.g. VirtualMachine:vm-42.
+func parseRef(s string) (types.ManagedObjectReference, bool) {
+	var ref types.ManagedObjectReference
+	if strings.Contains(s, "/") || !ref.FromString(s) {
+		return ref, false
+	}
+	return ref, true
+}
+
+// eventEx makes the EventEx that the event is posted as.
+func (r *Receiver) eventEx(ctx context.Context, event cloudevents.Event, ref types.ManagedObjectReference, msg string) (*types.EventEx, error) {
+	severity := string(types.EventEventSeverityInfo)
+	if ext, ok := event.Extensions()[SeverityExtension]; ok {
+		severity = fmt.Sprint(ext)
+	}
+	switch types.EventEventSeverity(severity) {
+	case types.EventEventSeverityInfo, types.EventEventSeverityWarning,
+		types.EventEventSeverityError, types.EventEventSeverityUser:
+	default:
+		return nil, badEvent("invalid %s %q", SeverityExtension, severity)
+	}
+
+	name, err := object.NewCommon(r.Client, ref).ObjectName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ex := &types.EventEx{
+		Event: types.Event{
+			FullFormattedMessage: msg,
+		},
+		EventTypeId: event.Type(),
+		Severity:    severity,
+		Message:     msg,
+		ObjectId:    ref.Value,
+		ObjectType:  ref.Type,
+		ObjectName:  name,
+		Arguments: []types.KeyAnyValue{{
+			Key:   "id",
+			Value: event.ID(),
+		}, {
+			Key:   "source",
+			Value: event.Source(),
+		}},
+	}
+	if subject := event.Subject(); subject != "" {
+		ex.Arguments = append(ex.Arguments, types.KeyAnyValue{Key: "subject", Value: subject})
+	}
+
+	// Put the event on the timeline of its entity.
+	arg := types.EntityEventArgument{Name: name}
+	switch ref.Type {
+	case "VirtualMachine":
+		ex.Vm = &types.VmEventArgument{EntityEventArgument: arg, Vm: ref}
+	case "HostSystem":
+		ex.Host = &types.HostEventArgument{EntityEventArgument: arg, Host: ref}
+	case "ClusterComputeResource", "ComputeResource":
+		ex.ComputeResource = &types.ComputeResourceEventArgument{EntityEventArgument: arg, ComputeResource: ref}
+	case "Datacenter":
+		ex.Datacenter = &types.DatacenterEventArgument{EntityEventArgument: arg, Datacenter: ref}
+	case "Datastore":
+		ex.Ds = &types.DatastoreEventArgument{EntityEventArgument: arg, Datastore: ref}
+	case "Network", "DistributedVirtualPortgroup", "OpaqueNetwork":
+		ex.Net = &types.NetworkEventArgument{EntityEventArgument: arg, Network: ref}
+	}
+	return ex, nil
+}
+
+// Message returns the message that the event is recorded with: the
+// "message" field of its data, when that is a JSON object with one, or
+// else its data when that is text (or a JSON string), or else its type and
+// source.
+func Message(event cloudevents.Event) string {
+	data := event.Data()
+	var obj struct {
+		Message *string `json:"message"`
+	}
+	if json.Unmarshal(data, &obj) == nil && obj.Message != nil {
+		return *obj.Message
+	}
+	var str string
+	if json.Unmarshal(data, &str) == nil {
+		return str
+	}
+	if len(data) > 0 && utf8.Valid(data) && !json.Valid(data) {
+		return string(data)
+	}
+	msg := fmt.Sprintf("%s from %s", event.Type(), event.Source())
+	if subject := event.Subject(); subject != "" {
+		msg += " about " + subject
+	}
+	return msg
+}
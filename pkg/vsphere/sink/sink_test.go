@@ -0,0 +1,324 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/zap"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/vspheretest"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		data        string
+		subject     string
+		want        string
+	}{{
+		name:        "message field",
+		contentType: cloudevents.ApplicationJSON,
+		data:        `{"message": "deployed v1.2.3", "commit": "abc123"}`,
+		want:        "deployed v1.2.3",
+	}, {
+		name:        "json string",
+		contentType: cloudevents.ApplicationJSON,
+		data:        `"deployed v1.2.3"`,
+		want:        "deployed v1.2.3",
+	}, {
+		name:        "text",
+		contentType: cloudevents.TextPlain,
+		data:        "deployed v1.2.3",
+		want:        "deployed v1.2.3",
+	}, {
+		name:        "json without a message",
+		contentType: cloudevents.ApplicationJSON,
+		data:        `{"commit": "abc123"}`,
+		want:        "dev.example.deployed from https://ci.example.com",
+	}, {
+		name: "no data",
+		want: "dev.example.deployed from https://ci.example.com",
+	}, {
+		name:    "no data, with subject",
+		subject: "web-1",
+		want:    "dev.example.deployed from https://ci.example.com about web-1",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := cloudevents.NewEvent()
+			event.SetType("dev.example.deployed")
+			event.SetSource("https://ci.example.com")
+			event.SetSubject(test.subject)
+			if test.data != "" {
+				event.SetData(test.contentType, []byte(test.data))
+			}
+			if got := Message(event); got != test.want {
+				t.Errorf("Message() = %q, wanted %q", got, test.want)
+			}
+		})
+	}
+}
+
+// simRecorder records events in the simulator, which doesn't implement
+// LogUserEvent, so it posts the GeneralUserEvent that vCenter logs instead.
+type simRecorder struct {
+	*event.Manager
+}
+
+func (sr simRecorder) LogUserEvent(ctx context.Context, entity types.ManagedObjectReference, msg string) error {
+	return sr.PostEvent(ctx, &types.GeneralUserEvent{
+		GeneralEvent: types.GeneralEvent{
+			Event: types.Event{
+				FullFormattedMessage: "User logged event: " + msg,
+			},
+			Message: msg,
+		},
+		Entity: &types.ManagedEntityEventArgument{
+			Entity: entity,
+		},
+	})
+}
+
+// failingRecorder fails to record anything.
+type failingRecorder struct{}
+
+func (failingRecorder) LogUserEvent(context.Context, types.ManagedObjectReference, string) error {
+	return errors.New("vCenter is down")
+}
+
+func (failingRecorder) PostEvent(context.Context, types.BaseEvent, ...types.TaskInfo) error {
+	return errors.New("vCenter is down")
+}
+
+func TestReceiver(t *testing.T) {
+	ctx := context.Background()
+	sim := vspheretest.New(t)
+	defer sim.Close()
+
+	root := sim.Client.ServiceContent.RootFolder
+	vm0 := sim.VM(ctx, "DC0_H0_VM0").Reference()
+	vm1 := sim.VM(ctx, "DC0_H0_VM1").Reference()
+
+	tests := []struct {
+		name     string
+		entity   string
+		recordAs string
+		recorder Recorder
+		path     string
+		header   http.Header
+		want     int
+		check    func(*testing.T, types.BaseEvent)
+	}{{
+		name: "user event on the root folder",
+		want: http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, root, "deployed v1.2.3")
+		},
+	}, {
+		name:   "user event on the sink's entity",
+		entity: "/DC0/vm/DC0_H0_VM0",
+		want:   http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, vm0, "deployed v1.2.3")
+		},
+	}, {
+		name:   "user event on the request's path",
+		entity: "/DC0/vm/DC0_H0_VM0",
+		path:   "/DC0/vm/DC0_H0_VM1",
+		want:   http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, vm1, "deployed v1.2.3")
+		},
+	}, {
+		name:   "user event on the extension's inventory path",
+		path:   "/DC0/vm/DC0_H0_VM0",
+		header: http.Header{"Ce-Vsphereentity": {"/DC0/vm/DC0_H0_VM1"}},
+		want:   http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, vm1, "deployed v1.2.3")
+		},
+	}, {
+		name:   "user event on the extension's reference",
+		header: http.Header{"Ce-Vsphereentity": {vm0.String()}},
+		want:   http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, vm0, "deployed v1.2.3")
+		},
+	}, {
+		name: "user event on the path's reference",
+		path: "/" + vm1.String(),
+		want: http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			wantUserEvent(t, be, vm1, "deployed v1.2.3")
+		},
+	}, {
+		name:     "eventex",
+		recordAs: RecordAsEventEx,
+		header: http.Header{
+			"Ce-Vsphereentity":   {"/DC0/vm/DC0_H0_VM0"},
+			"Ce-Vsphereseverity": {"warning"},
+			"Ce-Subject":         {"web"},
+		},
+		want: http.StatusAccepted,
+		check: func(t *testing.T, be types.BaseEvent) {
+			ex, ok := be.(*types.EventEx)
+			if !ok {
+				t.Fatalf("recorded %T, wanted *types.EventEx", be)
+			}
+			if got, want := ex.EventTypeId, "dev.example.deployed"; got != want {
+				t.Errorf("EventTypeId = %q, wanted %q", got, want)
+			}
+			if got, want := ex.Severity, "warning"; got != want {
+				t.Errorf("Severity = %q, wanted %q", got, want)
+			}
+			if got, want := ex.Message, "deployed v1.2.3"; got != want {
+				t.Errorf("Message = %q, wanted %q", got, want)
+			}
+			if ex.ObjectId != vm0.Value || ex.ObjectType != vm0.Type || ex.ObjectName != "DC0_H0_VM0" {
+				t.Errorf("object = %s:%s (%s), wanted %s (DC0_H0_VM0)", ex.ObjectType, ex.ObjectId, ex.ObjectName, vm0)
+			}
+			if ex.Vm == nil || ex.Vm.Vm != vm0 {
+				t.Errorf("Vm = %v, wanted %v", ex.Vm, vm0)
+			}
+			want := []types.KeyAnyValue{
+				{Key: "id", Value: "1234"},
+				{Key: "source", Value: "https://ci.example.com"},
+				{Key: "subject", Value: "web"},
+			}
+			if !cmp.Equal(want, ex.Arguments) {
+				t.Errorf("Arguments (-want, +got) = %s", cmp.Diff(want, ex.Arguments))
+			}
+		},
+	}, {
+		name:     "eventex with an invalid severity",
+		recordAs: RecordAsEventEx,
+		header:   http.Header{"Ce-Vsphereseverity": {"catastrophic"}},
+		want:     http.StatusBadRequest,
+	}, {
+		name: "no entity at the path",
+		path: "/DC0/vm/nope",
+		want: http.StatusNotFound,
+	}, {
+		name: "no entity with the path's reference",
+		path: "/VirtualMachine:vm-nope",
+		want: http.StatusNotFound,
+	}, {
+		name:   "no entity with the extension's reference",
+		header: http.Header{"Ce-Vsphereentity": {"VirtualMachine:vm-nope"}},
+		want:   http.StatusNotFound,
+	}, {
+		name:   "invalid entity",
+		header: http.Header{"Ce-Vsphereentity": {"nope"}},
+		want:   http.StatusBadRequest,
+	}, {
+		name:   "not a cloudevent",
+		header: http.Header{"Ce-Specversion": nil},
+		want:   http.StatusBadRequest,
+	}, {
+		name:     "vCenter fails",
+		recorder: failingRecorder{},
+		want:     http.StatusBadGateway,
+	}}
+
+	manager := event.NewManager(sim.Client.Client)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Receiver{
+				Logger:   zap.NewNop().Sugar(),
+				Client:   sim.Client.Client,
+				Recorder: test.recorder,
+				Entity:   test.entity,
+				RecordAs: test.recordAs,
+			}
+			if r.Recorder == nil {
+				r.Recorder = simRecorder{manager}
+			}
+			if r.RecordAs == "" {
+				r.RecordAs = RecordAsUserEvent
+			}
+			server := httptest.NewServer(r)
+			defer server.Close()
+
+			header := http.Header{
+				"Ce-Specversion": {"1.0"},
+				"Ce-Id":          {"1234"},
+				"Ce-Type":        {"dev.example.deployed"},
+				"Ce-Source":      {"https://ci.example.com"},
+				"Content-Type":   {"application/json"},
+			}
+			for key, values := range test.header {
+				if values == nil {
+					header.Del(key)
+				} else {
+					header[key] = values
+				}
+			}
+			body := strings.NewReader(`{"message": "deployed v1.2.3"}`)
+			req, err := http.NewRequest(http.MethodPost, server.URL+test.path, body)
+			if err != nil {
+				t.Fatalf("NewRequest() = %v", err)
+			}
+			req.Header = header
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do() = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.want {
+				t.Fatalf("StatusCode = %d, wanted %d", resp.StatusCode, test.want)
+			}
+
+			if test.check != nil {
+				// Events come back newest first.
+				events, err := manager.QueryEvents(ctx, types.EventFilterSpec{})
+				if err != nil {
+					t.Fatalf("QueryEvents() = %v", err)
+				}
+				if len(events) == 0 {
+					t.Fatal("no events were recorded")
+				}
+				test.check(t, events[0])
+			}
+		})
+	}
+}
+
+func wantUserEvent(t *testing.T, be types.BaseEvent, entity types.ManagedObjectReference, msg string) {
+	t.Helper()
+	ue, ok := be.(*types.GeneralUserEvent)
+	if !ok {
+		t.Fatalf("recorded %T, wanted *types.GeneralUserEvent", be)
+	}
+	if ue.Entity == nil || ue.Entity.Entity != entity {
+		t.Errorf("Entity = %v, wanted %v", ue.Entity, entity)
+	}
+	if ue.Message != msg {
+		t.Errorf("Message = %q, wanted %q", ue.Message, msg)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/metrics/metricskey"
+)
+
+var (
+	// eventsFilteredM is a counter which records the number of events
+	// dropped by the adapter rather than being sent.
+	eventsFilteredM = stats.Int64(
+		"events_filtered_count",
+		"Number of events dropped by the source's filters",
+		stats.UnitDimensionless,
+	)
+
+	// expressionErrorsM is a counter which records the number of events
+	// dropped because the source's expressions failed to evaluate.
+	expressionErrorsM = stats.Int64(
+		"expression_errors_count",
+		"Number of events dropped because an expression failed to evaluate",
+		stats.UnitDimensionless,
+	)
+
+	eventTypeKey  = tag.MustNewKey(metricskey.LabelEventType)
+	reasonKey     = tag.MustNewKey("reason")
+	expressionKey = tag.MustNewKey("expression")
+)
+
+const (
+	// filterReasonSelector is the reason recorded for events whose
+	// entity didn't match the source's selector.
+	filterReasonSelector = "selector"
+
+	// filterReasonFilter is the reason recorded for events for which the
+	// source's filter expression evaluated to false.
+	filterReasonFilter = "filter"
+)
+
+func init() {
+	if err := view.Register(&view.View{
+		Description: eventsFilteredM.Description(),
+		Measure:     eventsFilteredM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{eventTypeKey, reasonKey},
+	}, &view.View{
+		Description: expressionErrorsM.Description(),
+		Measure:     expressionErrorsM,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{eventTypeKey, expressionKey},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// reportFiltered records that an event of the given type was dropped
+// for the given reason.
+func reportFiltered(ctx context.Context, eventType, reason string) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(eventTypeKey, eventType),
+		tag.Insert(reasonKey, reason))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, eventsFilteredM.M(1))
+}
+
+// reportExpressionError records that an event of the given type was dropped
+// because the named expression failed to evaluate.
+func reportExpressionError(ctx context.Context, eventType, expression string) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(eventTypeKey, eventType),
+		tag.Insert(expressionKey, expression))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, expressionErrorsM.M(1))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	"github.com/vmware/govmomi"
+	"knative.dev/pkg/logging"
+)
+
+// TailOptions configure Tail.
+type TailOptions struct {
+	// Source is the CloudEvent source of the events, which the adapter
+	// sets to the vCenter's address.
+	Source string
+
+	// Filter is a CEL filter expression, as in a VSphereSource's filter.
+	Filter string
+
+	// Types restricts the events to those of the given vSphere event types,
+	// e.g. "VmPoweredOnEvent", when it isn't empty.
+	Types []string
+
+	// Scope is the inventory path of the entity whose events (and those of
+	// the entities beneath it) are sent, as in a VSphereSource's scope.
+	Scope string
+
+	// Since is when to start sending events from.  When it is zero, only
+	// the events that happen from now on are sent.
+	Since time.Time
+
+	// Record receives the events (before they are filtered), as
+	// RecordedEvent JSON lines, when it isn't nil.
+	Record io.Writer
+
+	// Replay is a recording that is played back in place of the vCenter's
+	// events when it isn't nil, at ReplaySpeed times the original pace (or
+	// without pausing when ReplaySpeed is zero).  The client isn't used.
+	Replay      io.Reader
+	ReplaySpeed float64
+}
+
+// filter returns the CEL filter expression combining Filter and Types.
+func (opts *TailOptions) filter() string {
+	var clauses []string
+	if opts.Filter != "" {
+		clauses = append(clauses, "("+opts.Filter+")")
+	}
+	if len(opts.Types) > 0 {
+		quoted := make([]string, 0, len(opts.Types))
+		for _, t := range opts.Types {
+			quoted = append(quoted, strconv.Quote(t))
+		}
+		clauses = append(clauses, fmt.Sprintf("%s in [%s]", expr.EventTypeVar, strings.Join(quoted, ", ")))
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// Tail sends the events of the vCenter that the client is connected to
+// through ceClient until the context is cancelled (or the end of the
+// recording being replayed), converting and filtering them exactly as the
+// adapter does.  Unlike the adapter, it doesn't keep a
+// checkpoint or report back to a controller.
+func Tail(ctx context.Context, client *govmomi.Client, ceClient cloudevents.Client, opts TailOptions) error {
+	a := &vAdapter{
+		Logger:   logging.FromContext(ctx),
+		Source:   opts.Source,
+		VClient:  client,
+		CEClient: ceClient,
+		KVStore:  newMemoryKVStore(),
+		Scope:    opts.Scope,
+	}
+	if filter := opts.filter(); filter != "" {
+		f, err := expr.NewFilter(filter)
+		if err != nil {
+			return fmt.Errorf("failed to compile filter: %w", err)
+		}
+		a.EventFilter = f
+	}
+	if opts.Record != nil {
+		a.Recorder = newRecorder(opts.Record)
+	}
+	if opts.Replay != nil {
+		a.Replay = newReplayer(opts.Replay, opts.ReplaySpeed)
+	}
+	if !opts.Since.IsZero() {
+		// Replay the events since then, as though we had handled the one
+		// right before them.
+		a.Checkpoint = &Checkpoint{LastEventTime: opts.Since}
+	}
+	return a.run(ctx)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/expr"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestTailOptionsFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TailOptions
+		want string
+	}{{
+		name: "nothing",
+	}, {
+		name: "filter",
+		opts: TailOptions{Filter: `event.UserName == "root"`},
+		want: `(event.UserName == "root")`,
+	}, {
+		name: "types",
+		opts: TailOptions{Types: []string{"VmPoweredOnEvent", "VmPoweredOffEvent"}},
+		want: `eventType in ["VmPoweredOnEvent", "VmPoweredOffEvent"]`,
+	}, {
+		name: "both",
+		opts: TailOptions{
+			Filter: `event.UserName == "root" || true`,
+			Types:  []string{"VmPoweredOnEvent"},
+		},
+		want: `(event.UserName == "root" || true) && eventType in ["VmPoweredOnEvent"]`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.opts.filter(); got != test.want {
+				t.Errorf("filter() = %s, wanted %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTailOptionsFilterMatches(t *testing.T) {
+	opts := TailOptions{Types: []string{"VmPoweredOnEvent"}}
+	f, err := expr.NewFilter(opts.filter())
+	if err != nil {
+		t.Fatalf("NewFilter() = %v", err)
+	}
+
+	for be, want := range map[types.BaseEvent]bool{
+		&types.VmPoweredOnEvent{}:  true,
+		&types.VmPoweredOffEvent{}: false,
+	} {
+		in, err := expr.NewInput(be)
+		if err != nil {
+			t.Fatalf("NewInput() = %v", err)
+		}
+		if got, err := f.Matches(in); err != nil {
+			t.Errorf("Matches(%T) = %v", be, err)
+		} else if got != want {
+			t.Errorf("Matches(%T) = %v, wanted %v", be, got, want)
+		}
+	}
+}
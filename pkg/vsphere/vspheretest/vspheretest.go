@@ -0,0 +1,264 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vspheretest runs govmomi's vCenter simulator in-process for unit
+// tests, and sets up the environment that vsphere.New expects, as the
+// VSphereBinding does in the cluster.  Tests can write:
+//
+//	sim := vspheretest.New(t)
+//	defer sim.Close()
+//
+//	client, err := vsphere.New(ctx)
+//	...
+//	vm := sim.CreateVM(ctx, "my-vm")
+//	sim.PowerOn(ctx, vm)
+//
+// The simulator keeps its inventory in a global, so a test binary may only
+// run one at a time, and tests using it must not be run in parallel.
+package vspheretest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+const (
+	// Username is the user that the simulator accepts.
+	Username = "administrator@vsphere.local"
+
+	// Password is the password that the simulator accepts.
+	Password = "vspheretest"
+)
+
+// Simulator is a simulated vCenter, with the environment pointed at it.
+type Simulator struct {
+	// Model is the simulated inventory: a datacenter (DC0), with a
+	// standalone host and a cluster of three, each running two VMs.
+	Model *simulator.Model
+
+	// Server serves the simulated vCenter's API.
+	Server *simulator.Server
+
+	// Client is logged into the simulator.
+	Client *govmomi.Client
+
+	// URL is the simulator's address, without credentials, as GOVC_URL is
+	// set.
+	URL string
+
+	// Dir holds the credentials in place of vsphere.MountPath.
+	Dir string
+
+	t       testing.TB
+	restore map[string]*string
+}
+
+// New starts a simulator and points GOVC_URL, GOVC_INSECURE and the
+// credentials that vsphere.New reads at it, until Close is called.
+func New(t testing.TB) *Simulator {
+	t.Helper()
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "vspheretest")
+	if err != nil {
+		t.Fatalf("TempDir() = %v", err)
+	}
+	s := &Simulator{
+		Model:   simulator.VPX(),
+		Dir:     dir,
+		t:       t,
+		restore: make(map[string]*string, 3),
+	}
+	// Clean up whatever we got to if we don't make it to the end.
+	ok := false
+	defer func() {
+		if !ok {
+			s.Close()
+		}
+	}()
+
+	for key, value := range map[string]string{
+		corev1.BasicAuthUsernameKey: Username,
+		corev1.BasicAuthPasswordKey: Password,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, key), []byte(value), 0600); err != nil {
+			t.Fatalf("WriteFile(%s) = %v", key, err)
+		}
+	}
+
+	if err := s.Model.Create(); err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	s.Model.Service.Listen = &url.URL{User: url.UserPassword(Username, Password)}
+	// Serve the endpoints (e.g. the vAPI for tags) of the simulator
+	// packages that the test imports.
+	s.Model.Service.RegisterEndpoints = true
+	s.Server = s.Model.Service.NewServer()
+
+	u := *s.Server.URL
+	u.User = nil
+	s.URL = u.String()
+	s.setenv("GOVC_URL", s.URL)
+	s.setenv("GOVC_INSECURE", "true")
+	s.setenv(vsphere.MountPathEnv, dir)
+
+	s.Client, err = vsphere.New(ctx)
+	if err != nil {
+		t.Fatalf("vsphere.New() = %v", err)
+	}
+	ok = true
+	return s
+}
+
+// Close stops the simulator, and restores the environment.
+func (s *Simulator) Close() {
+	if s.Client != nil {
+		s.Client.Logout(context.Background())
+	}
+	if s.Server != nil {
+		s.Server.Close()
+	}
+	s.Model.Remove()
+	for key, value := range s.restore {
+		if value == nil {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, *value)
+		}
+	}
+	os.RemoveAll(s.Dir)
+}
+
+// setenv sets the environment variable until Close.
+func (s *Simulator) setenv(key, value string) {
+	if _, ok := s.restore[key]; !ok {
+		if old, ok := os.LookupEnv(key); ok {
+			s.restore[key] = &old
+		} else {
+			s.restore[key] = nil
+		}
+	}
+	os.Setenv(key, value)
+}
+
+// Finder returns a finder for the simulator's inventory, scoped to its
+// datacenter.
+func (s *Simulator) Finder(ctx context.Context) *find.Finder {
+	s.t.Helper()
+	f := find.NewFinder(s.Client.Client, true)
+	dc, err := f.DefaultDatacenter(ctx)
+	if err != nil {
+		s.t.Fatalf("DefaultDatacenter() = %v", err)
+	}
+	return f.SetDatacenter(dc)
+}
+
+// VM returns the virtual machine with the given name (or inventory path),
+// e.g. one of the simulator's own, like DC0_H0_VM0.
+func (s *Simulator) VM(ctx context.Context, name string) *object.VirtualMachine {
+	s.t.Helper()
+	vm, err := s.Finder(ctx).VirtualMachine(ctx, name)
+	if err != nil {
+		s.t.Fatalf("VirtualMachine(%s) = %v", name, err)
+	}
+	return vm
+}
+
+// CreateVM creates a virtual machine on the simulator's standalone host,
+// which posts a VmCreatedEvent (among others).
+func (s *Simulator) CreateVM(ctx context.Context, name string) *object.VirtualMachine {
+	s.t.Helper()
+	f := s.Finder(ctx)
+	folder, err := f.DefaultFolder(ctx)
+	if err != nil {
+		s.t.Fatalf("DefaultFolder() = %v", err)
+	}
+	host, err := f.HostSystem(ctx, "DC0_H0")
+	if err != nil {
+		s.t.Fatalf("HostSystem() = %v", err)
+	}
+	pool, err := host.ResourcePool(ctx)
+	if err != nil {
+		s.t.Fatalf("ResourcePool() = %v", err)
+	}
+
+	task, err := folder.CreateVM(ctx, types.VirtualMachineConfigSpec{
+		Name:    name,
+		GuestId: string(types.VirtualMachineGuestOsIdentifierOtherGuest),
+		Files: &types.VirtualMachineFileInfo{
+			VmPathName: "[LocalDS_0]",
+		},
+	}, pool, host)
+	if err != nil {
+		s.t.Fatalf("CreateVM(%s) = %v", name, err)
+	}
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		s.t.Fatalf("CreateVM(%s) = %v", name, err)
+	}
+	return object.NewVirtualMachine(s.Client.Client, info.Result.(types.ManagedObjectReference))
+}
+
+// PowerOn powers the virtual machine on, which posts a VmPoweredOnEvent.
+func (s *Simulator) PowerOn(ctx context.Context, vm *object.VirtualMachine) {
+	s.t.Helper()
+	s.wait(ctx, "PowerOn", vm.Name(), func() (*object.Task, error) {
+		return vm.PowerOn(ctx)
+	})
+}
+
+// PowerOff powers the virtual machine off, which posts a
+// VmPoweredOffEvent.
+func (s *Simulator) PowerOff(ctx context.Context, vm *object.VirtualMachine) {
+	s.t.Helper()
+	s.wait(ctx, "PowerOff", vm.Name(), func() (*object.Task, error) {
+		return vm.PowerOff(ctx)
+	})
+}
+
+// Reconfigure applies the spec to the virtual machine, which posts a
+// VmReconfiguredEvent.
+func (s *Simulator) Reconfigure(ctx context.Context, vm *object.VirtualMachine, spec types.VirtualMachineConfigSpec) {
+	s.t.Helper()
+	s.wait(ctx, "Reconfigure", vm.Name(), func() (*object.Task, error) {
+		return vm.Reconfigure(ctx, spec)
+	})
+}
+
+// wait starts the task and waits for it to complete.
+func (s *Simulator) wait(ctx context.Context, what, name string, start func() (*object.Task, error)) {
+	s.t.Helper()
+	task, err := start()
+	if err != nil {
+		s.t.Fatalf("%s(%s) = %v", what, name, err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		s.t.Fatalf("%s(%s) = %v", what, name, err)
+	}
+}
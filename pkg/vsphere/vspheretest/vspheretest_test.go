@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vspheretest
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+)
+
+func TestSimulator(t *testing.T) {
+	os.Setenv("GOVC_URL", "https://elsewhere.local/sdk")
+	defer os.Unsetenv("GOVC_URL")
+
+	ctx := context.Background()
+	sim := New(t)
+
+	// vsphere.New connects to the simulator, with the credentials.
+	client, err := vsphere.New(ctx)
+	if err != nil {
+		t.Fatalf("vsphere.New() = %v", err)
+	}
+	defer client.Logout(ctx)
+	if got, err := vsphere.Address(ctx); err != nil || got != sim.URL {
+		t.Errorf("Address() = %q, %v, wanted %q", got, err, sim.URL)
+	}
+
+	vm := sim.CreateVM(ctx, "vspheretest")
+	sim.PowerOn(ctx, vm)
+	sim.Reconfigure(ctx, vm, types.VirtualMachineConfigSpec{Annotation: "hello"})
+	sim.PowerOff(ctx, vm)
+	if got := sim.VM(ctx, "vspheretest"); got.Reference() != vm.Reference() {
+		t.Errorf("VM() = %v, wanted %v", got.Reference(), vm.Reference())
+	}
+
+	events, err := event.NewManager(client.Client).QueryEvents(ctx, types.EventFilterSpec{
+		Entity: &types.EventFilterSpecByEntity{
+			Entity:    vm.Reference(),
+			Recursion: types.EventFilterSpecRecursionOptionSelf,
+		},
+	})
+	if err != nil {
+		t.Fatalf("QueryEvents() = %v", err)
+	}
+	// Events come back newest first.
+	got := make([]string, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		got = append(got, reflect.TypeOf(events[i]).Elem().Name())
+	}
+	want := []string{
+		"VmBeingCreatedEvent", "VmInstanceUuidAssignedEvent", "VmUuidAssignedEvent", "VmCreatedEvent",
+		"VmStartingEvent", "VmPoweredOnEvent",
+		"VmReconfiguredEvent",
+		"VmStoppingEvent", "VmPoweredOffEvent",
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("events (-want, +got) = %s", cmp.Diff(want, got))
+	}
+
+	// Close puts the environment back the way it was.
+	sim.Close()
+	if got := os.Getenv("GOVC_URL"); got != "https://elsewhere.local/sdk" {
+		t.Errorf("GOVC_URL = %q after Close", got)
+	}
+	if _, ok := os.LookupEnv(vsphere.MountPathEnv); ok {
+		t.Errorf("%s is still set after Close", vsphere.MountPathEnv)
+	}
+	if _, err := os.Stat(sim.Dir); !os.IsNotExist(err) {
+		t.Errorf("Stat(%s) = %v, wanted not found", sim.Dir, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/mattmoor/vmware-sources/pkg/vsphere"
+	"github.com/mattmoor/vmware-sources/pkg/vsphere/events"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -29,15 +30,14 @@ func main() {
 	}
 	r := &receiver{manager: tags.NewManager(client)}
 
-	if err := ceclient.StartReceiver(ctx, r.handle); err != nil {
+	router := events.NewRouter()
+	router.OnVmCreated(r.handle)
+
+	if err := ceclient.StartReceiver(ctx, router.Receive); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func (r *receiver) handle(ctx context.Context, event cloudevents.Event) error {
-	req := &types.VmCreatedEvent{}
-	if err := event.DataAs(&req); err != nil {
-		return err
-	}
-	return r.manager.AttachTag(ctx, "shrug", req.Vm.Vm)
+func (r *receiver) handle(ctx context.Context, event *types.VmCreatedEvent) error {
+	return r.manager.AttachTag(ctx, "shrug", event.Vm.Vm)
 }